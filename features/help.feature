@@ -17,8 +17,11 @@ Feature: help command
         clean       Removes dangling Docker images and volumes
         configure   Configures secrets for an Exosphere application deployed to the cloud
         deploy      Deploys Exosphere application to the cloud
+        docs        Generates documentation for the application
         generate    Generates docker-compose and terraform files
+        graph       Prints a graph of the services, messages and dependencies of the application
         init        Initializes a new Exosphere application
+        lint        Checks the application for cross-service inconsistencies
         run         Runs an Exosphere application
         template    Manage service templates
         test        Runs tests for the application
@@ -23,3 +23,23 @@ Feature: testing an Exosphere application
       | Testing service 'tweets-service' |
       | The following tests failed:      |
     And it exits with code 1
+
+
+  Scenario: verifying the message contracts
+    Given I am in the root directory of the "message-contracts" example application
+    When starting "exo test" in my application directory
+    Then I eventually see the following snippets:
+      | End-to-end tests passed                              |
+      | Message contracts                                    |
+      | users: ok                                            |
+      | web: never received declared message 'users.created' |
+    And it exits with code 0
+
+
+  Scenario: failing the tests on message contract violations
+    Given I am in the root directory of the "message-contracts" example application
+    When starting "exo test --strict-contracts" in my application directory
+    Then I eventually see the following snippets:
+      | End-to-end tests passed                              |
+      | web: never received declared message 'users.created' |
+    And it exits with code 1
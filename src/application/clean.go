@@ -16,9 +16,12 @@ import (
 func CleanContainers(appContext *context.AppContext, writer io.Writer) error {
 	for _, dockerComposeFileName := range types.GetComposeFileNames() {
 		var composeProjectName string
-		if dockerComposeFileName == types.LocalTestComposeFileName {
+		switch dockerComposeFileName {
+		case types.LocalTestComposeFileName:
 			composeProjectName = composebuilder.GetTestDockerComposeProjectName(appContext.Config.Name)
-		} else {
+		case types.LocalE2EComposeFileName:
+			composeProjectName = composebuilder.GetE2EDockerComposeProjectName(appContext.Config.Name)
+		default:
 			composeProjectName = composebuilder.GetDockerComposeProjectName(appContext.Config.Name)
 		}
 		err := killIfExists(appContext.Location, dockerComposeFileName, composeProjectName, writer)
@@ -7,6 +7,7 @@ import (
 	"github.com/Originate/exosphere/src/terraform"
 	"github.com/Originate/exosphere/src/types"
 	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/pkg/errors"
 )
 
 // StartDeploy starts the deployment process
@@ -71,6 +72,18 @@ func validateConfigs(deployConfig deploy.Config) error {
 			return err
 		}
 	}
+	serviceRemoteConfigs := map[string]types.ServiceRemoteConfig{}
+	for serviceRole, serviceContext := range deployConfig.AppContext.ServiceContexts {
+		serviceRemoteConfigs[serviceRole] = serviceContext.Config.Remote
+	}
+	err = deployConfig.AppContext.Config.Remote.Cluster.ValidateCapacity(serviceRemoteConfigs)
+	if err != nil {
+		return err
+	}
+	err = types.ValidateServiceRoutes(deployConfig.AppContext.Config.Remote.URL, serviceRemoteConfigs)
+	if err != nil {
+		return err
+	}
 	fmt.Fprintln(deployConfig.Writer, "Validating application dependencies...")
 	validatedDependencies := map[string]string{}
 	for _, dependency := range deployConfig.AppContext.Config.Remote.Dependencies {
@@ -103,6 +116,43 @@ func deployApplication(deployConfig deploy.Config, imagesMap map[string]string,
 		return err
 	}
 
-	fmt.Fprintln(deployConfig.Writer, "Applying changes...")
-	return terraform.RunApply(deployConfig, secrets, imagesMap, deployConfig.AutoApprove)
+	err = runMigrations(deployConfig, imagesMap, secrets)
+	if err != nil {
+		return err
+	}
+
+	err = applyChanges(deployConfig, secrets, imagesMap)
+	if err != nil {
+		return err
+	}
+	_, err = recordDeploy(deployConfig, imagesMap, secrets, "")
+	return err
+}
+
+// runs the migrations of all services that declare them as one-off ECS tasks with the new images,
+// before the services are updated. Any failing migration aborts the deploy
+func runMigrations(deployConfig deploy.Config, imagesMap map[string]string, secrets types.Secrets) error {
+	serviceRoles := deployConfig.AppContext.GetServiceRolesWithMigrations()
+	if len(serviceRoles) == 0 {
+		return nil
+	}
+	fmt.Fprintln(deployConfig.Writer, "Applying changes needed by the migrations...")
+	targets, err := terraform.GetMigrationsTargets(deployConfig)
+	if err != nil {
+		return err
+	}
+	err = terraform.RunApply(deployConfig, secrets, imagesMap, deployConfig.AutoApprove, targets...)
+	if err != nil {
+		return errors.Wrap(err, "Failed to apply the changes needed by the migrations")
+	}
+	clusterName := terraform.GetClusterName(deployConfig.AppContext.Config.Name)
+	for _, serviceRole := range serviceRoles {
+		fmt.Fprintf(deployConfig.Writer, "Running migrations of %s...\n", serviceRole)
+		migrations := deployConfig.AppContext.ServiceContexts[serviceRole].Config.Migrations
+		err = aws.RunTask(deployConfig.AwsConfig, clusterName, terraform.GetMigrationsTaskDefinitionFamily(serviceRole), migrations.GetTimeout())
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("The migrations of %s failed", serviceRole))
+		}
+	}
+	return nil
 }
@@ -0,0 +1,153 @@
+package deployer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Originate/exosphere/src/aws"
+	"github.com/Originate/exosphere/src/terraform"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/pkg/errors"
+)
+
+// GetDeployHistory returns the manifests of the successful deploys, from the newest to the oldest
+func GetDeployHistory(deployConfig deploy.Config) ([]deploy.Manifest, error) {
+	return aws.ReadDeployManifests(deployConfig.AwsConfig)
+}
+
+// Rollback re-applies the images recorded in the manifest of the deploy with the given ID,
+// without building or pushing any images. Dependencies and the Terraform configuration
+// are not rolled back, and neither are database migrations
+func Rollback(deployConfig deploy.Config, id string) error {
+	fmt.Fprintf(deployConfig.Writer, "Reading deploy %s...\n", id)
+	manifest, err := aws.ReadDeployManifest(deployConfig.AwsConfig, id)
+	if err != nil {
+		return err
+	}
+	imageNames, err := getRequiredImageNames(deployConfig)
+	if err != nil {
+		return err
+	}
+	missingImageNames := GetMissingImageNames(manifest, imageNames)
+	if len(missingImageNames) > 0 {
+		return fmt.Errorf("Deploy %s has no images for %s, which have been added since", id, strings.Join(missingImageNames, ", "))
+	}
+	fmt.Fprintln(deployConfig.Writer, "Checking images in ECR...")
+	for _, name := range imageNames {
+		hasImage, err := aws.HasImage(deployConfig.AwsConfig, manifest.Images[name])
+		if err != nil {
+			return err
+		}
+		if !hasImage {
+			return fmt.Errorf("The image %s of %s no longer exists in ECR", manifest.Images[name], name)
+		}
+	}
+	for _, change := range GetDependencyChanges(manifest, getDependencyVersions(deployConfig.AppContext)) {
+		fmt.Fprintf(deployConfig.Writer, "Warning: %s. Dependencies are not rolled back\n", change)
+	}
+	err = terraform.GenerateCheck(deployConfig)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(deployConfig.Writer, "Retrieving secrets...")
+	secrets, err := aws.ReadSecrets(deployConfig.AwsConfig)
+	if err != nil {
+		return err
+	}
+	varsHash, err := terraform.GetVarsHash(deployConfig, secrets, manifest.Images)
+	if err != nil {
+		return err
+	}
+	if varsHash != manifest.TerraformVarsHash {
+		fmt.Fprintln(deployConfig.Writer, "Note: the configuration or secrets changed since this deploy, only the images are rolled back")
+	}
+	fmt.Fprintln(deployConfig.Writer, "Retrieving remote state...")
+	err = terraform.RunInit(deployConfig)
+	if err != nil {
+		return err
+	}
+	err = applyChanges(deployConfig, secrets, manifest.Images)
+	if err != nil {
+		return err
+	}
+	_, err = recordDeploy(deployConfig, manifest.Images, secrets, id)
+	return err
+}
+
+// GetMissingImageNames returns the given service and dependency names the manifest has no image for
+func GetMissingImageNames(manifest deploy.Manifest, imageNames []string) []string {
+	result := []string{}
+	for _, name := range imageNames {
+		if _, ok := manifest.Images[name]; !ok {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// GetDependencyChanges describes how the given dependency versions differ from the ones in the manifest
+func GetDependencyChanges(manifest deploy.Manifest, dependencyVersions map[string]string) []string {
+	result := []string{}
+	for name, version := range dependencyVersions {
+		recordedVersion, ok := manifest.Dependencies[name]
+		if !ok {
+			result = append(result, fmt.Sprintf("%s %s was added since deploy %s", name, version, manifest.ID))
+		} else if recordedVersion != version {
+			result = append(result, fmt.Sprintf("%s was updated from %s to %s since deploy %s", name, recordedVersion, version, manifest.ID))
+		}
+	}
+	for name, recordedVersion := range manifest.Dependencies {
+		if _, ok := dependencyVersions[name]; !ok {
+			result = append(result, fmt.Sprintf("%s %s was removed since deploy %s", name, recordedVersion, manifest.ID))
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// stores the manifest of a successful deploy next to the Terraform state
+func recordDeploy(deployConfig deploy.Config, imagesMap map[string]string, secrets types.Secrets, rollbackOf string) (deploy.Manifest, error) {
+	varsHash, err := terraform.GetVarsHash(deployConfig, secrets, imagesMap)
+	if err != nil {
+		return deploy.Manifest{}, err
+	}
+	manifest := deploy.NewManifest(time.Now(), imagesMap, getDependencyVersions(deployConfig.AppContext), varsHash)
+	manifest.RollbackOf = rollbackOf
+	err = aws.WriteDeployManifest(deployConfig.AwsConfig, manifest)
+	if err != nil {
+		return manifest, errors.Wrap(err, "The deploy succeeded but its manifest could not be stored")
+	}
+	fmt.Fprintf(deployConfig.Writer, "Recorded deploy %s\n", manifest.ID)
+	return manifest, nil
+}
+
+// returns the names of the services and dependencies that need an image
+func getRequiredImageNames(deployConfig deploy.Config) ([]string, error) {
+	result := deployConfig.AppContext.Config.GetSortedServiceRoles()
+	dependencyImages, err := getDependencyImageNames(deployConfig)
+	if err != nil {
+		return nil, err
+	}
+	for name := range dependencyImages {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// returns the versions of the remote dependencies of the application and all services
+func getDependencyVersions(appContext *context.AppContext) map[string]string {
+	result := map[string]string{}
+	dependencies := appContext.Config.Remote.Dependencies
+	for _, serviceContext := range appContext.ServiceContexts {
+		dependencies = append(dependencies, serviceContext.Config.Remote.Dependencies...)
+	}
+	for _, dependency := range dependencies {
+		result[dependency.Name] = dependency.Version
+	}
+	return result
+}
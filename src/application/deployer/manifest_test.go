@@ -0,0 +1,50 @@
+package deployer_test
+
+import (
+	"time"
+
+	"github.com/Originate/exosphere/src/application/deployer"
+	"github.com/Originate/exosphere/src/types/deploy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deploy manifests", func() {
+	manifest := deploy.NewManifest(
+		time.Date(2017, 12, 1, 14, 30, 5, 0, time.UTC),
+		map[string]string{"web": "123.dkr.ecr.us-west-2.amazonaws.com/app_web:abc123"},
+		map[string]string{"exocom": "0.27.0", "postgres": "9.6.4"},
+		"hash",
+	)
+
+	It("uses the deploy time as ID", func() {
+		Expect(manifest.ID).To(Equal("20171201-143005"))
+	})
+
+	It("sorts manifests from the newest to the oldest deploy", func() {
+		manifests := []deploy.Manifest{{ID: "20171201-143005"}, {ID: "20180102-090000"}, {ID: "20171130-235959"}}
+		deploy.SortManifests(manifests)
+		Expect(manifests).To(Equal([]deploy.Manifest{{ID: "20180102-090000"}, {ID: "20171201-143005"}, {ID: "20171130-235959"}}))
+	})
+
+	Describe("GetMissingImageNames", func() {
+		It("returns the services and dependencies added since the deploy", func() {
+			Expect(deployer.GetMissingImageNames(manifest, []string{"exocom", "web"})).To(Equal([]string{"exocom"}))
+		})
+	})
+
+	Describe("GetDependencyChanges", func() {
+		It("describes added, removed and updated dependencies", func() {
+			changes := deployer.GetDependencyChanges(manifest, map[string]string{"exocom": "0.28.0", "redis": "3.2"})
+			Expect(changes).To(Equal([]string{
+				"exocom was updated from 0.27.0 to 0.28.0 since deploy 20171201-143005",
+				"postgres 9.6.4 was removed since deploy 20171201-143005",
+				"redis 3.2 was added since deploy 20171201-143005",
+			}))
+		})
+
+		It("returns no changes if the dependencies are the same", func() {
+			Expect(deployer.GetDependencyChanges(manifest, manifest.Dependencies)).To(BeEmpty())
+		})
+	})
+})
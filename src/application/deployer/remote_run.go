@@ -0,0 +1,90 @@
+package deployer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Originate/exosphere/src/aws"
+	"github.com/Originate/exosphere/src/terraform"
+	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/pkg/errors"
+)
+
+// how often the state and the logs of a one-off task are polled
+const taskPollInterval = 2 * time.Second
+
+// RunRemoteCommand runs the given command in a one-off ECS task of the given service
+// with the task definition of its deployed version, and prints the logs of the task until it stopped.
+// It returns the exit code of the command
+func RunRemoteCommand(deployConfig deploy.Config, serviceRole string, command []string) (int64, error) {
+	if _, ok := deployConfig.AppContext.ServiceContexts[serviceRole]; !ok {
+		return 0, fmt.Errorf("Unknown service '%s'", serviceRole)
+	}
+	taskDefinition, logStreamPrefix, err := getRemoteTaskDefinition(deployConfig, serviceRole)
+	if err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("Failed to read the deployed task definition of %s", serviceRole))
+	}
+	clusterName := terraform.GetClusterName(deployConfig.AppContext.Config.Name)
+	taskArn, err := aws.StartTask(deployConfig.AwsConfig, clusterName, taskDefinition, terraform.GetContainerName(serviceRole), command)
+	if err != nil {
+		return 0, err
+	}
+	fmt.Fprintf(deployConfig.Writer, "Started task %s\n", taskArn)
+	logGroupName := terraform.GetLogGroupName(serviceRole)
+	logStreamName := terraform.GetLogStreamName(logStreamPrefix, serviceRole, taskArn)
+	nextToken := ""
+	printLogs := func() error {
+		var messages []string
+		messages, nextToken, err = aws.GetLogMessages(deployConfig.AwsConfig, logGroupName, logStreamName, nextToken)
+		for _, message := range messages {
+			fmt.Fprintln(deployConfig.Writer, message)
+		}
+		return err
+	}
+	for {
+		status, err := aws.GetTaskStatus(deployConfig.AwsConfig, clusterName, taskArn)
+		if err != nil {
+			return 0, err
+		}
+		if status.IsStopped() {
+			// the awslogs driver forwards the last messages shortly after the container exited
+			time.Sleep(taskPollInterval)
+			if err = printLogs(); err != nil {
+				return 0, err
+			}
+			if status.ExitCode == nil {
+				return 0, fmt.Errorf("the task stopped before its container exited: %s", status.StoppedReason)
+			}
+			return *status.ExitCode, nil
+		}
+		if err = printLogs(); err != nil {
+			return 0, err
+		}
+		time.Sleep(taskPollInterval)
+	}
+}
+
+// returns the task definition the deployed version of the given service runs and the prefix of its log streams.
+// Services deployed with the blue-green or canary strategy run the task definition of their live color
+func getRemoteTaskDefinition(deployConfig deploy.Config, serviceRole string) (string, string, error) {
+	serviceConfig := deployConfig.AppContext.ServiceContexts[serviceRole].Config
+	if !serviceConfig.Remote.Deploy.ShiftsTraffic() {
+		return terraform.GetTaskDefinitionFamily(serviceRole), terraform.LogStreamPrefix, nil
+	}
+	liveColor, err := getLiveColor(deployConfig, serviceRole)
+	if err != nil {
+		return "", "", err
+	}
+	if liveColor == "" {
+		return "", "", fmt.Errorf("%s has not been deployed yet", serviceRole)
+	}
+	clusterName := terraform.GetClusterName(deployConfig.AppContext.Config.Name)
+	taskDefinition, err := aws.GetServiceTaskDefinition(deployConfig.AwsConfig, clusterName, terraform.GetColorServiceName(serviceRole, liveColor))
+	if err != nil {
+		return "", "", err
+	}
+	if taskDefinition == "" {
+		return "", "", fmt.Errorf("the ECS service of the live color of %s does not exist", serviceRole)
+	}
+	return taskDefinition, liveColor, nil
+}
@@ -0,0 +1,27 @@
+package deployer_test
+
+import (
+	"bytes"
+
+	"github.com/Originate/exosphere/src/application/deployer"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/types/deploy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunRemoteCommand", func() {
+	It("returns an error if the service does not exist", func() {
+		deployConfig := deploy.Config{
+			AppContext: &context.AppContext{
+				Config:          types.AppConfig{Name: "example-app"},
+				ServiceContexts: map[string]*context.ServiceContext{},
+			},
+			Writer: &bytes.Buffer{},
+		}
+		_, err := deployer.RunRemoteCommand(deployConfig, "web", []string{"bin/console"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Unknown service 'web'"))
+	})
+})
@@ -0,0 +1,242 @@
+package deployer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Originate/exosphere/src/aws"
+	"github.com/Originate/exosphere/src/terraform"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/pkg/errors"
+)
+
+// how often the new version of a service is checked while it bakes
+const bakePollInterval = 15 * time.Second
+
+// ColoredService is a running service deployed with the blue-green or canary strategy
+type ColoredService struct {
+	ServiceRole  string
+	DeployConfig types.ServiceDeployConfig
+	LiveColor    string
+	LiveImage    string
+	LiveCount    int64
+}
+
+// applies the changes deploying the given images. Services deployed with the blue-green or canary strategy
+// keep running their live color while the new version is deployed to the idle one,
+// then the traffic is shifted to it
+func applyChanges(deployConfig deploy.Config, secrets types.Secrets, imagesMap map[string]string) error {
+	coloredServices, err := getColoredServices(deployConfig)
+	if err != nil {
+		return errors.Wrap(err, "Failed to read the live versions of the services")
+	}
+	terraformImagesMap := map[string]string{}
+	for name, image := range imagesMap {
+		terraformImagesMap[name] = image
+	}
+	for _, service := range coloredServices {
+		terraformImagesMap[terraform.GetColorImageKey(service.ServiceRole, service.LiveColor)] = service.LiveImage
+	}
+	fmt.Fprintln(deployConfig.Writer, "Applying changes...")
+	err = terraform.RunApply(deployConfig, secrets, terraformImagesMap, deployConfig.AutoApprove)
+	if err != nil {
+		return errors.Wrap(err, "Failed to apply the changes")
+	}
+	client := awsTrafficShiftingClient{awsConfig: deployConfig.AwsConfig}
+	for _, service := range coloredServices {
+		err = ShiftTraffic(deployConfig, client, service)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCanaryTaskCount returns the number of tasks of the new version that receive
+// the given percentage of the traffic next to the given number of live tasks
+func GetCanaryTaskCount(liveCount, percentage int64) int64 {
+	result := (percentage*liveCount + 100 - percentage - 1) / (100 - percentage)
+	if result < 1 {
+		return 1
+	}
+	return result
+}
+
+// returns the services deployed with the blue-green or canary strategy that run already.
+// The first deploy of a service creates its blue color, so there is no traffic to shift
+func getColoredServices(deployConfig deploy.Config) ([]ColoredService, error) {
+	result := []ColoredService{}
+	clusterName := terraform.GetClusterName(deployConfig.AppContext.Config.Name)
+	for _, serviceRole := range deployConfig.AppContext.Config.GetSortedServiceRoles() {
+		serviceDeployConfig := deployConfig.AppContext.ServiceContexts[serviceRole].Config.Remote.Deploy
+		if !serviceDeployConfig.ShiftsTraffic() {
+			continue
+		}
+		liveColor, err := getLiveColor(deployConfig, serviceRole)
+		if err != nil {
+			return nil, err
+		}
+		if liveColor == "" {
+			continue
+		}
+		liveImage, liveCount, err := aws.GetServiceImage(deployConfig.AwsConfig, clusterName, terraform.GetColorServiceName(serviceRole, liveColor))
+		if err != nil {
+			return nil, err
+		}
+		if liveImage == "" {
+			continue
+		}
+		result = append(result, ColoredService{
+			ServiceRole:  serviceRole,
+			DeployConfig: serviceDeployConfig,
+			LiveColor:    liveColor,
+			LiveImage:    liveImage,
+			LiveCount:    liveCount,
+		})
+	}
+	return result, nil
+}
+
+// returns the color of the given service the ALB sends its traffic to,
+// or an empty string if the ALB of the service does not exist yet
+func getLiveColor(deployConfig deploy.Config, serviceRole string) (string, error) {
+	_, liveTargetGroupArn, err := aws.GetListenerTargetGroup(deployConfig.AwsConfig, terraform.GetLoadBalancerName(serviceRole), terraform.ListenerPort)
+	if err != nil || liveTargetGroupArn == "" {
+		return "", err
+	}
+	blueTargetGroupArn, err := aws.GetTargetGroupArn(deployConfig.AwsConfig, terraform.GetColorTargetGroupName(serviceRole, terraform.ColorBlue))
+	if err != nil {
+		return "", err
+	}
+	if liveTargetGroupArn == blueTargetGroupArn {
+		return terraform.ColorBlue, nil
+	}
+	return terraform.ColorGreen, nil
+}
+
+// ShiftTraffic shifts the traffic of the given service from its live color to the idle one, which runs the new version.
+// A canary deploy registers tasks of the new version with the live target group first, so that they receive
+// a part of the traffic. Then the listeners of both colors are switched. The new version bakes after
+// each step and the deploy rolls back to the live color if it fails
+// nolint gocyclo
+func ShiftTraffic(deployConfig deploy.Config, client TrafficShiftingClient, service ColoredService) error {
+	clusterName := terraform.GetClusterName(deployConfig.AppContext.Config.Name)
+	loadBalancerName := terraform.GetLoadBalancerName(service.ServiceRole)
+	idleServiceName := terraform.GetColorServiceName(service.ServiceRole, terraform.GetOtherColor(service.LiveColor))
+	listenerArn, liveTargetGroupArn, err := client.GetListenerTargetGroup(loadBalancerName, terraform.ListenerPort)
+	if err != nil {
+		return err
+	}
+	testListenerArn, idleTargetGroupArn, err := client.GetListenerTargetGroup(loadBalancerName, terraform.TestListenerPort)
+	if err != nil {
+		return err
+	}
+	desiredCount := service.LiveCount
+	if desiredCount < 1 {
+		desiredCount = 1
+	}
+	canaryTargets := []aws.Target{}
+	rollback := func(cause error) error {
+		fmt.Fprintf(deployConfig.Writer, "Rolling back %s to the previous version...\n", service.ServiceRole)
+		err := client.DeregisterTargets(liveTargetGroupArn, canaryTargets)
+		if err == nil {
+			err = client.ScaleService(clusterName, idleServiceName, 0)
+		}
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Failed to roll back %s after: %s", service.ServiceRole, cause))
+		}
+		return errors.Wrap(cause, fmt.Sprintf("The new version of %s has been rolled back", service.ServiceRole))
+	}
+
+	for _, percentage := range service.DeployConfig.GetTrafficPercentages() {
+		canaryCount := GetCanaryTaskCount(desiredCount, int64(percentage))
+		fmt.Fprintf(deployConfig.Writer, "Sending %d%% of the traffic of %s to %d task(s) of the new version...\n", canaryCount*100/(canaryCount+desiredCount), service.ServiceRole, canaryCount)
+		err = client.ScaleService(clusterName, idleServiceName, canaryCount)
+		if err != nil {
+			return rollback(err)
+		}
+		canaryTargets, err = client.GetServiceTargets(clusterName, idleServiceName)
+		if err != nil {
+			return rollback(err)
+		}
+		err = client.RegisterTargets(liveTargetGroupArn, canaryTargets)
+		if err != nil {
+			return rollback(err)
+		}
+		err = bake(deployConfig, client, service, idleTargetGroupArn)
+		if err != nil {
+			return rollback(err)
+		}
+	}
+
+	fmt.Fprintf(deployConfig.Writer, "Sending all traffic of %s to the new version...\n", service.ServiceRole)
+	err = client.ScaleService(clusterName, idleServiceName, desiredCount)
+	if err != nil {
+		return rollback(err)
+	}
+	err = switchListeners(client, listenerArn, idleTargetGroupArn, testListenerArn, liveTargetGroupArn)
+	if err != nil {
+		return rollback(err)
+	}
+	err = client.DeregisterTargets(liveTargetGroupArn, canaryTargets)
+	if err != nil {
+		return err
+	}
+	canaryTargets = []aws.Target{}
+	err = bake(deployConfig, client, service, idleTargetGroupArn)
+	if err != nil {
+		switchErr := switchListeners(client, listenerArn, liveTargetGroupArn, testListenerArn, idleTargetGroupArn)
+		if switchErr != nil {
+			return errors.Wrap(switchErr, fmt.Sprintf("Failed to roll back %s after: %s", service.ServiceRole, err))
+		}
+		return rollback(err)
+	}
+	fmt.Fprintf(deployConfig.Writer, "Stopping the previous version of %s...\n", service.ServiceRole)
+	return client.ScaleService(clusterName, terraform.GetColorServiceName(service.ServiceRole, service.LiveColor), 0)
+}
+
+// monitors the new version of a service, which runs behind the given target group, for the bake time
+// and returns an error if it fails any of the auto-rollback conditions of the service
+func bake(deployConfig deploy.Config, client TrafficShiftingClient, service ColoredService, targetGroupArn string) error {
+	bakeTime := service.DeployConfig.GetBakeTime()
+	autoRollback := service.DeployConfig.AutoRollback
+	fmt.Fprintf(deployConfig.Writer, "Monitoring the new version of %s for %s...\n", service.ServiceRole, bakeTime)
+	start := time.Now()
+	for elapsed := time.Duration(0); elapsed < bakeTime; {
+		pollInterval := bakePollInterval
+		if remaining := bakeTime - elapsed; remaining < pollInterval {
+			pollInterval = remaining
+		}
+		client.Sleep(pollInterval)
+		elapsed += pollInterval
+		if autoRollback.HealthCheckFailure {
+			unhealthyCount, err := client.GetUnhealthyTargetCount(targetGroupArn)
+			if err != nil {
+				return err
+			}
+			if unhealthyCount > 0 {
+				return fmt.Errorf("%d task(s) of the new version of %s failed the health check", unhealthyCount, service.ServiceRole)
+			}
+		}
+		if autoRollback.Max5xxErrors > 0 {
+			errorCount, err := client.GetLoadBalancer5xxCount(terraform.GetLoadBalancerName(service.ServiceRole), start)
+			if err != nil {
+				return err
+			}
+			if errorCount > autoRollback.Max5xxErrors {
+				return fmt.Errorf("%s sent %d 5xx responses, more than the %d allowed", service.ServiceRole, errorCount, autoRollback.Max5xxErrors)
+			}
+		}
+	}
+	return nil
+}
+
+// makes the listener forward to the given target group and the test listener to the given test target group
+func switchListeners(client TrafficShiftingClient, listenerArn, targetGroupArn, testListenerArn, testTargetGroupArn string) error {
+	err := client.SetListenerTargetGroup(listenerArn, targetGroupArn)
+	if err != nil {
+		return err
+	}
+	return client.SetListenerTargetGroup(testListenerArn, testTargetGroupArn)
+}
@@ -0,0 +1,64 @@
+package deployer
+
+import (
+	"time"
+
+	"github.com/Originate/exosphere/src/aws"
+	"github.com/Originate/exosphere/src/types"
+)
+
+// TrafficShiftingClient performs the ECS and ELB calls that shift the traffic of a service
+// from its live color to the idle one
+type TrafficShiftingClient interface {
+	GetListenerTargetGroup(loadBalancerName string, port int64) (string, string, error)
+	SetListenerTargetGroup(listenerArn, targetGroupArn string) error
+	RegisterTargets(targetGroupArn string, targets []aws.Target) error
+	DeregisterTargets(targetGroupArn string, targets []aws.Target) error
+	GetUnhealthyTargetCount(targetGroupArn string) (int, error)
+	GetLoadBalancer5xxCount(loadBalancerName string, since time.Time) (int, error)
+	ScaleService(clusterName, serviceName string, desiredCount int64) error
+	GetServiceTargets(clusterName, serviceName string) ([]aws.Target, error)
+	// Sleep waits between the checks of the new version while it bakes
+	Sleep(duration time.Duration)
+}
+
+// awsTrafficShiftingClient is the TrafficShiftingClient talking to the AWS account of the application
+type awsTrafficShiftingClient struct {
+	awsConfig types.AwsConfig
+}
+
+func (c awsTrafficShiftingClient) GetListenerTargetGroup(loadBalancerName string, port int64) (string, string, error) {
+	return aws.GetListenerTargetGroup(c.awsConfig, loadBalancerName, port)
+}
+
+func (c awsTrafficShiftingClient) SetListenerTargetGroup(listenerArn, targetGroupArn string) error {
+	return aws.SetListenerTargetGroup(c.awsConfig, listenerArn, targetGroupArn)
+}
+
+func (c awsTrafficShiftingClient) RegisterTargets(targetGroupArn string, targets []aws.Target) error {
+	return aws.RegisterTargets(c.awsConfig, targetGroupArn, targets)
+}
+
+func (c awsTrafficShiftingClient) DeregisterTargets(targetGroupArn string, targets []aws.Target) error {
+	return aws.DeregisterTargets(c.awsConfig, targetGroupArn, targets)
+}
+
+func (c awsTrafficShiftingClient) GetUnhealthyTargetCount(targetGroupArn string) (int, error) {
+	return aws.GetUnhealthyTargetCount(c.awsConfig, targetGroupArn)
+}
+
+func (c awsTrafficShiftingClient) GetLoadBalancer5xxCount(loadBalancerName string, since time.Time) (int, error) {
+	return aws.GetLoadBalancer5xxCount(c.awsConfig, loadBalancerName, since)
+}
+
+func (c awsTrafficShiftingClient) ScaleService(clusterName, serviceName string, desiredCount int64) error {
+	return aws.ScaleService(c.awsConfig, clusterName, serviceName, desiredCount)
+}
+
+func (c awsTrafficShiftingClient) GetServiceTargets(clusterName, serviceName string) ([]aws.Target, error) {
+	return aws.GetServiceTargets(c.awsConfig, clusterName, serviceName)
+}
+
+func (c awsTrafficShiftingClient) Sleep(duration time.Duration) {
+	time.Sleep(duration)
+}
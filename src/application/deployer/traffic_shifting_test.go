@@ -0,0 +1,220 @@
+package deployer_test
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/Originate/exosphere/src/application/deployer"
+	"github.com/Originate/exosphere/src/aws"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/types/deploy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeTrafficShiftingClient records the calls made while shifting traffic
+// and reports the given number of unhealthy targets and 5xx responses
+type fakeTrafficShiftingClient struct {
+	calls           []string
+	listeners       map[string]string
+	unhealthyCounts []int
+	errorCount      int
+	sleepDuration   time.Duration
+}
+
+func newFakeTrafficShiftingClient() *fakeTrafficShiftingClient {
+	return &fakeTrafficShiftingClient{
+		listeners: map[string]string{
+			"listener-443":  "tg-blue",
+			"listener-8443": "tg-green",
+		},
+	}
+}
+
+func (c *fakeTrafficShiftingClient) GetListenerTargetGroup(loadBalancerName string, port int64) (string, string, error) {
+	listenerArn := fmt.Sprintf("listener-%d", port)
+	return listenerArn, c.listeners[listenerArn], nil
+}
+
+func (c *fakeTrafficShiftingClient) SetListenerTargetGroup(listenerArn, targetGroupArn string) error {
+	c.calls = append(c.calls, fmt.Sprintf("forward %s to %s", listenerArn, targetGroupArn))
+	c.listeners[listenerArn] = targetGroupArn
+	return nil
+}
+
+func (c *fakeTrafficShiftingClient) RegisterTargets(targetGroupArn string, targets []aws.Target) error {
+	c.calls = append(c.calls, fmt.Sprintf("register %d target(s) with %s", len(targets), targetGroupArn))
+	return nil
+}
+
+func (c *fakeTrafficShiftingClient) DeregisterTargets(targetGroupArn string, targets []aws.Target) error {
+	c.calls = append(c.calls, fmt.Sprintf("deregister %d target(s) from %s", len(targets), targetGroupArn))
+	return nil
+}
+
+func (c *fakeTrafficShiftingClient) GetUnhealthyTargetCount(targetGroupArn string) (int, error) {
+	c.calls = append(c.calls, fmt.Sprintf("check health of %s", targetGroupArn))
+	if len(c.unhealthyCounts) == 0 {
+		return 0, nil
+	}
+	result := c.unhealthyCounts[0]
+	c.unhealthyCounts = c.unhealthyCounts[1:]
+	return result, nil
+}
+
+func (c *fakeTrafficShiftingClient) GetLoadBalancer5xxCount(loadBalancerName string, since time.Time) (int, error) {
+	c.calls = append(c.calls, fmt.Sprintf("count 5xx responses of %s", loadBalancerName))
+	return c.errorCount, nil
+}
+
+func (c *fakeTrafficShiftingClient) ScaleService(clusterName, serviceName string, desiredCount int64) error {
+	c.calls = append(c.calls, fmt.Sprintf("scale %s/%s to %d", clusterName, serviceName, desiredCount))
+	return nil
+}
+
+func (c *fakeTrafficShiftingClient) GetServiceTargets(clusterName, serviceName string) ([]aws.Target, error) {
+	return []aws.Target{{InstanceID: "i-1", Port: 32768}, {InstanceID: "i-2", Port: 32769}}, nil
+}
+
+func (c *fakeTrafficShiftingClient) Sleep(duration time.Duration) {
+	c.sleepDuration += duration
+}
+
+var _ = Describe("Traffic shifting", func() {
+	Describe("GetCanaryTaskCount", func() {
+		It("runs enough tasks of the new version to receive at least the given percentage of the traffic", func() {
+			Expect(deployer.GetCanaryTaskCount(9, 10)).To(Equal(int64(1)))
+			Expect(deployer.GetCanaryTaskCount(10, 10)).To(Equal(int64(2)))
+			Expect(deployer.GetCanaryTaskCount(4, 50)).To(Equal(int64(4)))
+			Expect(deployer.GetCanaryTaskCount(1, 75)).To(Equal(int64(3)))
+		})
+
+		It("runs at least one task of the new version", func() {
+			Expect(deployer.GetCanaryTaskCount(1, 1)).To(Equal(int64(1)))
+		})
+	})
+
+	Describe("ShiftTraffic", func() {
+		var client *fakeTrafficShiftingClient
+		deployConfig := deploy.Config{
+			AppContext: &context.AppContext{
+				Config: types.AppConfig{Name: "example-app"},
+			},
+			Writer: &bytes.Buffer{},
+		}
+
+		BeforeEach(func() {
+			client = newFakeTrafficShiftingClient()
+		})
+
+		It("registers canary tasks with the live target group, then switches the listeners", func() {
+			err := deployer.ShiftTraffic(deployConfig, client, deployer.ColoredService{
+				ServiceRole: "web",
+				DeployConfig: types.ServiceDeployConfig{
+					Strategy:     types.DeployStrategyCanary,
+					BakeTime:     30,
+					AutoRollback: types.ServiceAutoRollbackConfig{HealthCheckFailure: true},
+				},
+				LiveColor: "blue",
+				LiveCount: 4,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.calls).To(Equal([]string{
+				"scale production-example-app/web-green to 1",
+				"register 2 target(s) with tg-blue",
+				"check health of tg-green",
+				"check health of tg-green",
+				"scale production-example-app/web-green to 4",
+				"forward listener-443 to tg-green",
+				"forward listener-8443 to tg-blue",
+				"deregister 2 target(s) from tg-blue",
+				"check health of tg-green",
+				"check health of tg-green",
+				"scale production-example-app/web-blue to 0",
+			}))
+			Expect(client.sleepDuration).To(Equal(60 * time.Second))
+			Expect(client.listeners).To(Equal(map[string]string{
+				"listener-443":  "tg-green",
+				"listener-8443": "tg-blue",
+			}))
+		})
+
+		It("switches the listeners right away for blue-green deploys", func() {
+			err := deployer.ShiftTraffic(deployConfig, client, deployer.ColoredService{
+				ServiceRole:  "web",
+				DeployConfig: types.ServiceDeployConfig{Strategy: types.DeployStrategyBlueGreen, BakeTime: 10},
+				LiveColor:    "blue",
+				LiveCount:    2,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.calls).To(Equal([]string{
+				"scale production-example-app/web-green to 2",
+				"forward listener-443 to tg-green",
+				"forward listener-8443 to tg-blue",
+				"deregister 0 target(s) from tg-blue",
+				"scale production-example-app/web-blue to 0",
+			}))
+			Expect(client.sleepDuration).To(Equal(10 * time.Second))
+		})
+
+		It("rolls back the canary tasks if they fail the health check", func() {
+			client.unhealthyCounts = []int{0, 1}
+			err := deployer.ShiftTraffic(deployConfig, client, deployer.ColoredService{
+				ServiceRole: "web",
+				DeployConfig: types.ServiceDeployConfig{
+					Strategy:     types.DeployStrategyCanary,
+					BakeTime:     30,
+					AutoRollback: types.ServiceAutoRollbackConfig{HealthCheckFailure: true},
+				},
+				LiveColor: "blue",
+				LiveCount: 4,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("The new version of web has been rolled back: 1 task(s) of the new version of web failed the health check"))
+			Expect(client.calls).To(Equal([]string{
+				"scale production-example-app/web-green to 1",
+				"register 2 target(s) with tg-blue",
+				"check health of tg-green",
+				"check health of tg-green",
+				"deregister 2 target(s) from tg-blue",
+				"scale production-example-app/web-green to 0",
+			}))
+			Expect(client.listeners["listener-443"]).To(Equal("tg-blue"))
+		})
+
+		It("switches the listeners back if the new version fails after receiving all traffic", func() {
+			client.listeners = map[string]string{
+				"listener-443":  "tg-green",
+				"listener-8443": "tg-blue",
+			}
+			client.errorCount = 11
+			err := deployer.ShiftTraffic(deployConfig, client, deployer.ColoredService{
+				ServiceRole: "web",
+				DeployConfig: types.ServiceDeployConfig{
+					Strategy:     types.DeployStrategyBlueGreen,
+					BakeTime:     30,
+					AutoRollback: types.ServiceAutoRollbackConfig{Max5xxErrors: 10},
+				},
+				LiveColor: "green",
+				LiveCount: 1,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("The new version of web has been rolled back: web sent 11 5xx responses, more than the 10 allowed"))
+			Expect(client.calls).To(Equal([]string{
+				"scale production-example-app/web-blue to 1",
+				"forward listener-443 to tg-blue",
+				"forward listener-8443 to tg-green",
+				"deregister 0 target(s) from tg-green",
+				"count 5xx responses of web",
+				"forward listener-443 to tg-green",
+				"forward listener-8443 to tg-blue",
+				"deregister 0 target(s) from tg-green",
+				"scale production-example-app/web-blue to 0",
+			}))
+			Expect(client.listeners["listener-443"]).To(Equal("tg-green"))
+			Expect(client.sleepDuration).To(Equal(15 * time.Second))
+		})
+	})
+})
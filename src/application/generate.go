@@ -30,7 +30,7 @@ var buildModes = []types.BuildMode{
 
 // CheckGeneratedDockerComposeFiles checks if docker-compose files are up-to-date
 func CheckGeneratedDockerComposeFiles(appContext *context.AppContext) error {
-	for _, buildMode := range buildModes {
+	for _, buildMode := range getBuildModes(appContext) {
 		dockerCompose, err := composebuilder.GetApplicationDockerCompose(composebuilder.ApplicationOptions{
 			AppContext: appContext,
 			BuildMode:  buildMode,
@@ -49,7 +49,7 @@ func CheckGeneratedDockerComposeFiles(appContext *context.AppContext) error {
 // GenerateComposeFiles generates all docker-compose files for exosphere commands
 func GenerateComposeFiles(appContext *context.AppContext) error {
 	composeDir := path.Join(appContext.Location, "docker-compose")
-	for _, buildMode := range buildModes {
+	for _, buildMode := range getBuildModes(appContext) {
 		dockerCompose, err := composebuilder.GetApplicationDockerCompose(composebuilder.ApplicationOptions{
 			AppContext: appContext,
 			BuildMode:  buildMode,
@@ -65,6 +65,16 @@ func GenerateComposeFiles(appContext *context.AppContext) error {
 	return nil
 }
 
+func getBuildModes(appContext *context.AppContext) []types.BuildMode {
+	if !appContext.Config.Local.E2E.IsConfigured() {
+		return buildModes
+	}
+	return append(buildModes, types.BuildMode{
+		Type:        types.BuildModeTypeLocal,
+		Environment: types.BuildModeEnvironmentE2E,
+	})
+}
+
 func diffDockerCompose(newDockerCompose *types.DockerCompose, appDir, dockerComposeFileName string) error {
 	dockerComposeRelativeFilePath := path.Join("docker-compose", dockerComposeFileName)
 	dockerComposeAbsoluteFilePath := path.Join(appDir, dockerComposeRelativeFilePath)
@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// Possible values for Node.Type and Edge.Type
+const (
+	NodeTypeService    = "service"
+	NodeTypeDependency = "dependency"
+	EdgeTypeMessage    = "message"
+	EdgeTypeDependency = "dependency"
+)
+
+// Graph represents the topology of an application
+type Graph struct {
+	Name              string             `json:"name"`
+	Nodes             []Node             `json:"nodes"`
+	Edges             []Edge             `json:"edges"`
+	UnmatchedSends    []UnmatchedMessage `json:"unmatchedSends"`
+	UnmatchedReceives []UnmatchedMessage `json:"unmatchedReceives"`
+}
+
+// Node represents a service or a dependency
+type Node struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Edge represents a message sent from one service to another,
+// or a service using a dependency
+type Edge struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Type    string `json:"type"`
+	Message string `json:"message,omitempty"`
+}
+
+// UnmatchedMessage represents a message that is sent but never received,
+// or received but never sent
+type UnmatchedMessage struct {
+	Role    string `json:"role"`
+	Message string `json:"message"`
+}
+
+// NewGraph returns the graph of the given application.
+// Message names are translated to their public names
+func NewGraph(appContext *context.AppContext) Graph {
+	result := Graph{
+		Name:              appContext.Config.Name,
+		Nodes:             []Node{},
+		Edges:             []Edge{},
+		UnmatchedSends:    []UnmatchedMessage{},
+		UnmatchedReceives: []UnmatchedMessage{},
+	}
+	serviceRoles := appContext.Config.GetSortedServiceRoles()
+	sends := map[string][]string{}
+	receives := map[string][]string{}
+	for _, serviceRole := range serviceRoles {
+		result.Nodes = append(result.Nodes, Node{ID: serviceRole, Type: NodeTypeService})
+		serviceSource := appContext.Config.Services[serviceRole]
+		serviceMessages := appContext.ServiceContexts[serviceRole].Config.ServiceMessages
+		for _, messageName := range serviceMessages.Sends {
+			sends[serviceRole] = append(sends[serviceRole], serviceSource.GetPublicMessageName(messageName))
+		}
+		for _, messageName := range serviceMessages.Receives {
+			receives[serviceRole] = append(receives[serviceRole], serviceSource.GetPublicMessageName(messageName))
+		}
+	}
+	for _, dependencyName := range getDependencyNames(appContext) {
+		result.Nodes = append(result.Nodes, Node{ID: dependencyName, Type: NodeTypeDependency})
+	}
+	for _, senderRole := range serviceRoles {
+		for _, messageName := range sends[senderRole] {
+			received := false
+			for _, receiverRole := range serviceRoles {
+				if util.DoesStringArrayContain(receives[receiverRole], messageName) {
+					received = true
+					result.Edges = append(result.Edges, Edge{From: senderRole, To: receiverRole, Type: EdgeTypeMessage, Message: messageName})
+				}
+			}
+			if !received {
+				result.UnmatchedSends = append(result.UnmatchedSends, UnmatchedMessage{Role: senderRole, Message: messageName})
+			}
+		}
+	}
+	for _, receiverRole := range serviceRoles {
+		for _, messageName := range receives[receiverRole] {
+			sent := false
+			for _, senderRole := range serviceRoles {
+				if util.DoesStringArrayContain(sends[senderRole], messageName) {
+					sent = true
+				}
+			}
+			if !sent {
+				result.UnmatchedReceives = append(result.UnmatchedReceives, UnmatchedMessage{Role: receiverRole, Message: messageName})
+			}
+		}
+	}
+	for _, serviceRole := range serviceRoles {
+		for _, dependencyName := range getServiceDependencyNames(appContext, serviceRole) {
+			result.Edges = append(result.Edges, Edge{From: serviceRole, To: dependencyName, Type: EdgeTypeDependency})
+		}
+	}
+	return result
+}
+
+// returns the names of all local and remote dependencies of the application and its services
+func getDependencyNames(appContext *context.AppContext) []string {
+	result := []string{}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		for _, dependencyName := range getServiceDependencyNames(appContext, serviceRole) {
+			if !util.DoesStringArrayContain(result, dependencyName) {
+				result = append(result, dependencyName)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// returns the names of the local and remote dependencies the given service uses,
+// including the application-wide dependencies
+func getServiceDependencyNames(appContext *context.AppContext, serviceRole string) []string {
+	serviceConfig := appContext.ServiceContexts[serviceRole].Config
+	localDependencies := [][]types.LocalDependency{appContext.Config.Local.Dependencies, serviceConfig.Local.Dependencies}
+	remoteDependencies := [][]types.RemoteDependency{appContext.Config.Remote.Dependencies, serviceConfig.Remote.Dependencies}
+	result := []string{}
+	for _, dependencies := range localDependencies {
+		for _, dependency := range dependencies {
+			if !util.DoesStringArrayContain(result, dependency.Name) {
+				result = append(result, dependency.Name)
+			}
+		}
+	}
+	for _, dependencies := range remoteDependencies {
+		for _, dependency := range dependencies {
+			if !util.DoesStringArrayContain(result, dependency.Name) {
+				result = append(result, dependency.Name)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
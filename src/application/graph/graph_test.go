@@ -0,0 +1,103 @@
+package graph_test
+
+import (
+	"github.com/Originate/exosphere/src/application/graph"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Graph", func() {
+	var appGraph graph.Graph
+
+	var _ = BeforeEach(func() {
+		appContext := &context.AppContext{
+			Config: types.AppConfig{
+				Name: "graph-app",
+				Local: types.LocalConfig{
+					Dependencies: []types.LocalDependency{{Name: "exocom", Version: "0.26.1"}},
+				},
+				Services: map[string]types.ServiceSource{
+					"web": {Location: "./web"},
+					"users": {
+						Location: "./users",
+						MessageTranslations: []types.MessageTranslation{
+							{Public: "users", Internal: "mongo"},
+						},
+					},
+				},
+			},
+			ServiceContexts: map[string]*context.ServiceContext{
+				"web": {Config: types.ServiceConfig{
+					ServiceMessages: types.ServiceMessages{
+						Sends:    []string{"users.list", "users.delete"},
+						Receives: []string{"users.listed"},
+					},
+				}},
+				"users": {Config: types.ServiceConfig{
+					ServiceMessages: types.ServiceMessages{
+						Sends:    []string{"mongo.listed"},
+						Receives: []string{"mongo.list", "mongo.count"},
+					},
+					Local: types.LocalConfig{
+						Dependencies: []types.LocalDependency{{Name: "mongo", Version: "3.4.0"}},
+					},
+				}},
+			},
+		}
+		appGraph = graph.NewGraph(appContext)
+	})
+
+	It("includes nodes for services and dependencies", func() {
+		Expect(appGraph.Nodes).To(Equal([]graph.Node{
+			{ID: "users", Type: graph.NodeTypeService},
+			{ID: "web", Type: graph.NodeTypeService},
+			{ID: "exocom", Type: graph.NodeTypeDependency},
+			{ID: "mongo", Type: graph.NodeTypeDependency},
+		}))
+	})
+
+	It("includes edges for messages with translations applied and for dependencies", func() {
+		Expect(appGraph.Edges).To(Equal([]graph.Edge{
+			{From: "users", To: "web", Type: graph.EdgeTypeMessage, Message: "users.listed"},
+			{From: "web", To: "users", Type: graph.EdgeTypeMessage, Message: "users.list"},
+			{From: "users", To: "exocom", Type: graph.EdgeTypeDependency},
+			{From: "users", To: "mongo", Type: graph.EdgeTypeDependency},
+			{From: "web", To: "exocom", Type: graph.EdgeTypeDependency},
+		}))
+	})
+
+	It("includes unmatched sends and receives", func() {
+		Expect(appGraph.UnmatchedSends).To(Equal([]graph.UnmatchedMessage{
+			{Role: "web", Message: "users.delete"},
+		}))
+		Expect(appGraph.UnmatchedReceives).To(Equal([]graph.UnmatchedMessage{
+			{Role: "users", Message: "users.count"},
+		}))
+	})
+
+	It("renders the DOT format", func() {
+		output, err := appGraph.Render(graph.FormatDot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring(`digraph "graph-app" {`))
+		Expect(output).To(ContainSubstring(`"mongo" [shape=cylinder];`))
+		Expect(output).To(ContainSubstring(`"web" -> "users" [label="users.list"];`))
+		Expect(output).To(ContainSubstring(`"users" -> "mongo" [style=dashed];`))
+		Expect(output).To(ContainSubstring(`"web" -> "unmatched_send_0" [label="users.delete", color=red, fontcolor=red];`))
+	})
+
+	It("renders the Mermaid format", func() {
+		output, err := appGraph.Render(graph.FormatMermaid)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("graph LR\n"))
+		Expect(output).To(ContainSubstring("  mongo[(mongo)]\n"))
+		Expect(output).To(ContainSubstring("  web -->|users.list| users\n"))
+		Expect(output).To(ContainSubstring("  unmatched_receive_0((?)) -->|users.count| users\n"))
+	})
+
+	It("throws an error for unsupported formats", func() {
+		_, err := appGraph.Render("png")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// Possible values for the output format
+const (
+	FormatDot     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// Render returns the graph in the given format
+func (g Graph) Render(format string) (string, error) {
+	switch format {
+	case FormatDot:
+		return g.RenderDot(), nil
+	case FormatMermaid:
+		return g.RenderMermaid(), nil
+	case FormatJSON:
+		return g.RenderJSON()
+	default:
+		return "", fmt.Errorf("Unsupported format '%s'. Must be one of: dot, mermaid, json", format)
+	}
+}
+
+// RenderDot returns the graph in the Graphviz DOT format
+func (g Graph) RenderDot() string {
+	var result bytes.Buffer
+	fmt.Fprintf(&result, "digraph %q {\n", g.Name)
+	for _, node := range g.Nodes {
+		shape := "box"
+		if node.Type == NodeTypeDependency {
+			shape = "cylinder"
+		}
+		fmt.Fprintf(&result, "  %q [shape=%s];\n", node.ID, shape)
+	}
+	for _, edge := range g.Edges {
+		if edge.Type == EdgeTypeDependency {
+			fmt.Fprintf(&result, "  %q -> %q [style=dashed];\n", edge.From, edge.To)
+		} else {
+			fmt.Fprintf(&result, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Message)
+		}
+	}
+	for i, unmatched := range g.UnmatchedSends {
+		nodeID := fmt.Sprintf("unmatched_send_%d", i)
+		fmt.Fprintf(&result, "  %q [shape=point, color=red];\n", nodeID)
+		fmt.Fprintf(&result, "  %q -> %q [label=%q, color=red, fontcolor=red];\n", unmatched.Role, nodeID, unmatched.Message)
+	}
+	for i, unmatched := range g.UnmatchedReceives {
+		nodeID := fmt.Sprintf("unmatched_receive_%d", i)
+		fmt.Fprintf(&result, "  %q [shape=point, color=red];\n", nodeID)
+		fmt.Fprintf(&result, "  %q -> %q [label=%q, color=red, fontcolor=red];\n", nodeID, unmatched.Role, unmatched.Message)
+	}
+	result.WriteString("}\n")
+	return result.String()
+}
+
+// RenderMermaid returns the graph in the Mermaid flowchart format
+func (g Graph) RenderMermaid() string {
+	var result bytes.Buffer
+	result.WriteString("graph LR\n")
+	for _, node := range g.Nodes {
+		if node.Type == NodeTypeDependency {
+			fmt.Fprintf(&result, "  %s[(%s)]\n", toMermaidID(node.ID), node.ID)
+		} else {
+			fmt.Fprintf(&result, "  %s[%s]\n", toMermaidID(node.ID), node.ID)
+		}
+	}
+	for _, edge := range g.Edges {
+		if edge.Type == EdgeTypeDependency {
+			fmt.Fprintf(&result, "  %s -.-> %s\n", toMermaidID(edge.From), toMermaidID(edge.To))
+		} else {
+			fmt.Fprintf(&result, "  %s -->|%s| %s\n", toMermaidID(edge.From), edge.Message, toMermaidID(edge.To))
+		}
+	}
+	for i, unmatched := range g.UnmatchedSends {
+		nodeID := fmt.Sprintf("unmatched_send_%d", i)
+		fmt.Fprintf(&result, "  %s -->|%s| %s((?))\n", toMermaidID(unmatched.Role), unmatched.Message, nodeID)
+		fmt.Fprintf(&result, "  style %s fill:#f66,stroke:#f00\n", nodeID)
+	}
+	for i, unmatched := range g.UnmatchedReceives {
+		nodeID := fmt.Sprintf("unmatched_receive_%d", i)
+		fmt.Fprintf(&result, "  %s((?)) -->|%s| %s\n", nodeID, unmatched.Message, toMermaidID(unmatched.Role))
+		fmt.Fprintf(&result, "  style %s fill:#f66,stroke:#f00\n", nodeID)
+	}
+	return result.String()
+}
+
+// RenderJSON returns the graph as JSON
+func (g Graph) RenderJSON() (string, error) {
+	result, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(result) + "\n", nil
+}
+
+// mermaid node ids can only contain alphanumeric characters and underscores
+func toMermaidID(id string) string {
+	return regexp.MustCompile("[^a-zA-Z0-9_]").ReplaceAllString(id, "_")
+}
@@ -0,0 +1,102 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// Possible values for Issue.Severity
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Possible values for the output format
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Issue represents a problem found by a lint rule
+type Issue struct {
+	RuleID   string `json:"rule"`
+	Severity string `json:"severity"`
+	Role     string `json:"role,omitempty"`
+	Message  string `json:"message"`
+}
+
+// Rule represents a check for the application as a whole
+type Rule struct {
+	ID          string
+	Severity    string
+	Description string
+	Check       func(appContext *context.AppContext) []Issue
+}
+
+// Lint runs all rules that are not disabled in application.yml
+// and returns the issues found
+func Lint(appContext *context.AppContext) []Issue {
+	result := []Issue{}
+	for _, rule := range Rules {
+		if util.DoesStringArrayContain(appContext.Config.Lint.Disable, rule.ID) {
+			continue
+		}
+		for _, issue := range rule.Check(appContext) {
+			issue.RuleID = rule.ID
+			issue.Severity = rule.Severity
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// HasErrors returns whether or not any of the given issues is an error
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Render returns the given issues in the given format
+func Render(issues []Issue, format string) (string, error) {
+	switch format {
+	case FormatText:
+		return RenderText(issues), nil
+	case FormatJSON:
+		return RenderJSON(issues)
+	default:
+		return "", fmt.Errorf("Unsupported format '%s'. Must be one of: text, json", format)
+	}
+}
+
+// RenderText returns the given issues in a human-readable format
+func RenderText(issues []Issue) string {
+	if len(issues) == 0 {
+		return "No issues found\n"
+	}
+	var result bytes.Buffer
+	for _, issue := range issues {
+		if issue.Role != "" {
+			fmt.Fprintf(&result, "%s [%s] %s: %s\n", issue.Severity, issue.RuleID, issue.Role, issue.Message)
+		} else {
+			fmt.Fprintf(&result, "%s [%s] %s\n", issue.Severity, issue.RuleID, issue.Message)
+		}
+	}
+	return result.String()
+}
+
+// RenderJSON returns the given issues as JSON
+func RenderJSON(issues []Issue) (string, error) {
+	result, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(result) + "\n", nil
+}
@@ -0,0 +1,102 @@
+package linter_test
+
+import (
+	"github.com/Originate/exosphere/src/application/linter"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lint", func() {
+	var appContext *context.AppContext
+
+	var _ = BeforeEach(func() {
+		appContext = &context.AppContext{
+			Config: types.AppConfig{
+				Name: "lint-app",
+				Local: types.LocalConfig{
+					Dependencies: []types.LocalDependency{
+						{Name: "exocom", Version: "0.26.1"},
+						{Name: "mongo", Version: "3.4.0", Config: types.LocalDependencyConfig{Ports: []string{"3000:27017"}}},
+					},
+				},
+				Services: map[string]types.ServiceSource{
+					"web": {Location: "./web"},
+					"users": {
+						Location: "./users",
+						MessageTranslations: []types.MessageTranslation{
+							{Public: "users", Internal: "mongo"},
+							{Public: "accounts", Internal: "postgres"},
+						},
+					},
+				},
+			},
+			ServiceContexts: map[string]*context.ServiceContext{
+				"web": {
+					Source: &types.ServiceSource{Location: "./web"},
+					Config: types.ServiceConfig{
+						Type: types.ServiceTypePublic,
+						ServiceMessages: types.ServiceMessages{
+							Sends:    []string{"users.list", "users.delete"},
+							Receives: []string{"users.listed"},
+						},
+						Development: types.ServiceDevelopmentConfig{
+							Port:    "80",
+							Scripts: map[string]string{"test": "npm test"},
+						},
+						Environment: types.EnvVars{
+							Default: map[string]string{"EXOCOM_HOST": "localhost"},
+						},
+					},
+				},
+				"users": {
+					Source: &types.ServiceSource{Location: "./users"},
+					Config: types.ServiceConfig{
+						Type: "worker",
+						ServiceMessages: types.ServiceMessages{
+							Sends:    []string{"mongo.listed"},
+							Receives: []string{"mongo.list"},
+						},
+						Local: types.LocalConfig{
+							Dependencies: []types.LocalDependency{{Name: "mongo", Version: "3.2.0"}},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("returns the issues of all rules", func() {
+		Expect(linter.Lint(appContext)).To(Equal([]linter.Issue{
+			{RuleID: "unmatched-send", Severity: linter.SeverityWarning, Role: "web", Message: "sends 'users.delete' but no service receives it"},
+			{RuleID: "unknown-message-translation", Severity: linter.SeverityError, Role: "users", Message: "message translation from 'accounts' to 'postgres' does not match any message the service sends or receives"},
+			{RuleID: "duplicate-port", Severity: linter.SeverityError, Message: "host port 3000 is used by: web, mongo3.4.0"},
+			{RuleID: "missing-test-script", Severity: linter.SeverityWarning, Role: "users", Message: "has no 'development.scripts.test'"},
+			{RuleID: "missing-health-check", Severity: linter.SeverityWarning, Role: "web", Message: "is public but has no 'remote.health-check'"},
+			{RuleID: "env-var-collision", Severity: linter.SeverityError, Role: "web", Message: "environment variable 'EXOCOM_HOST' collides with the one injected by exocom"},
+			{RuleID: "conflicting-dependency-version", Severity: linter.SeverityError, Role: "users", Message: "local dependency 'mongo' has version 3.2.0 but application.yml declares version 3.4.0"},
+		}))
+	})
+
+	It("skips the rules disabled in application.yml", func() {
+		appContext.Config.Lint.Disable = []string{"unmatched-send", "duplicate-port", "missing-test-script", "missing-health-check", "env-var-collision", "conflicting-dependency-version"}
+		Expect(linter.Lint(appContext)).To(Equal([]linter.Issue{
+			{RuleID: "unknown-message-translation", Severity: linter.SeverityError, Role: "users", Message: "message translation from 'accounts' to 'postgres' does not match any message the service sends or receives"},
+		}))
+	})
+
+	It("returns whether there are any errors", func() {
+		Expect(linter.HasErrors(linter.Lint(appContext))).To(BeTrue())
+		Expect(linter.HasErrors([]linter.Issue{{Severity: linter.SeverityWarning}})).To(BeFalse())
+	})
+
+	It("renders the issues as text", func() {
+		output := linter.RenderText([]linter.Issue{
+			{RuleID: "duplicate-port", Severity: linter.SeverityError, Message: "host port 3000 is used by: web, mongo3.4.0"},
+			{RuleID: "missing-test-script", Severity: linter.SeverityWarning, Role: "users", Message: "has no 'development.scripts.test'"},
+		})
+		Expect(output).To(Equal("error [duplicate-port] host port 3000 is used by: web, mongo3.4.0\nwarning [missing-test-script] users: has no 'development.scripts.test'\n"))
+		Expect(linter.RenderText([]linter.Issue{})).To(Equal("No issues found\n"))
+	})
+})
@@ -0,0 +1,246 @@
+package linter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Originate/exosphere/src/application/graph"
+	"github.com/Originate/exosphere/src/config"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/types/endpoints"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// Rules are all the rules 'exo lint' checks
+var Rules = []Rule{
+	{
+		ID:          "unmatched-send",
+		Severity:    SeverityWarning,
+		Description: "a message is sent but no service receives it",
+		Check:       checkUnmatchedSends,
+	},
+	{
+		ID:          "unmatched-receive",
+		Severity:    SeverityWarning,
+		Description: "a message is received but no service sends it",
+		Check:       checkUnmatchedReceives,
+	},
+	{
+		ID:          "unknown-message-translation",
+		Severity:    SeverityError,
+		Description: "a message translation references messages the service neither sends nor receives",
+		Check:       checkUnknownMessageTranslations,
+	},
+	{
+		ID:          "duplicate-port",
+		Severity:    SeverityError,
+		Description: "a host port is used more than once",
+		Check:       checkDuplicatePorts,
+	},
+	{
+		ID:          "missing-test-script",
+		Severity:    SeverityWarning,
+		Description: "a service has no 'development.scripts.test'",
+		Check:       checkMissingTestScripts,
+	},
+	{
+		ID:          "missing-health-check",
+		Severity:    SeverityWarning,
+		Description: "a public service has no 'remote.health-check'",
+		Check:       checkMissingHealthChecks,
+	},
+	{
+		ID:          "env-var-collision",
+		Severity:    SeverityError,
+		Description: "an environment variable of a service collides with one injected by exosphere or a dependency",
+		Check:       checkEnvVarCollisions,
+	},
+	{
+		ID:          "conflicting-dependency-version",
+		Severity:    SeverityError,
+		Description: "a dependency is declared in application.yml and service.yml with different versions",
+		Check:       checkConflictingDependencyVersions,
+	},
+}
+
+func checkUnmatchedSends(appContext *context.AppContext) []Issue {
+	result := []Issue{}
+	for _, unmatched := range graph.NewGraph(appContext).UnmatchedSends {
+		result = append(result, Issue{
+			Role:    unmatched.Role,
+			Message: fmt.Sprintf("sends '%s' but no service receives it", unmatched.Message),
+		})
+	}
+	return result
+}
+
+func checkUnmatchedReceives(appContext *context.AppContext) []Issue {
+	result := []Issue{}
+	for _, unmatched := range graph.NewGraph(appContext).UnmatchedReceives {
+		result = append(result, Issue{
+			Role:    unmatched.Role,
+			Message: fmt.Sprintf("receives '%s' but no service sends it", unmatched.Message),
+		})
+	}
+	return result
+}
+
+func checkUnknownMessageTranslations(appContext *context.AppContext) []Issue {
+	result := []Issue{}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		serviceMessages := appContext.ServiceContexts[serviceRole].Config.ServiceMessages
+		messageNames := util.JoinStringSlices(serviceMessages.Sends, serviceMessages.Receives)
+		for _, translation := range appContext.Config.Services[serviceRole].MessageTranslations {
+			matches := false
+			for _, messageName := range messageNames {
+				if messageName == translation.Internal || strings.HasPrefix(messageName, translation.Internal+".") {
+					matches = true
+				}
+			}
+			if !matches {
+				result = append(result, Issue{
+					Role:    serviceRole,
+					Message: fmt.Sprintf("message translation from '%s' to '%s' does not match any message the service sends or receives", translation.Public, translation.Internal),
+				})
+			}
+		}
+	}
+	return result
+}
+
+func checkDuplicatePorts(appContext *context.AppContext) []Issue {
+	hostPorts := map[string][]string{}
+	addHostPort := func(port, owner string) {
+		if !util.DoesStringArrayContain(hostPorts[port], owner) {
+			hostPorts[port] = append(hostPorts[port], owner)
+		}
+	}
+	serviceEndpoints := endpoints.NewServiceEndpoints(appContext, types.BuildMode{
+		Type:        types.BuildModeTypeLocal,
+		Environment: types.BuildModeEnvironmentDevelopment,
+	})
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		if serviceEndpoints[serviceRole].HostPort != "" {
+			addHostPort(serviceEndpoints[serviceRole].HostPort, serviceRole)
+		}
+	}
+	dependencies := appContext.Config.Local.Dependencies
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		dependencies = append(dependencies, appContext.ServiceContexts[serviceRole].Config.Local.Dependencies...)
+	}
+	for _, dependency := range dependencies {
+		for _, port := range dependency.Config.Ports {
+			parts := strings.Split(port, ":")
+			if len(parts) >= 2 {
+				addHostPort(parts[len(parts)-2], dependency.Name+dependency.Version)
+			}
+		}
+	}
+	ports := []string{}
+	for port := range hostPorts {
+		ports = append(ports, port)
+	}
+	sort.Strings(ports)
+	result := []Issue{}
+	for _, port := range ports {
+		if len(hostPorts[port]) > 1 {
+			result = append(result, Issue{
+				Message: fmt.Sprintf("host port %s is used by: %s", port, strings.Join(hostPorts[port], ", ")),
+			})
+		}
+	}
+	return result
+}
+
+func checkMissingTestScripts(appContext *context.AppContext) []Issue {
+	result := []Issue{}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		serviceContext := appContext.ServiceContexts[serviceRole]
+		if serviceContext.Source.Location != "" && serviceContext.Config.Development.Scripts["test"] == "" {
+			result = append(result, Issue{
+				Role:    serviceRole,
+				Message: "has no 'development.scripts.test'",
+			})
+		}
+	}
+	return result
+}
+
+func checkMissingHealthChecks(appContext *context.AppContext) []Issue {
+	result := []Issue{}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		serviceConfig := appContext.ServiceContexts[serviceRole].Config
+		if serviceConfig.Type == types.ServiceTypePublic && serviceConfig.Remote.HealthCheck == "" {
+			result = append(result, Issue{
+				Role:    serviceRole,
+				Message: "is public but has no 'remote.health-check'",
+			})
+		}
+	}
+	return result
+}
+
+func checkEnvVarCollisions(appContext *context.AppContext) []Issue {
+	result := []Issue{}
+	appDependencies := config.GetBuiltLocalAppDependencies(appContext)
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		serviceConfig := appContext.ServiceContexts[serviceRole].Config
+		injectedBy := map[string]string{"ROLE": "exosphere"}
+		serviceDependencies := config.GetBuiltLocalServiceDependencies(serviceConfig, appContext)
+		for _, builtDependencies := range []map[string]config.LocalAppDependency{appDependencies, serviceDependencies} {
+			for dependencyName, builtDependency := range builtDependencies {
+				for variable := range builtDependency.GetServiceEnvVariables() {
+					injectedBy[variable] = dependencyName
+				}
+			}
+		}
+		variables := append([]string{}, serviceConfig.Environment.Secrets...)
+		for _, envVars := range []map[string]string{serviceConfig.Environment.Default, serviceConfig.Environment.Local, serviceConfig.Environment.Remote} {
+			for variable := range envVars {
+				if !util.DoesStringArrayContain(variables, variable) {
+					variables = append(variables, variable)
+				}
+			}
+		}
+		sort.Strings(variables)
+		for _, variable := range variables {
+			if source, ok := injectedBy[variable]; ok {
+				result = append(result, Issue{
+					Role:    serviceRole,
+					Message: fmt.Sprintf("environment variable '%s' collides with the one injected by %s", variable, source),
+				})
+			}
+		}
+	}
+	return result
+}
+
+func checkConflictingDependencyVersions(appContext *context.AppContext) []Issue {
+	result := []Issue{}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		serviceConfig := appContext.ServiceContexts[serviceRole].Config
+		for _, dependency := range serviceConfig.Local.Dependencies {
+			for _, appDependency := range appContext.Config.Local.Dependencies {
+				if dependency.Name == appDependency.Name && dependency.Version != appDependency.Version {
+					result = append(result, Issue{
+						Role:    serviceRole,
+						Message: fmt.Sprintf("local dependency '%s' has version %s but application.yml declares version %s", dependency.Name, dependency.Version, appDependency.Version),
+					})
+				}
+			}
+		}
+		for _, dependency := range serviceConfig.Remote.Dependencies {
+			for _, appDependency := range appContext.Config.Remote.Dependencies {
+				if dependency.Name == appDependency.Name && dependency.Version != appDependency.Version {
+					result = append(result, Issue{
+						Role:    serviceRole,
+						Message: fmt.Sprintf("remote dependency '%s' has version %s but application.yml declares version %s", dependency.Name, dependency.Version, appDependency.Version),
+					})
+				}
+			}
+		}
+	}
+	return result
+}
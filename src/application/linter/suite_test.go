@@ -0,0 +1,13 @@
+package linter_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestLinter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "application/linter Suite")
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// RenderMessageDocs returns a markdown catalogue of all messages of the application
+// with their producers, consumers and schemas
+func RenderMessageDocs(appContext *context.AppContext) (string, error) {
+	producers := map[string][]string{}
+	consumers := map[string][]string{}
+	serviceRoles := appContext.Config.GetSortedServiceRoles()
+	for _, serviceRole := range serviceRoles {
+		serviceMessages := appContext.ServiceContexts[serviceRole].Config.ServiceMessages
+		for _, messageName := range serviceMessages.Sends {
+			producers[messageName] = append(producers[messageName], serviceRole)
+		}
+		for _, messageName := range serviceMessages.Receives {
+			consumers[messageName] = append(consumers[messageName], serviceRole)
+		}
+	}
+	messageNames := []string{}
+	for messageName := range producers {
+		messageNames = append(messageNames, messageName)
+	}
+	for messageName := range consumers {
+		if !util.DoesStringArrayContain(messageNames, messageName) {
+			messageNames = append(messageNames, messageName)
+		}
+	}
+	sort.Strings(messageNames)
+
+	var result bytes.Buffer
+	fmt.Fprintf(&result, "# %s messages\n", appContext.Config.Name)
+	for _, messageName := range messageNames {
+		fmt.Fprintf(&result, "\n## %s\n\n", messageName)
+		fmt.Fprintf(&result, "- __producers:__ %s\n", formatRoles(producers[messageName]))
+		fmt.Fprintf(&result, "- __consumers:__ %s\n", formatRoles(consumers[messageName]))
+		for _, serviceRole := range serviceRoles {
+			schema, ok := appContext.ServiceContexts[serviceRole].Config.ServiceMessages.Schemas[messageName]
+			if !ok || schema.Schema == nil {
+				continue
+			}
+			schemaJSON, err := json.MarshalIndent(schema.Schema, "", "  ")
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&result, "\nSchema of _%s_:\n\n```json\n%s\n```\n", serviceRole, schemaJSON)
+		}
+	}
+	return result.String(), nil
+}
+
+func formatRoles(serviceRoles []string) string {
+	if len(serviceRoles) == 0 {
+		return "none"
+	}
+	return strings.Join(serviceRoles, ", ")
+}
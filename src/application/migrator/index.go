@@ -0,0 +1,63 @@
+package migrator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Originate/exosphere/src/docker/composerunner"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+	"github.com/pkg/errors"
+)
+
+// Run runs the given migrations action for the given service, or for all services
+// that declare migrations if no service is given, against the local dependency containers
+func Run(options MigrateOptions) error {
+	if !util.DoesStringArrayContain(types.MigrationActions, options.Action) {
+		return fmt.Errorf("Unsupported migrations action '%s'. Must be one of: %s", options.Action, strings.Join(types.MigrationActions, ", "))
+	}
+	serviceRoles, err := GetServiceRoles(options.AppContext, options.ServiceRole)
+	if err != nil {
+		return err
+	}
+	if options.Action == types.MigrationActionRollback && len(serviceRoles) > 1 {
+		return fmt.Errorf("Multiple services declare migrations (%s). Please specify the service to roll back", strings.Join(serviceRoles, ", "))
+	}
+	runOptions := composerunner.RunOptions{
+		AppDir:                   options.AppContext.Location,
+		DockerComposeDir:         path.Join(options.AppContext.Location, "docker-compose"),
+		DockerComposeFileName:    options.BuildMode.GetDockerComposeFileName(),
+		DockerComposeProjectName: options.DockerComposeProjectName,
+		Writer:                   options.Writer,
+	}
+	for _, serviceRole := range serviceRoles {
+		fmt.Fprintf(options.Writer, "Running '%s' migrations of %s...\n", options.Action, serviceRole)
+		migrations := options.AppContext.ServiceContexts[serviceRole].Config.Migrations
+		err = composerunner.RunCommand(runOptions, serviceRole, migrations.GetCommand(options.Action))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("The '%s' migrations of %s failed", options.Action, serviceRole))
+		}
+	}
+	return nil
+}
+
+// GetServiceRoles returns the given service role if it declares migrations,
+// or the roles of all services that declare migrations if no role is given
+func GetServiceRoles(appContext *context.AppContext, serviceRole string) ([]string, error) {
+	serviceRoles := appContext.GetServiceRolesWithMigrations()
+	if serviceRole == "" {
+		if len(serviceRoles) == 0 {
+			return nil, errors.New("No service declares migrations")
+		}
+		return serviceRoles, nil
+	}
+	if _, ok := appContext.ServiceContexts[serviceRole]; !ok {
+		return nil, fmt.Errorf("Unknown service '%s'", serviceRole)
+	}
+	if !util.DoesStringArrayContain(serviceRoles, serviceRole) {
+		return nil, fmt.Errorf("The service '%s' does not declare migrations", serviceRole)
+	}
+	return []string{serviceRole}, nil
+}
@@ -0,0 +1,65 @@
+package migrator_test
+
+import (
+	"bytes"
+
+	"github.com/Originate/exosphere/src/application/migrator"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Migrator", func() {
+	migrations := types.ServiceMigrationsConfig{Command: "bin/migrations", Directory: "migrations"}
+	appContext := &context.AppContext{
+		Config: types.AppConfig{
+			Services: map[string]types.ServiceSource{"users": {}, "orders": {}, "web": {}},
+		},
+		ServiceContexts: map[string]*context.ServiceContext{
+			"users":  {Config: types.ServiceConfig{Migrations: migrations}},
+			"orders": {Config: types.ServiceConfig{Migrations: migrations}},
+			"web":    {Config: types.ServiceConfig{}},
+		},
+	}
+
+	Describe("GetServiceRoles", func() {
+		It("returns all services that declare migrations if no service is given", func() {
+			serviceRoles, err := migrator.GetServiceRoles(appContext, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serviceRoles).To(Equal([]string{"orders", "users"}))
+		})
+
+		It("returns the given service if it declares migrations", func() {
+			serviceRoles, err := migrator.GetServiceRoles(appContext, "users")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serviceRoles).To(Equal([]string{"users"}))
+		})
+
+		It("returns an error if the given service does not declare migrations", func() {
+			_, err := migrator.GetServiceRoles(appContext, "web")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("The service 'web' does not declare migrations"))
+		})
+
+		It("returns an error if the given service does not exist", func() {
+			_, err := migrator.GetServiceRoles(appContext, "payments")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Unknown service 'payments'"))
+		})
+	})
+
+	Describe("Run", func() {
+		It("returns an error for unsupported actions", func() {
+			err := migrator.Run(migrator.MigrateOptions{AppContext: appContext, Action: "redo", Writer: &bytes.Buffer{}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Unsupported migrations action 'redo'. Must be one of: migrate, status, rollback"))
+		})
+
+		It("requires a service to roll back if multiple services declare migrations", func() {
+			err := migrator.Run(migrator.MigrateOptions{AppContext: appContext, Action: types.MigrationActionRollback, Writer: &bytes.Buffer{}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Please specify the service to roll back"))
+		})
+	})
+})
@@ -0,0 +1,18 @@
+package migrator
+
+import (
+	"io"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+)
+
+// MigrateOptions are the options passed into Run
+type MigrateOptions struct {
+	AppContext               *context.AppContext
+	ServiceRole              string
+	Action                   string
+	DockerComposeProjectName string
+	BuildMode                types.BuildMode
+	Writer                   io.Writer
+}
@@ -1,11 +1,16 @@
 package runner
 
 import (
+	"fmt"
 	"os"
 	"os/signal"
 	"path"
+	"sort"
 
+	"github.com/Originate/exosphere/src/application/scheduler"
+	"github.com/Originate/exosphere/src/docker/composebuilder"
 	"github.com/Originate/exosphere/src/docker/composerunner"
+	"github.com/Originate/exosphere/src/util"
 )
 
 // Run runs the application with graceful shutdown
@@ -15,7 +20,11 @@ func Run(options RunOptions) error {
 		DockerComposeDir:         path.Join(options.AppContext.Location, "docker-compose"),
 		DockerComposeFileName:    options.BuildMode.GetDockerComposeFileName(),
 		DockerComposeProjectName: options.DockerComposeProjectName,
-		Writer: options.Writer,
+		Writer:                   options.Writer,
+	}
+	serviceNames, err := getRunningServiceNames(options)
+	if err != nil {
+		return err
 	}
 	doneChannel := make(chan bool, 1)
 	go func() {
@@ -26,10 +35,57 @@ func Run(options RunOptions) error {
 		doneChannel <- true
 	}()
 	go func() {
-		_ = composerunner.Run(runOptions)
+		switch {
+		case serviceNames == nil:
+			_ = composerunner.Run(runOptions)
+		case len(serviceNames) > 0:
+			_ = composerunner.RunServices(runOptions, serviceNames)
+		default:
+			return
+		}
 		doneChannel <- true
 	}()
+	stopChannel := make(chan bool, 1)
+	if options.WithSchedules {
+		go func() {
+			err := scheduler.Run(scheduler.ScheduleOptions{
+				AppContext:               options.AppContext,
+				DockerComposeProjectName: options.DockerComposeProjectName,
+				BuildMode:                options.BuildMode,
+				Writer:                   options.Writer,
+			}, stopChannel)
+			if err != nil {
+				fmt.Fprintln(options.Writer, err)
+			}
+		}()
+	}
 	<-doneChannel
+	stopChannel <- true
 	_ = composerunner.Shutdown(runOptions)
 	return nil
 }
+
+// returns the names of the containers to start if the application has scheduled services,
+// which run in one-off containers when they are due instead. Returns nil to start all containers
+// and an empty list if there is nothing but scheduled services
+func getRunningServiceNames(options RunOptions) ([]string, error) {
+	scheduledServiceRoles := options.AppContext.GetScheduledServiceRoles()
+	if len(scheduledServiceRoles) == 0 {
+		return nil, nil
+	}
+	dockerCompose, err := composebuilder.GetApplicationDockerCompose(composebuilder.ApplicationOptions{
+		AppContext: options.AppContext,
+		BuildMode:  options.BuildMode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for serviceName := range dockerCompose.Services {
+		if !util.DoesStringArrayContain(scheduledServiceRoles, serviceName) {
+			result = append(result, serviceName)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
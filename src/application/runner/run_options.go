@@ -13,4 +13,5 @@ type RunOptions struct {
 	DockerComposeProjectName string
 	Writer                   io.Writer
 	BuildMode                types.BuildMode
+	WithSchedules            bool
 }
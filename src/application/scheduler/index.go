@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/Originate/exosphere/src/docker/composerunner"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/pkg/errors"
+)
+
+// Run starts a one-off container of each scheduled service whenever its schedule is due,
+// until the given channel receives a value. A run is skipped while the previous run of the service
+// has not finished yet. The schedules are in UTC, like the CloudWatch Events rules of a deploy
+func Run(options ScheduleOptions, stopChannel <-chan bool) error {
+	schedules, err := GetSchedules(options.AppContext)
+	if err != nil || len(schedules) == 0 {
+		return err
+	}
+	serviceRoles := options.AppContext.GetScheduledServiceRoles()
+	runOptions := getRunOptions(options)
+	err = composerunner.BuildServices(runOptions, serviceRoles)
+	if err != nil {
+		return errors.Wrap(err, "Failed to build the scheduled services")
+	}
+	nextRuns := map[string]time.Time{}
+	for serviceRole, schedule := range schedules {
+		nextRuns[serviceRole] = schedule.Next(time.Now())
+	}
+	runningServices := map[string]bool{}
+	var mutex sync.Mutex
+	for {
+		serviceRole := GetNextServiceRole(nextRuns)
+		if serviceRole == "" {
+			<-stopChannel
+			return nil
+		}
+		fmt.Fprintf(options.Writer, "Next run of %s at %s\n", serviceRole, nextRuns[serviceRole].Format(time.RFC3339))
+		select {
+		case <-stopChannel:
+			return nil
+		case <-time.After(time.Until(nextRuns[serviceRole])):
+		}
+		nextRuns[serviceRole] = schedules[serviceRole].Next(nextRuns[serviceRole])
+		mutex.Lock()
+		if runningServices[serviceRole] {
+			mutex.Unlock()
+			fmt.Fprintf(options.Writer, "Skipping the run of %s, the previous run has not finished yet\n", serviceRole)
+			continue
+		}
+		runningServices[serviceRole] = true
+		mutex.Unlock()
+		go func(serviceRole string) {
+			err := runJob(runOptions, serviceRole)
+			if err != nil {
+				fmt.Fprintln(options.Writer, err)
+			}
+			mutex.Lock()
+			delete(runningServices, serviceRole)
+			mutex.Unlock()
+		}(serviceRole)
+	}
+}
+
+// RunJob builds the given scheduled service and runs it once in a one-off container
+func RunJob(options ScheduleOptions, serviceRole string) error {
+	serviceContext, ok := options.AppContext.ServiceContexts[serviceRole]
+	if !ok {
+		return fmt.Errorf("Unknown service '%s'", serviceRole)
+	}
+	if serviceContext.Config.Type != types.ServiceTypeScheduled {
+		return fmt.Errorf("The service '%s' is not a scheduled service", serviceRole)
+	}
+	runOptions := getRunOptions(options)
+	err := composerunner.BuildServices(runOptions, []string{serviceRole})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Failed to build %s", serviceRole))
+	}
+	return runJob(runOptions, serviceRole)
+}
+
+// GetSchedules returns the parsed schedules of the scheduled services of the given application
+func GetSchedules(appContext *context.AppContext) (map[string]types.CronSchedule, error) {
+	result := map[string]types.CronSchedule{}
+	for _, serviceRole := range appContext.GetScheduledServiceRoles() {
+		schedule, err := appContext.ServiceContexts[serviceRole].Config.GetCronSchedule()
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("Invalid schedule of %s", serviceRole))
+		}
+		result[serviceRole] = schedule
+	}
+	return result, nil
+}
+
+// GetNextServiceRole returns the role of the service that runs next given the next run of each service,
+// or an empty string if none of them runs again. Services due at the same time run in alphabetical order
+func GetNextServiceRole(nextRuns map[string]time.Time) string {
+	result := ""
+	for serviceRole, nextRun := range nextRuns {
+		if nextRun.IsZero() {
+			continue
+		}
+		if result == "" || nextRun.Before(nextRuns[result]) || (nextRun.Equal(nextRuns[result]) && serviceRole < result) {
+			result = serviceRole
+		}
+	}
+	return result
+}
+
+func runJob(runOptions composerunner.RunOptions, serviceRole string) error {
+	fmt.Fprintf(runOptions.Writer, "Running %s...\n", serviceRole)
+	err := composerunner.RunCommand(runOptions, serviceRole, []string{})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("The run of %s failed", serviceRole))
+	}
+	fmt.Fprintf(runOptions.Writer, "%s finished\n", serviceRole)
+	return nil
+}
+
+func getRunOptions(options ScheduleOptions) composerunner.RunOptions {
+	return composerunner.RunOptions{
+		AppDir:                   options.AppContext.Location,
+		DockerComposeDir:         path.Join(options.AppContext.Location, "docker-compose"),
+		DockerComposeFileName:    options.BuildMode.GetDockerComposeFileName(),
+		DockerComposeProjectName: options.DockerComposeProjectName,
+		Writer:                   options.Writer,
+	}
+}
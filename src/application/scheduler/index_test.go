@@ -0,0 +1,73 @@
+package scheduler_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/Originate/exosphere/src/application/scheduler"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scheduler", func() {
+	appContext := &context.AppContext{
+		Config: types.AppConfig{
+			Services: map[string]types.ServiceSource{"reports": {}, "cleanup": {}, "web": {}},
+		},
+		ServiceContexts: map[string]*context.ServiceContext{
+			"reports": {Config: types.ServiceConfig{Type: types.ServiceTypeScheduled, Schedule: "0 3 * * *"}},
+			"cleanup": {Config: types.ServiceConfig{Type: types.ServiceTypeScheduled, Schedule: "*/30 * * * *"}},
+			"web":     {Config: types.ServiceConfig{Type: types.ServiceTypePublic}},
+		},
+	}
+
+	Describe("GetSchedules", func() {
+		It("returns the schedules of the scheduled services", func() {
+			schedules, err := scheduler.GetSchedules(appContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedules).To(HaveLen(2))
+			Expect(schedules).To(HaveKey("reports"))
+			Expect(schedules).To(HaveKey("cleanup"))
+		})
+	})
+
+	Describe("GetNextServiceRole", func() {
+		now := time.Date(2017, time.November, 15, 10, 20, 0, 0, time.UTC)
+
+		It("returns the service that runs first", func() {
+			schedules, err := scheduler.GetSchedules(appContext)
+			Expect(err).NotTo(HaveOccurred())
+			nextRuns := map[string]time.Time{}
+			for serviceRole, schedule := range schedules {
+				nextRuns[serviceRole] = schedule.Next(now)
+			}
+			Expect(scheduler.GetNextServiceRole(nextRuns)).To(Equal("cleanup"))
+		})
+
+		It("returns services due at the same time in alphabetical order", func() {
+			nextRuns := map[string]time.Time{"reports": now, "cleanup": now}
+			Expect(scheduler.GetNextServiceRole(nextRuns)).To(Equal("cleanup"))
+		})
+
+		It("ignores services that never run again", func() {
+			nextRuns := map[string]time.Time{"reports": {}, "cleanup": {}}
+			Expect(scheduler.GetNextServiceRole(nextRuns)).To(Equal(""))
+		})
+	})
+
+	Describe("RunJob", func() {
+		It("returns an error if the service is not scheduled", func() {
+			err := scheduler.RunJob(scheduler.ScheduleOptions{AppContext: appContext, Writer: &bytes.Buffer{}}, "web")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("The service 'web' is not a scheduled service"))
+		})
+
+		It("returns an error if the service does not exist", func() {
+			err := scheduler.RunJob(scheduler.ScheduleOptions{AppContext: appContext, Writer: &bytes.Buffer{}}, "payments")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Unknown service 'payments'"))
+		})
+	})
+})
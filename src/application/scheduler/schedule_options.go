@@ -0,0 +1,16 @@
+package scheduler
+
+import (
+	"io"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+)
+
+// ScheduleOptions are the options passed into Run and RunJob
+type ScheduleOptions struct {
+	AppContext               *context.AppContext
+	DockerComposeProjectName string
+	BuildMode                types.BuildMode
+	Writer                   io.Writer
+}
@@ -0,0 +1,230 @@
+package snapshotter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Originate/exosphere/src/config"
+	"github.com/Originate/exosphere/src/docker/composerunner"
+	"github.com/Originate/exosphere/src/docker/tools"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+	"github.com/pkg/errors"
+)
+
+// SnapshotsDir is the directory of an application the snapshots are stored in
+const SnapshotsDir = ".exosphere/snapshots"
+
+// helperImage is the image of the containers that archive and extract the volumes
+const helperImage = "alpine:3.6"
+
+const archiveExtension = ".tar.gz"
+
+// Snapshot represents a stored snapshot and the volumes it contains
+type Snapshot struct {
+	Name        string
+	VolumeNames []string
+}
+
+// Create stops the application and archives the named volumes of the given dependencies,
+// or of all dependencies if none are given, into a new snapshot with the given name
+func Create(options SnapshotOptions, name string, dependencyNames []string) error {
+	err := validateSnapshotName(name)
+	if err != nil {
+		return err
+	}
+	snapshotDir := getSnapshotDir(options.AppContext, name)
+	exists, err := util.DoesDirectoryExist(snapshotDir)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("Snapshot '%s' already exists", name)
+	}
+	volumeNames, err := GetVolumeNames(options.AppContext, dependencyNames)
+	if err != nil {
+		return err
+	}
+	err = shutdown(options)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(snapshotDir, 0777)
+	if err != nil {
+		return err
+	}
+	for _, volumeName := range volumeNames {
+		fmt.Fprintf(options.Writer, "Archiving %s...\n", volumeName)
+		err = runInHelperContainer(options, snapshotDir, volumeName, fmt.Sprintf("tar -czf /snapshot/%s%s -C /volume .", volumeName, archiveExtension))
+		if err != nil {
+			_ = os.RemoveAll(snapshotDir)
+			return errors.Wrap(err, fmt.Sprintf("Failed to archive %s", volumeName))
+		}
+	}
+	return nil
+}
+
+// Restore stops the application and replaces the contents of the volumes
+// contained in the snapshot with the given name
+func Restore(options SnapshotOptions, name string) error {
+	snapshot, err := getSnapshot(options.AppContext, name)
+	if err != nil {
+		return err
+	}
+	err = shutdown(options)
+	if err != nil {
+		return err
+	}
+	snapshotDir := getSnapshotDir(options.AppContext, name)
+	for _, volumeName := range snapshot.VolumeNames {
+		fmt.Fprintf(options.Writer, "Restoring %s...\n", volumeName)
+		err = runInHelperContainer(options, snapshotDir, volumeName, fmt.Sprintf("find /volume -mindepth 1 -delete && tar -xzf /snapshot/%s%s -C /volume", volumeName, archiveExtension))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Failed to restore %s", volumeName))
+		}
+	}
+	return nil
+}
+
+// List returns the snapshots of the application sorted by name
+func List(appContext *context.AppContext) ([]Snapshot, error) {
+	result := []Snapshot{}
+	snapshotsDir := path.Join(appContext.Location, SnapshotsDir)
+	exists, err := util.DoesDirectoryExist(snapshotsDir)
+	if err != nil || !exists {
+		return result, err
+	}
+	fileInfos, err := ioutil.ReadDir(snapshotsDir)
+	if err != nil {
+		return result, err
+	}
+	for _, fileInfo := range fileInfos {
+		if !fileInfo.IsDir() {
+			continue
+		}
+		snapshot, err := getSnapshot(appContext, fileInfo.Name())
+		if err != nil {
+			return result, err
+		}
+		result = append(result, snapshot)
+	}
+	return result, nil
+}
+
+// Reset stops the application and removes the named volumes of the given dependencies,
+// or of all dependencies if none are given, so that they start out empty
+func Reset(options SnapshotOptions, dependencyNames []string) error {
+	volumeNames, err := GetVolumeNames(options.AppContext, dependencyNames)
+	if err != nil {
+		return err
+	}
+	err = shutdown(options)
+	if err != nil {
+		return err
+	}
+	command := []string{"docker", "volume", "rm", "--force"}
+	for _, volumeName := range volumeNames {
+		command = append(command, getDockerVolumeName(options, volumeName))
+	}
+	return util.RunAndPipe("", []string{}, options.Writer, command...)
+}
+
+// GetVolumeNames returns the sorted named volumes of the given dependencies, or of all dependencies if none are given
+func GetVolumeNames(appContext *context.AppContext, dependencyNames []string) ([]string, error) {
+	dependencyVolumeNames := config.GetLocalDependencyVolumeNames(appContext)
+	if len(dependencyVolumeNames) == 0 {
+		return nil, errors.New("No dependency persists data. Add paths to 'config.persist' of the dependencies to store their data in named volumes")
+	}
+	if len(dependencyNames) == 0 {
+		for dependencyName := range dependencyVolumeNames {
+			dependencyNames = append(dependencyNames, dependencyName)
+		}
+	}
+	result := []string{}
+	for _, dependencyName := range dependencyNames {
+		volumeNames, ok := dependencyVolumeNames[dependencyName]
+		if !ok {
+			return nil, fmt.Errorf("The dependency '%s' does not exist or persists no data", dependencyName)
+		}
+		for _, volumeName := range volumeNames {
+			if !util.DoesStringArrayContain(result, volumeName) {
+				result = append(result, volumeName)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func getSnapshot(appContext *context.AppContext, name string) (Snapshot, error) {
+	result := Snapshot{Name: name, VolumeNames: []string{}}
+	snapshotDir := getSnapshotDir(appContext, name)
+	exists, err := util.DoesDirectoryExist(snapshotDir)
+	if err != nil {
+		return result, err
+	}
+	if !exists {
+		return result, fmt.Errorf("Snapshot '%s' does not exist", name)
+	}
+	fileInfos, err := ioutil.ReadDir(snapshotDir)
+	if err != nil {
+		return result, err
+	}
+	for _, fileInfo := range fileInfos {
+		if strings.HasSuffix(fileInfo.Name(), archiveExtension) {
+			result.VolumeNames = append(result.VolumeNames, strings.TrimSuffix(fileInfo.Name(), archiveExtension))
+		}
+	}
+	return result, nil
+}
+
+func getSnapshotDir(appContext *context.AppContext, name string) string {
+	return path.Join(appContext.Location, SnapshotsDir, name)
+}
+
+// docker-compose prefixes the named volumes it creates with the project name
+func getDockerVolumeName(options SnapshotOptions, volumeName string) string {
+	return fmt.Sprintf("%s_%s", options.DockerComposeProjectName, volumeName)
+}
+
+func runInHelperContainer(options SnapshotOptions, snapshotDir, volumeName, command string) error {
+	return tools.RunInDockerContainer(tools.RunConfig{
+		Volumes: []string{
+			fmt.Sprintf("%s:/volume", getDockerVolumeName(options, volumeName)),
+			fmt.Sprintf("%s:/snapshot", snapshotDir),
+		},
+		ImageName: helperImage,
+		Command:   []string{"sh", "-c", command},
+		Writer:    options.Writer,
+	})
+}
+
+// stops the containers of the application, since the volumes cannot be changed while they are in use
+// and cannot be archived consistently while the dependencies are writing to them
+func shutdown(options SnapshotOptions) error {
+	buildMode := types.BuildMode{
+		Type:        types.BuildModeTypeLocal,
+		Mount:       true,
+		Environment: types.BuildModeEnvironmentDevelopment,
+	}
+	return composerunner.Shutdown(composerunner.RunOptions{
+		AppDir:                   options.AppContext.Location,
+		DockerComposeDir:         path.Join(options.AppContext.Location, "docker-compose"),
+		DockerComposeFileName:    buildMode.GetDockerComposeFileName(),
+		DockerComposeProjectName: options.DockerComposeProjectName,
+		Writer:                   options.Writer,
+	})
+}
+
+func validateSnapshotName(name string) error {
+	if !regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$").MatchString(name) {
+		return fmt.Errorf("Invalid snapshot name '%s'. Only alphanumeric characters, dots, underscores and hyphens allowed", name)
+	}
+	return nil
+}
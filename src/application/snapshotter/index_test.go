@@ -0,0 +1,106 @@
+package snapshotter_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/Originate/exosphere/src/application/snapshotter"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Snapshotter", func() {
+	var appContext *context.AppContext
+
+	BeforeEach(func() {
+		appDir, err := ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+		appContext = &context.AppContext{
+			Location: appDir,
+			Config: types.AppConfig{
+				Name: "snapshot-app",
+				Local: types.LocalConfig{
+					Dependencies: []types.LocalDependency{
+						{Name: "mongo", Version: "3.4.0", Config: types.LocalDependencyConfig{Persist: []string{"/data/db", "/data/configdb"}}},
+						{Name: "redis", Version: "4.0.2", Config: types.LocalDependencyConfig{Persist: []string{"/data"}}},
+						{Name: "exocom", Version: "0.27.0"},
+					},
+				},
+			},
+			ServiceContexts: map[string]*context.ServiceContext{},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(appContext.Location)).To(Succeed())
+	})
+
+	Describe("GetVolumeNames", func() {
+		It("returns the volumes of all dependencies if none are given", func() {
+			volumeNames, err := snapshotter.GetVolumeNames(appContext, []string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volumeNames).To(Equal([]string{"mongo__data_configdb", "mongo__data_db", "redis__data"}))
+		})
+
+		It("returns the volumes of the given dependencies", func() {
+			volumeNames, err := snapshotter.GetVolumeNames(appContext, []string{"redis"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volumeNames).To(Equal([]string{"redis__data"}))
+		})
+
+		It("returns an error for dependencies that persist no data", func() {
+			_, err := snapshotter.GetVolumeNames(appContext, []string{"exocom"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("The dependency 'exocom' does not exist or persists no data"))
+		})
+	})
+
+	Describe("List", func() {
+		It("returns no snapshots if there are none", func() {
+			snapshots, err := snapshotter.List(appContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(BeEmpty())
+		})
+
+		It("returns the snapshots with the volumes they contain", func() {
+			for _, filePath := range []string{"seed/mongo__data_db.tar.gz", "seed/redis__data.tar.gz", "empty/.gitkeep"} {
+				fullPath := path.Join(appContext.Location, snapshotter.SnapshotsDir, filePath)
+				Expect(os.MkdirAll(path.Dir(fullPath), 0777)).To(Succeed())
+				Expect(ioutil.WriteFile(fullPath, []byte{}, 0644)).To(Succeed())
+			}
+			snapshots, err := snapshotter.List(appContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(Equal([]snapshotter.Snapshot{
+				{Name: "empty", VolumeNames: []string{}},
+				{Name: "seed", VolumeNames: []string{"mongo__data_db", "redis__data"}},
+			}))
+		})
+	})
+
+	Describe("Create", func() {
+		It("returns an error for invalid snapshot names", func() {
+			err := snapshotter.Create(snapshotter.SnapshotOptions{AppContext: appContext, Writer: &bytes.Buffer{}}, "../seed", []string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Invalid snapshot name '../seed'"))
+		})
+
+		It("returns an error if the snapshot already exists", func() {
+			Expect(os.MkdirAll(path.Join(appContext.Location, snapshotter.SnapshotsDir, "seed"), 0777)).To(Succeed())
+			err := snapshotter.Create(snapshotter.SnapshotOptions{AppContext: appContext, Writer: &bytes.Buffer{}}, "seed", []string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Snapshot 'seed' already exists"))
+		})
+	})
+
+	Describe("Restore", func() {
+		It("returns an error if the snapshot does not exist", func() {
+			err := snapshotter.Restore(snapshotter.SnapshotOptions{AppContext: appContext, Writer: &bytes.Buffer{}}, "seed")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Snapshot 'seed' does not exist"))
+		})
+	})
+})
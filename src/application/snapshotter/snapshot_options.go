@@ -0,0 +1,14 @@
+package snapshotter
+
+import (
+	"io"
+
+	"github.com/Originate/exosphere/src/types/context"
+)
+
+// SnapshotOptions are the options passed into the snapshot functions
+type SnapshotOptions struct {
+	AppContext               *context.AppContext
+	DockerComposeProjectName string
+	Writer                   io.Writer
+}
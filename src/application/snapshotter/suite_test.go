@@ -0,0 +1,13 @@
+package snapshotter_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSnapshotter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "application/snapshotter suite")
+}
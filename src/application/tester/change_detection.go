@@ -0,0 +1,89 @@
+package tester
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// GetChangedFiles returns the paths (relative to appDir) of all files
+// that changed since the given git ref, including untracked files
+func GetChangedFiles(appDir, ref string) ([]string, error) {
+	diffOutput, err := util.Run(appDir, "git", "diff", "--name-only", "--relative", ref)
+	if err != nil {
+		return nil, err
+	}
+	untrackedOutput, err := util.Run(appDir, "git", "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, line := range strings.Split(diffOutput+"\n"+untrackedOutput, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !util.DoesStringArrayContain(result, line) {
+			result = append(result, line)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// GetAffectedServices returns a map from service role to the reason why the service
+// is affected by the given changed files
+func GetAffectedServices(appContext *context.AppContext, changedFiles []string) map[string]string {
+	result := map[string]string{}
+	for _, changedFile := range changedFiles {
+		if path.Clean(changedFile) == "application.yml" {
+			for serviceRole := range appContext.ServiceContexts {
+				result[serviceRole] = "application.yml changed"
+			}
+			return result
+		}
+	}
+	for serviceRole, serviceContext := range appContext.ServiceContexts {
+		if serviceContext.Source.Location == "" {
+			continue
+		}
+		serviceLocation := path.Clean(serviceContext.Source.Location)
+		for _, changedFile := range changedFiles {
+			changedFile = path.Clean(changedFile)
+			if changedFile == serviceLocation || strings.HasPrefix(changedFile, serviceLocation+"/") {
+				result[serviceRole] = fmt.Sprintf("files changed in %s", serviceLocation)
+				break
+			}
+		}
+	}
+	directlyAffectedRoles := []string{}
+	for serviceRole := range result {
+		directlyAffectedRoles = append(directlyAffectedRoles, serviceRole)
+	}
+	sort.Strings(directlyAffectedRoles)
+	for _, affectedRole := range directlyAffectedRoles {
+		affectedMessages := appContext.ServiceContexts[affectedRole].Config.ServiceMessages
+		for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+			if _, ok := result[serviceRole]; ok {
+				continue
+			}
+			serviceMessages := appContext.ServiceContexts[serviceRole].Config.ServiceMessages
+			if message := getSharedMessage(affectedMessages.Sends, serviceMessages.Receives); message != "" {
+				result[serviceRole] = fmt.Sprintf("receives '%s' sent by %s", message, affectedRole)
+			} else if message := getSharedMessage(affectedMessages.Receives, serviceMessages.Sends); message != "" {
+				result[serviceRole] = fmt.Sprintf("sends '%s' received by %s", message, affectedRole)
+			}
+		}
+	}
+	return result
+}
+
+func getSharedMessage(messages1, messages2 []string) string {
+	for _, message := range messages1 {
+		if util.DoesStringArrayContain(messages2, message) {
+			return message
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package tester_test
+
+import (
+	"github.com/Originate/exosphere/src/application/tester"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetAffectedServices", func() {
+	var appContext *context.AppContext
+
+	var _ = BeforeEach(func() {
+		appContext = &context.AppContext{
+			Config: types.AppConfig{
+				Services: map[string]types.ServiceSource{
+					"web":      {Location: "./web"},
+					"users":    {Location: "./users"},
+					"todos":    {Location: "./todos"},
+					"external": {DockerImage: "originate/external"},
+				},
+			},
+			ServiceContexts: map[string]*context.ServiceContext{},
+		}
+		serviceMessages := map[string]types.ServiceMessages{
+			"web": {
+				Sends:    []string{"users.list"},
+				Receives: []string{"users.listed"},
+			},
+			"users": {
+				Sends:    []string{"users.listed"},
+				Receives: []string{"users.list"},
+			},
+			"todos": {
+				Sends: []string{"users.list"},
+			},
+			"external": {},
+		}
+		for serviceRole, serviceSource := range appContext.Config.Services {
+			source := serviceSource
+			appContext.ServiceContexts[serviceRole] = &context.ServiceContext{
+				Role:       serviceRole,
+				AppContext: appContext,
+				Source:     &source,
+				Config:     types.ServiceConfig{ServiceMessages: serviceMessages[serviceRole]},
+			}
+		}
+	})
+
+	It("returns nothing if no service files changed", func() {
+		result := tester.GetAffectedServices(appContext, []string{"README.md"})
+		Expect(result).To(BeEmpty())
+	})
+
+	It("selects services whose files changed", func() {
+		result := tester.GetAffectedServices(appContext, []string{"web/src/index.js"})
+		Expect(result).To(HaveKeyWithValue("web", "files changed in web"))
+	})
+
+	It("selects services that communicate with changed services", func() {
+		result := tester.GetAffectedServices(appContext, []string{"users/service.yml"})
+		Expect(result).To(Equal(map[string]string{
+			"users": "files changed in users",
+			"web":   "receives 'users.listed' sent by users",
+			"todos": "sends 'users.list' received by users",
+		}))
+		result = tester.GetAffectedServices(appContext, []string{"web/server.js"})
+		Expect(result).To(Equal(map[string]string{
+			"web":   "files changed in web",
+			"users": "receives 'users.list' sent by web",
+		}))
+	})
+
+	It("selects all services if application.yml changed", func() {
+		result := tester.GetAffectedServices(appContext, []string{"application.yml"})
+		Expect(result).To(HaveLen(4))
+		Expect(result["external"]).To(Equal("application.yml changed"))
+	})
+})
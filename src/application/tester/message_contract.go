@@ -0,0 +1,96 @@
+package tester
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// RecordedMessage represents a message that was routed by exocom
+type RecordedMessage struct {
+	Sender    string
+	Name      string
+	Receivers []string
+}
+
+// exocom logs each message it routes as "<sender>  --[ <message name> ]->  <receiver>, <receiver>",
+// followed by the response time "( 12.1261ms )" for replies
+var exocomMessageRegex = regexp.MustCompile(`^(\S+)\s+--\[\s*(\S+)\s*\]->\s*(.*?)(\s*\(\s*[\d.]+\w*\s*\))?$`)
+
+// ParseRecordedMessages returns the messages found in the given exocom logs
+func ParseRecordedMessages(logs string) []RecordedMessage {
+	result := []RecordedMessage{}
+	for _, line := range strings.Split(logs, "\n") {
+		_, output := util.ParseDockerComposeLog("exocom", util.NormalizeDockerComposeLog(line))
+		matches := exocomMessageRegex.FindStringSubmatch(strings.TrimSpace(output))
+		if len(matches) != 5 {
+			continue
+		}
+		receivers := []string{}
+		for _, receiver := range strings.Split(matches[3], ",") {
+			receiver = strings.TrimSpace(receiver)
+			if receiver != "" && receiver != "(none)" {
+				receivers = append(receivers, receiver)
+			}
+		}
+		result = append(result, RecordedMessage{
+			Sender:    matches[1],
+			Name:      matches[2],
+			Receivers: receivers,
+		})
+	}
+	return result
+}
+
+// VerifyMessageContracts compares the recorded messages with the messages each of the given services declares
+// and returns a report for every one of them that has declared or sent messages.
+// Only the services that ran while the messages were recorded should be given.
+// The declared receives are only verified if the messages include the end-to-end tests,
+// because nothing sends a service the messages it receives while its own tests run
+func VerifyMessageContracts(appContext *context.AppContext, serviceRoles []string, messages []RecordedMessage, includesE2E bool) []types.MessageContractReport {
+	result := []types.MessageContractReport{}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		if !util.DoesStringArrayContain(serviceRoles, serviceRole) {
+			continue
+		}
+		serviceMessages := appContext.ServiceContexts[serviceRole].Config.ServiceMessages
+		serviceSource := appContext.Config.Services[serviceRole]
+		report := types.MessageContractReport{
+			Role:              serviceRole,
+			UndeclaredSends:   []string{},
+			UnhandledReceives: []string{},
+		}
+		receivedMessages := []string{}
+		for _, message := range messages {
+			if message.Sender == serviceRole && !isMessageDeclared(serviceMessages.Sends, message.Name, serviceSource) && !util.DoesStringArrayContain(report.UndeclaredSends, message.Name) {
+				report.UndeclaredSends = append(report.UndeclaredSends, message.Name)
+			}
+			if util.DoesStringArrayContain(message.Receivers, serviceRole) {
+				receivedMessages = append(receivedMessages, message.Name)
+			}
+		}
+		for _, messageName := range serviceMessages.Receives {
+			if includesE2E && !isMessageDeclared(receivedMessages, messageName, serviceSource) {
+				report.UnhandledReceives = append(report.UnhandledReceives, messageName)
+			}
+		}
+		if len(serviceMessages.Sends) > 0 || len(serviceMessages.Receives) > 0 || len(report.UndeclaredSends) > 0 {
+			result = append(result, report)
+		}
+	}
+	return result
+}
+
+// returns whether the given message name is in messageNames,
+// either under the same name or under the public name of the service
+func isMessageDeclared(messageNames []string, messageName string, serviceSource types.ServiceSource) bool {
+	for _, name := range messageNames {
+		if name == messageName || serviceSource.GetPublicMessageName(name) == messageName || serviceSource.GetPublicMessageName(messageName) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package tester_test
+
+import (
+	"github.com/Originate/exosphere/src/application/tester"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("message contracts", func() {
+	var _ = Describe("ParseRecordedMessages", func() {
+		It("parses the messages routed by exocom", func() {
+			logs := "exocom0.26.1    | exocom online at port 80\n" +
+				"exocom0.26.1    | web  --[ users.list ]->  users\n" +
+				"exocom0.26.1    | users  --[ users.listed ]->  web, admin\n" +
+				"exocom0.26.1    | web  --[ users.delete ]->  (none)\n"
+			Expect(tester.ParseRecordedMessages(logs)).To(Equal([]tester.RecordedMessage{
+				{Sender: "web", Name: "users.list", Receivers: []string{"users"}},
+				{Sender: "users", Name: "users.listed", Receivers: []string{"web", "admin"}},
+				{Sender: "web", Name: "users.delete", Receivers: []string{}},
+			}))
+		})
+
+		It("ignores the payloads and response times exocom logs with the messages", func() {
+			logs := "exocom0.26.1    | html-server  --[ todo.list ]->  todo\n" +
+				"exocom0.26.1    | {}\n" +
+				"todo            | listing todos: 0 found\n" +
+				"exocom0.26.1    | todo  --[ todo.listing ]->  html-server  ( 12.1261ms )\n" +
+				"exocom0.26.1    | []\n"
+			Expect(tester.ParseRecordedMessages(logs)).To(Equal([]tester.RecordedMessage{
+				{Sender: "html-server", Name: "todo.list", Receivers: []string{"todo"}},
+				{Sender: "todo", Name: "todo.listing", Receivers: []string{"html-server"}},
+			}))
+		})
+	})
+
+	var _ = Describe("VerifyMessageContracts", func() {
+		var appContext *context.AppContext
+
+		var _ = BeforeEach(func() {
+			appContext = &context.AppContext{
+				Config: types.AppConfig{
+					Services: map[string]types.ServiceSource{
+						"web": {Location: "./web"},
+						"users": {
+							Location: "./users",
+							MessageTranslations: []types.MessageTranslation{
+								{Public: "users", Internal: "mongo"},
+							},
+						},
+					},
+				},
+				ServiceContexts: map[string]*context.ServiceContext{
+					"web": {Config: types.ServiceConfig{ServiceMessages: types.ServiceMessages{
+						Sends:    []string{"users.list"},
+						Receives: []string{"users.listed", "users.created"},
+					}}},
+					"users": {Config: types.ServiceConfig{ServiceMessages: types.ServiceMessages{
+						Sends:    []string{"mongo.listed"},
+						Receives: []string{"mongo.list"},
+					}}},
+				},
+			}
+		})
+
+		It("reports undeclared sends and unhandled receives", func() {
+			messages := []tester.RecordedMessage{
+				{Sender: "web", Name: "users.list", Receivers: []string{"users"}},
+				{Sender: "web", Name: "users.delete", Receivers: []string{}},
+				{Sender: "users", Name: "users.listed", Receivers: []string{"web"}},
+			}
+			Expect(tester.VerifyMessageContracts(appContext, []string{"users", "web"}, messages, true)).To(Equal([]types.MessageContractReport{
+				{Role: "users", UndeclaredSends: []string{}, UnhandledReceives: []string{}},
+				{Role: "web", UndeclaredSends: []string{"users.delete"}, UnhandledReceives: []string{"users.created"}},
+			}))
+		})
+
+		It("only verifies the given services", func() {
+			messages := []tester.RecordedMessage{
+				{Sender: "web", Name: "users.list", Receivers: []string{"users"}},
+			}
+			Expect(tester.VerifyMessageContracts(appContext, []string{"web"}, messages, true)).To(Equal([]types.MessageContractReport{
+				{Role: "web", UndeclaredSends: []string{}, UnhandledReceives: []string{"users.listed", "users.created"}},
+			}))
+		})
+
+		It("does not verify the received messages without the end-to-end tests", func() {
+			messages := []tester.RecordedMessage{
+				{Sender: "web", Name: "users.list", Receivers: []string{"users"}},
+			}
+			Expect(tester.VerifyMessageContracts(appContext, []string{"users", "web"}, messages, false)).To(Equal([]types.MessageContractReport{
+				{Role: "users", UndeclaredSends: []string{}, UnhandledReceives: []string{}},
+				{Role: "web", UndeclaredSends: []string{}, UnhandledReceives: []string{}},
+			}))
+		})
+
+		It("only applies translations to whole segments of message names", func() {
+			appContext.ServiceContexts["users"].Config.ServiceMessages.Sends = []string{"mongo.listed", "mongo-admin.listed"}
+			messages := []tester.RecordedMessage{
+				{Sender: "users", Name: "users.listed", Receivers: []string{"web"}},
+				{Sender: "users", Name: "users-admin.listed", Receivers: []string{}},
+				{Sender: "web", Name: "users.list", Receivers: []string{"users"}},
+			}
+			Expect(tester.VerifyMessageContracts(appContext, []string{"users"}, messages, true)).To(Equal([]types.MessageContractReport{
+				{Role: "users", UndeclaredSends: []string{"users-admin.listed"}, UnhandledReceives: []string{}},
+			}))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package tester_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestTester(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "application/tester Suite")
+}
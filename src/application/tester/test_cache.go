@@ -0,0 +1,99 @@
+package tester
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const testCacheDir = ".exosphere/cache/tests"
+
+// GetServiceTestHash returns a hash of everything that can influence the result of the
+// tests for the given service: the contents of its directory (including Dockerfile.dev
+// and service.yml), its dependencies and the relevant sections of application.yml
+func GetServiceTestHash(serviceContext *context.ServiceContext) (string, error) {
+	hash := sha256.New()
+	serviceDir := path.Join(serviceContext.AppContext.Location, serviceContext.Source.Location)
+	err := filepath.Walk(serviceDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(serviceDir, filePath)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(hash, relativePath+"\n")
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close() // nolint errcheck
+		_, err = io.Copy(hash, file)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	appSections, err := yaml.Marshal(map[string]interface{}{
+		"local":   serviceContext.AppContext.Config.Local,
+		"service": serviceContext.Source,
+	})
+	if err != nil {
+		return "", err
+	}
+	_, err = hash.Write(appSections)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// IsTestCached returns whether or not the tests for the given service
+// have already passed with the given hash
+func IsTestCached(serviceContext *context.ServiceContext, hash string) (bool, error) {
+	cacheFilePath := getTestCacheFilePath(serviceContext)
+	exists, err := util.DoesFileExist(cacheFilePath)
+	if err != nil || !exists {
+		return false, err
+	}
+	content, err := ioutil.ReadFile(cacheFilePath)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(content)) == hash, nil
+}
+
+// CacheTestResult stores the given hash as having passed the tests for the given service
+func CacheTestResult(serviceContext *context.ServiceContext, hash string) error {
+	cacheFilePath := getTestCacheFilePath(serviceContext)
+	err := util.MakeDirectory(path.Dir(cacheFilePath))
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(cacheFilePath, []byte(hash), 0644)
+}
+
+func getTestCacheFilePath(serviceContext *context.ServiceContext) string {
+	return path.Join(serviceContext.AppContext.Location, testCacheDir, serviceContext.Role)
+}
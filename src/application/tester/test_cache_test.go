@@ -0,0 +1,69 @@
+package tester_test
+
+import (
+	"io/ioutil"
+	"path"
+
+	"github.com/Originate/exosphere/src/application/tester"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/test/helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("test cache", func() {
+	var appDir string
+	var serviceContext *context.ServiceContext
+
+	var _ = BeforeEach(func() {
+		var err error
+		appDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+		err = helpers.CheckoutApp(appDir, "e2e")
+		Expect(err).NotTo(HaveOccurred())
+		appContext, err := context.GetAppContext(appDir)
+		Expect(err).NotTo(HaveOccurred())
+		serviceContext = appContext.ServiceContexts["web"]
+	})
+
+	It("returns the same hash if nothing changed", func() {
+		hash1, err := tester.GetServiceTestHash(serviceContext)
+		Expect(err).NotTo(HaveOccurred())
+		hash2, err := tester.GetServiceTestHash(serviceContext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hash1).To(Equal(hash2))
+	})
+
+	It("returns a different hash if a file of the service changed", func() {
+		hash1, err := tester.GetServiceTestHash(serviceContext)
+		Expect(err).NotTo(HaveOccurred())
+		err = ioutil.WriteFile(path.Join(appDir, "web", "server.js"), []byte("changed"), 0644)
+		Expect(err).NotTo(HaveOccurred())
+		hash2, err := tester.GetServiceTestHash(serviceContext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hash1).NotTo(Equal(hash2))
+	})
+
+	It("returns a different hash if an application dependency changed", func() {
+		hash1, err := tester.GetServiceTestHash(serviceContext)
+		Expect(err).NotTo(HaveOccurred())
+		serviceContext.AppContext.Config.Local.Dependencies[0].Version = "0.27.0"
+		hash2, err := tester.GetServiceTestHash(serviceContext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hash1).NotTo(Equal(hash2))
+	})
+
+	It("caches passing test results", func() {
+		cached, err := tester.IsTestCached(serviceContext, "hash1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cached).To(Equal(false))
+		err = tester.CacheTestResult(serviceContext, "hash1")
+		Expect(err).NotTo(HaveOccurred())
+		cached, err = tester.IsTestCached(serviceContext, "hash1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cached).To(Equal(true))
+		cached, err = tester.IsTestCached(serviceContext, "hash2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cached).To(Equal(false))
+	})
+})
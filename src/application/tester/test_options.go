@@ -0,0 +1,32 @@
+package tester
+
+import (
+	"time"
+
+	"github.com/Originate/exosphere/src/types"
+)
+
+// TestOptions are the options passed into the test functions
+type TestOptions struct {
+	NoCache bool
+	// Timeout overrides the 'development.test.timeout' of each service if not 0
+	Timeout time.Duration
+	// Retries overrides the 'development.test.retries' of each service if not nil
+	Retries *int
+	// StrictContracts fails the tests if a service sends or receives undeclared messages
+	StrictContracts bool
+}
+
+func (t TestOptions) getTimeout(testConfig types.ServiceTestConfig) time.Duration {
+	if t.Timeout != 0 {
+		return t.Timeout
+	}
+	return testConfig.GetTimeout()
+}
+
+func (t TestOptions) getRetries(testConfig types.ServiceTestConfig) int {
+	if t.Retries != nil {
+		return *t.Retries
+	}
+	return testConfig.Retries
+}
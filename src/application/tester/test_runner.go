@@ -1,10 +1,12 @@
 package tester
 
 import (
+	"bytes"
 	"io"
 	"path"
 	"strings"
 
+	"github.com/Originate/exosphere/src/config"
 	"github.com/Originate/exosphere/src/docker/composebuilder"
 	"github.com/Originate/exosphere/src/docker/composerunner"
 	"github.com/Originate/exosphere/src/types"
@@ -13,10 +15,11 @@ import (
 
 // TestRunner runs the tests for the given service
 type TestRunner struct {
-	AppContext *context.AppContext
-	BuildMode  types.BuildMode
-	RunOptions composerunner.RunOptions
-	Writer     io.Writer
+	AppContext       *context.AppContext
+	BuildMode        types.BuildMode
+	RunOptions       composerunner.RunOptions
+	Writer           io.Writer
+	RecordedMessages []RecordedMessage
 }
 
 // NewTestRunner is TestRunner's constructor
@@ -46,6 +49,28 @@ func (s *TestRunner) RunTest(serviceRole string) (int, error) {
 	return 0, nil
 }
 
+// PrintLogs prints the logs of the given service
+func (s *TestRunner) PrintLogs(serviceRole string) error {
+	return composerunner.PrintLogs(s.RunOptions, serviceRole)
+}
+
+// RecordMessages records the messages routed by exocom during the tests
+func (s *TestRunner) RecordMessages() error {
+	exocomContainerName := getExocomContainerName(s.AppContext)
+	if exocomContainerName == "" {
+		return nil
+	}
+	var logs bytes.Buffer
+	runOptions := s.RunOptions
+	runOptions.Writer = &logs
+	err := composerunner.PrintLogs(runOptions, exocomContainerName)
+	if err != nil {
+		return err
+	}
+	s.RecordedMessages = append(s.RecordedMessages, ParseRecordedMessages(logs.String())...)
+	return nil
+}
+
 // Shutdown shuts down the tests
 func (s *TestRunner) Shutdown() error {
 	return composerunner.Shutdown(s.RunOptions)
@@ -53,12 +78,27 @@ func (s *TestRunner) Shutdown() error {
 
 func (s *TestRunner) getRunOptions() (composerunner.RunOptions, error) {
 	dockerComposeProjectName := composebuilder.GetTestDockerComposeProjectName(s.AppContext.Config.Name)
+	exitCodeFrom := ""
+	if s.BuildMode.Environment == types.BuildModeEnvironmentE2E {
+		dockerComposeProjectName = composebuilder.GetE2EDockerComposeProjectName(s.AppContext.Config.Name)
+		exitCodeFrom = composebuilder.E2EServiceName
+	}
 	return composerunner.RunOptions{
 		AppDir:                   s.AppContext.Location,
 		DockerComposeDir:         path.Join(s.AppContext.Location, "docker-compose"),
 		DockerComposeFileName:    s.BuildMode.GetDockerComposeFileName(),
 		DockerComposeProjectName: dockerComposeProjectName,
-		Writer:      s.Writer,
-		AbortOnExit: true,
+		Writer:       s.Writer,
+		AbortOnExit:  true,
+		ExitCodeFrom: exitCodeFrom,
 	}, nil
 }
+
+func getExocomContainerName(appContext *context.AppContext) string {
+	for _, dependency := range appContext.Config.Local.Dependencies {
+		if dependency.Name == "exocom" {
+			return config.NewLocalAppDependency(dependency, appContext).GetContainerName()
+		}
+	}
+	return ""
+}
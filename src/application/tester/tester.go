@@ -1,25 +1,63 @@
 package tester
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"time"
 
+	"github.com/Originate/exosphere/src/docker/composebuilder"
 	"github.com/Originate/exosphere/src/types"
 	"github.com/Originate/exosphere/src/types/context"
 	"github.com/Originate/exosphere/src/util"
 	"github.com/fatih/color"
+	"github.com/pkg/errors"
 )
 
 // TestApp runs the tests for the entire application and return true if the tests passed
 // and an error if any
-func TestApp(appContext *context.AppContext, writer io.Writer, mode types.BuildMode, shutdown chan os.Signal) (types.TestResult, error) {
-	failedTests := []string{}
-	locations := []string{}
-	testRunner, err := NewTestRunner(appContext, writer, mode)
+func TestApp(appContext *context.AppContext, writer io.Writer, mode types.BuildMode, options TestOptions, shutdown chan os.Signal) (types.TestResult, error) {
+	return testServices(appContext, appContext.Config.GetSortedServiceRoles(), writer, mode, options, shutdown)
+}
+
+// TestAffectedServices runs the tests for the services affected by the changes
+// since the given git ref and return true if the tests passed and an error if any
+func TestAffectedServices(appContext *context.AppContext, ref string, writer io.Writer, mode types.BuildMode, options TestOptions, shutdown chan os.Signal) (types.TestResult, error) {
+	changedFiles, err := GetChangedFiles(appContext.Location, ref)
 	if err != nil {
 		return types.TestResult{}, err
 	}
+	affectedServices := GetAffectedServices(appContext, changedFiles)
+	if len(affectedServices) == 0 {
+		util.PrintSectionHeaderf(writer, "No services affected by changes since '%s', skipping\n", ref)
+		return types.TestResult{Passed: true}, nil
+	}
+	serviceRoles := []string{}
 	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		if reason, ok := affectedServices[serviceRole]; ok {
+			_, err = fmt.Fprintf(writer, "%s selected: %s\n", serviceRole, reason)
+			if err != nil {
+				return types.TestResult{}, err
+			}
+			serviceRoles = append(serviceRoles, serviceRole)
+		}
+	}
+	return testServices(appContext, serviceRoles, writer, mode, options, shutdown)
+}
+
+func testServices(appContext *context.AppContext, serviceRoles []string, writer io.Writer, mode types.BuildMode, options TestOptions, shutdown chan os.Signal) (types.TestResult, error) {
+	result := types.TestResult{
+		Failed: []string{},
+		Flaky:  []string{},
+		Cached: []string{},
+	}
+	locations := []string{}
+	testedRoles := []string{}
+	testRunner, err := NewTestRunner(appContext, writer, mode)
+	if err != nil {
+		return result, err
+	}
+	for _, serviceRole := range serviceRoles {
 		serviceContext := appContext.ServiceContexts[serviceRole]
 		serviceLocation := serviceContext.Source.Location
 		if serviceLocation == "" || util.DoesStringArrayContain(locations, serviceLocation) {
@@ -29,39 +67,173 @@ func TestApp(appContext *context.AppContext, writer io.Writer, mode types.BuildM
 		if serviceContext.Config.Development.Scripts["test"] == "" {
 			util.PrintSectionHeaderf(writer, "%s has no tests, skipping\n", serviceContext.ID())
 		} else {
+			var hash string
+			hash, err = GetServiceTestHash(serviceContext)
+			if err != nil {
+				return result, err
+			}
+			if !options.NoCache {
+				var cached bool
+				cached, err = IsTestCached(serviceContext, hash)
+				if err != nil {
+					return result, err
+				}
+				if cached {
+					util.PrintSectionHeaderf(writer, "%s passed before with the same content, skipping (cached)\n", serviceContext.ID())
+					result.Cached = append(result.Cached, serviceContext.ID())
+					continue
+				}
+			}
 			var testResult types.TestResult
-			testResult, err = runServiceTest(testRunner, serviceContext, writer, shutdown)
+			var flaky bool
+			testResult, flaky, err = runServiceTestWithRetries(testRunner, serviceContext, writer, options, shutdown)
 			if err != nil {
 				util.PrintSectionHeaderf(writer, "error running '%s' tests:", err)
 			}
 			if testResult.Interrupted {
 				return testResult, nil
 			}
+			testedRoles = append(testedRoles, serviceRole)
 			if !testResult.Passed {
-				failedTests = append(failedTests, serviceContext.ID())
+				result.Failed = append(result.Failed, serviceContext.ID())
+			} else {
+				if flaky {
+					result.Flaky = append(result.Flaky, serviceContext.ID())
+				}
+				err = CacheTestResult(serviceContext, hash)
+				if err != nil {
+					return result, err
+				}
 			}
 		}
 	}
-	err = printResults(failedTests, writer)
-	return types.TestResult{
-		Passed: len(failedTests) == 0,
-	}, err
+	err = printResults(result, writer)
+	if err != nil {
+		return result, err
+	}
+	result.Passed = len(result.Failed) == 0
+	recordedMessages := testRunner.RecordedMessages
+	includesE2E := appContext.Config.Local.E2E.IsConfigured()
+	if includesE2E {
+		var e2eResult types.TestResult
+		var e2eMessages []RecordedMessage
+		e2eResult, e2eMessages, err = testE2E(appContext, writer, options, shutdown)
+		if err != nil || e2eResult.Interrupted {
+			return e2eResult, err
+		}
+		if !e2eResult.Passed {
+			result.Passed = false
+			result.Failed = append(result.Failed, composebuilder.E2EServiceName)
+		}
+		recordedMessages = append(recordedMessages, e2eMessages...)
+		testedRoles = appContext.Config.GetSortedServiceRoles()
+	}
+	return result, verifyMessageContracts(appContext, testedRoles, recordedMessages, includesE2E, options, &result, writer)
 }
 
-func printResults(failedTests []string, writer io.Writer) error {
-	if len(failedTests) == 0 {
+// runs the end-to-end tests for the application against the full development stack
+// and returns the result, the messages routed by exocom during the tests and an error if any
+func testE2E(appContext *context.AppContext, writer io.Writer, options TestOptions, shutdown chan os.Signal) (types.TestResult, []RecordedMessage, error) {
+	testRunner, err := NewTestRunner(appContext, writer, types.BuildMode{
+		Type:        types.BuildModeTypeLocal,
+		Environment: types.BuildModeEnvironmentE2E,
+	})
+	if err != nil {
+		return types.TestResult{}, nil, err
+	}
+	util.PrintSectionHeader(writer, "Running end-to-end tests\n")
+	testResult, err := runTest(testRunner, composebuilder.E2EServiceName, options.Timeout, shutdown)
+	if err != nil || testResult.Interrupted {
+		return testResult, nil, err
+	}
+	return testResult, testRunner.RecordedMessages, printE2EResult(testResult, writer)
+}
+
+// verifies that every service that ran during the tests only sends and receives the messages it declares,
+// fails the tests on violations if options.StrictContracts is set. Services that were skipped,
+// because they were cached or not affected by the changes, are not verified. The declared receives
+// are only verified by the end-to-end tests
+func verifyMessageContracts(appContext *context.AppContext, serviceRoles []string, recordedMessages []RecordedMessage, includesE2E bool, options TestOptions, result *types.TestResult, writer io.Writer) error {
+	if getExocomContainerName(appContext) == "" {
+		return nil
+	}
+	util.PrintSectionHeader(writer, "Message contracts\n")
+	if len(recordedMessages) == 0 {
+		_, err := fmt.Fprintln(writer, "No messages were recorded, skipping message contract verification")
+		return err
+	}
+	if !includesE2E {
+		_, err := fmt.Fprintln(writer, "No end-to-end tests configured, skipping the verification of received messages")
+		if err != nil {
+			return err
+		}
+	}
+	result.Contracts = VerifyMessageContracts(appContext, serviceRoles, recordedMessages, includesE2E)
+	c := color.New(color.FgYellow)
+	if options.StrictContracts {
+		c = color.New(color.FgRed)
+	}
+	for _, report := range result.Contracts {
+		if report.IsValid() {
+			_, err := color.New(color.FgGreen).Fprintf(writer, "%s: ok\n", report.Role)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		for _, messageName := range report.UndeclaredSends {
+			_, err := c.Fprintf(writer, "%s: sends undeclared message '%s'\n", report.Role, messageName)
+			if err != nil {
+				return err
+			}
+		}
+		for _, messageName := range report.UnhandledReceives {
+			_, err := c.Fprintf(writer, "%s: never received declared message '%s'\n", report.Role, messageName)
+			if err != nil {
+				return err
+			}
+		}
+		if options.StrictContracts {
+			result.Passed = false
+		}
+	}
+	return nil
+}
+
+func printE2EResult(testResult types.TestResult, writer io.Writer) error {
+	if testResult.Passed {
+		green := color.New(color.FgGreen)
+		_, err := green.Fprint(writer, "End-to-end tests passed\n\n")
+		return err
+	}
+	red := color.New(color.FgRed)
+	_, err := red.Fprint(writer, "End-to-end tests failed\n\n")
+	return err
+}
+
+func printResults(result types.TestResult, writer io.Writer) error {
+	yellow := color.New(color.FgYellow)
+	err := printList(yellow, writer, "The following tests were cached:", result.Cached)
+	if err != nil {
+		return err
+	}
+	err = printList(yellow, writer, "The following tests are flaky (passed only on retry):", result.Flaky)
+	if err != nil {
+		return err
+	}
+	if len(result.Failed) == 0 {
 		green := color.New(color.FgGreen)
-		_, err := green.Fprint(writer, "All tests passed\n\n")
+		_, err = green.Fprint(writer, "All tests passed\n\n")
 		if err != nil {
 			return err
 		}
 	}
 	red := color.New(color.FgRed)
-	_, err := red.Fprint(writer, "The following tests failed:\n")
+	_, err = red.Fprint(writer, "The following tests failed:\n")
 	if err != nil {
 		return err
 	}
-	for _, failedTest := range failedTests {
+	for _, failedTest := range result.Failed {
 		_, err = red.Fprintln(writer, failedTest)
 		if err != nil {
 			return err
@@ -70,37 +242,90 @@ func printResults(failedTests []string, writer io.Writer) error {
 	return nil
 }
 
+func printList(c *color.Color, writer io.Writer, header string, items []string) error {
+	if len(items) == 0 {
+		return nil
+	}
+	_, err := c.Fprintln(writer, header)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		_, err = c.Fprintln(writer, item)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // TestService runs the tests for the service and return true if the tests passed
 // and an error if any
-func TestService(serviceContext *context.ServiceContext, writer io.Writer, mode types.BuildMode, shutdown chan os.Signal) (types.TestResult, error) {
+func TestService(serviceContext *context.ServiceContext, writer io.Writer, mode types.BuildMode, options TestOptions, shutdown chan os.Signal) (types.TestResult, error) {
 	testRunner, err := NewTestRunner(serviceContext.AppContext, writer, mode)
 	if err != nil {
 		return types.TestResult{}, err
 	}
-	return runServiceTest(testRunner, serviceContext, writer, shutdown)
+	testResult, flaky, err := runServiceTestWithRetries(testRunner, serviceContext, writer, options, shutdown)
+	if flaky {
+		testResult.Flaky = []string{serviceContext.ID()}
+	}
+	return testResult, err
 }
 
-func runServiceTest(testRunner *TestRunner, serviceContext *context.ServiceContext, writer io.Writer, shutdown chan os.Signal) (types.TestResult, error) {
-	util.PrintSectionHeaderf(writer, "Testing service '%s'\n", serviceContext.ID())
+// runs the tests for the service, retrying failed runs up to the configured number of retries,
+// returns the result of the last run and whether or not the tests only passed on retry
+func runServiceTestWithRetries(testRunner *TestRunner, serviceContext *context.ServiceContext, writer io.Writer, options TestOptions, shutdown chan os.Signal) (types.TestResult, bool, error) {
+	testConfig := serviceContext.Config.Development.Test
+	retries := options.getRetries(testConfig)
+	timeout := options.getTimeout(testConfig)
+	for attempt := 0; ; attempt++ {
+		util.PrintSectionHeaderf(writer, "Testing service '%s'\n", serviceContext.ID())
+		testResult, err := runTest(testRunner, serviceContext.Role, timeout, shutdown)
+		if err != nil || testResult.Interrupted || testResult.Passed || attempt >= retries {
+			return testResult, testResult.Passed && attempt > 0, err
+		}
+		util.PrintSectionHeaderf(writer, "'%s' tests failed, retrying (%d/%d)\n", serviceContext.ID(), attempt+1, retries)
+	}
+}
 
-	testExit := make(chan int)
-	testError := make(chan error)
+func runTest(testRunner *TestRunner, serviceRole string, timeout time.Duration, shutdown chan os.Signal) (types.TestResult, error) {
+	testExit := make(chan int, 1)
+	testError := make(chan error, 1)
 	go func() {
-		exitCode, err := testRunner.RunTest(serviceContext.Role)
+		exitCode, err := testRunner.RunTest(serviceRole)
 		if err != nil {
 			testError <- err
 			return
 		}
 		testExit <- exitCode
 	}()
+	var testTimeout <-chan time.Time
+	if timeout > 0 {
+		testTimeout = time.After(timeout)
+	}
 
 	select {
 	case <-shutdown:
 		return types.TestResult{Interrupted: true}, testRunner.Shutdown()
+	case <-testTimeout:
+		util.PrintSectionHeaderf(testRunner.Writer, "'%s' tests timed out after %s\n", serviceRole, timeout)
+		testRunner.PrintLogs(serviceRole) // nolint errcheck
+		return types.TestResult{TimedOut: true}, recordMessagesAndShutdown(testRunner)
 	case err := <-testError:
 		testRunner.Shutdown() // nolint errcheck
 		return types.TestResult{}, err
 	case exitCode := <-testExit:
-		return types.TestResult{Passed: exitCode == 0}, testRunner.Shutdown()
+		return types.TestResult{Passed: exitCode == 0}, recordMessagesAndShutdown(testRunner)
+	}
+}
+
+// records the messages routed by exocom during the tests before shutting them down
+func recordMessagesAndShutdown(testRunner *TestRunner) error {
+	err := testRunner.RecordMessages()
+	if err != nil {
+		testRunner.Shutdown() // nolint errcheck
+		return errors.Wrap(err, "Failed to record the messages routed by exocom")
 	}
+	return testRunner.Shutdown()
 }
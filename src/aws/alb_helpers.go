@@ -0,0 +1,187 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// Target is an ECS container an ALB target group forwards to
+type Target struct {
+	InstanceID string
+	Port       int64
+}
+
+// GetListenerTargetGroup returns the ARN of the listener on the given port of the given ALB
+// and the ARN of the target group it forwards to. Both are empty if the ALB does not exist yet
+func GetListenerTargetGroup(awsConfig types.AwsConfig, loadBalancerName string, port int64) (string, string, error) {
+	elbClient := createElbClient(awsConfig)
+	loadBalancerArn, err := getLoadBalancerArn(elbClient, loadBalancerName)
+	if err != nil || loadBalancerArn == "" {
+		return "", "", err
+	}
+	describeListenersOutput, err := elbClient.DescribeListeners(&elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	})
+	if err != nil {
+		return "", "", err
+	}
+	for _, listener := range describeListenersOutput.Listeners {
+		if aws.Int64Value(listener.Port) != port {
+			continue
+		}
+		for _, action := range listener.DefaultActions {
+			if aws.StringValue(action.Type) == elbv2.ActionTypeEnumForward {
+				return aws.StringValue(listener.ListenerArn), aws.StringValue(action.TargetGroupArn), nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("the load balancer '%s' has no listener forwarding traffic on port %d", loadBalancerName, port)
+}
+
+// GetTargetGroupArn returns the ARN of the target group with the given name
+func GetTargetGroupArn(awsConfig types.AwsConfig, targetGroupName string) (string, error) {
+	elbClient := createElbClient(awsConfig)
+	describeTargetGroupsOutput, err := elbClient.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		Names: []*string{aws.String(targetGroupName)},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(describeTargetGroupsOutput.TargetGroups) == 0 {
+		return "", fmt.Errorf("the target group '%s' does not exist", targetGroupName)
+	}
+	return aws.StringValue(describeTargetGroupsOutput.TargetGroups[0].TargetGroupArn), nil
+}
+
+// SetListenerTargetGroup makes the given listener forward all traffic to the given target group
+func SetListenerTargetGroup(awsConfig types.AwsConfig, listenerArn, targetGroupArn string) error {
+	elbClient := createElbClient(awsConfig)
+	_, err := elbClient.ModifyListener(&elbv2.ModifyListenerInput{
+		ListenerArn: aws.String(listenerArn),
+		DefaultActions: []*elbv2.Action{{
+			TargetGroupArn: aws.String(targetGroupArn),
+			Type:           aws.String(elbv2.ActionTypeEnumForward),
+		}},
+	})
+	return err
+}
+
+// RegisterTargets adds the given targets to the given target group
+func RegisterTargets(awsConfig types.AwsConfig, targetGroupArn string, targets []Target) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	elbClient := createElbClient(awsConfig)
+	_, err := elbClient.RegisterTargets(&elbv2.RegisterTargetsInput{
+		TargetGroupArn: aws.String(targetGroupArn),
+		Targets:        getTargetDescriptions(targets),
+	})
+	return err
+}
+
+// DeregisterTargets removes the given targets from the given target group
+func DeregisterTargets(awsConfig types.AwsConfig, targetGroupArn string, targets []Target) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	elbClient := createElbClient(awsConfig)
+	_, err := elbClient.DeregisterTargets(&elbv2.DeregisterTargetsInput{
+		TargetGroupArn: aws.String(targetGroupArn),
+		Targets:        getTargetDescriptions(targets),
+	})
+	return err
+}
+
+// GetUnhealthyTargetCount returns the number of targets of the given target group failing the health check
+func GetUnhealthyTargetCount(awsConfig types.AwsConfig, targetGroupArn string) (int, error) {
+	elbClient := createElbClient(awsConfig)
+	describeTargetHealthOutput, err := elbClient.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(targetGroupArn),
+	})
+	if err != nil {
+		return 0, err
+	}
+	result := 0
+	for _, targetHealth := range describeTargetHealthOutput.TargetHealthDescriptions {
+		if aws.StringValue(targetHealth.TargetHealth.State) == elbv2.TargetHealthStateEnumUnhealthy {
+			result++
+		}
+	}
+	return result, nil
+}
+
+// GetLoadBalancer5xxCount returns the number of 5xx responses the targets of the given ALB sent since the given time.
+// CloudWatch aggregates the metrics per minute, so the count lags behind by up to a few minutes
+func GetLoadBalancer5xxCount(awsConfig types.AwsConfig, loadBalancerName string, since time.Time) (int, error) {
+	loadBalancerArn, err := getLoadBalancerArn(createElbClient(awsConfig), loadBalancerName)
+	if err != nil {
+		return 0, err
+	}
+	config := CreateAwsConfig(awsConfig)
+	currSession := session.Must(session.NewSession())
+	cloudwatchClient := cloudwatch.New(currSession, config)
+	getMetricStatisticsOutput, err := cloudwatchClient.GetMetricStatistics(&cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/ApplicationELB"),
+		MetricName: aws.String("HTTPCode_Target_5XX_Count"),
+		Dimensions: []*cloudwatch.Dimension{{
+			Name: aws.String("LoadBalancer"),
+			// the dimension is the part of the ARN after "loadbalancer/", e.g. app/name/id
+			Value: aws.String(loadBalancerArn[strings.Index(loadBalancerArn, ":loadbalancer/")+len(":loadbalancer/"):]),
+		}},
+		StartTime:  aws.Time(since.Truncate(time.Minute)),
+		EndTime:    aws.Time(time.Now()),
+		Period:     aws.Int64(60),
+		Statistics: []*string{aws.String(cloudwatch.StatisticSum)},
+	})
+	if err != nil {
+		return 0, err
+	}
+	result := 0
+	for _, datapoint := range getMetricStatisticsOutput.Datapoints {
+		result += int(aws.Float64Value(datapoint.Sum))
+	}
+	return result, nil
+}
+
+func createElbClient(awsConfig types.AwsConfig) *elbv2.ELBV2 {
+	config := CreateAwsConfig(awsConfig)
+	currSession := session.Must(session.NewSession())
+	return elbv2.New(currSession, config)
+}
+
+// returns the ARN of the ALB with the given name, or an empty string if it does not exist
+func getLoadBalancerArn(elbClient *elbv2.ELBV2, loadBalancerName string) (string, error) {
+	describeLoadBalancersOutput, err := elbClient.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		Names: []*string{aws.String(loadBalancerName)},
+	})
+	if err != nil {
+		awsErr, ok := err.(awserr.Error)
+		if ok && awsErr.Code() == elbv2.ErrCodeLoadBalancerNotFoundException {
+			return "", nil
+		}
+		return "", err
+	}
+	if len(describeLoadBalancersOutput.LoadBalancers) == 0 {
+		return "", nil
+	}
+	return aws.StringValue(describeLoadBalancersOutput.LoadBalancers[0].LoadBalancerArn), nil
+}
+
+func getTargetDescriptions(targets []Target) []*elbv2.TargetDescription {
+	result := []*elbv2.TargetDescription{}
+	for _, target := range targets {
+		result = append(result, &elbv2.TargetDescription{
+			Id:   aws.String(target.InstanceID),
+			Port: aws.Int64(target.Port),
+		})
+	}
+	return result
+}
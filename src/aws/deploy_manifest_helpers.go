@@ -0,0 +1,102 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+)
+
+// deploy manifests are stored next to the Terraform state
+const deployManifestsPrefix = "deploys/"
+
+// WriteDeployManifest stores the given deploy manifest in the Terraform state bucket
+func WriteDeployManifest(awsConfig types.AwsConfig, manifest deploy.Manifest) error {
+	s3client := createS3client(awsConfig)
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal deploy manifest into JSON")
+	}
+	return putS3Object(s3client, bytes.NewReader(manifestBytes), awsConfig.TerraformStateBucket, getDeployManifestKey(manifest.ID))
+}
+
+// ReadDeployManifest reads the deploy manifest with the given ID from the Terraform state bucket
+func ReadDeployManifest(awsConfig types.AwsConfig, id string) (deploy.Manifest, error) {
+	s3client := createS3client(awsConfig)
+	manifest, err := readDeployManifest(s3client, awsConfig.TerraformStateBucket, getDeployManifestKey(id))
+	if err != nil {
+		awsErr, ok := err.(awserr.Error)
+		if ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return manifest, fmt.Errorf("deploy '%s' does not exist. Run 'exo deploy history' to list the deploys", id)
+		}
+	}
+	return manifest, err
+}
+
+// ReadDeployManifests reads all deploy manifests from the Terraform state bucket,
+// sorted from the newest to the oldest deploy
+func ReadDeployManifests(awsConfig types.AwsConfig) ([]deploy.Manifest, error) {
+	s3client := createS3client(awsConfig)
+	result := []deploy.Manifest{}
+	keys := []string{}
+	err := s3client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(awsConfig.TerraformStateBucket),
+		Prefix: aws.String(deployManifestsPrefix),
+	}, func(output *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range output.Contents {
+			keys = append(keys, *object.Key)
+		}
+		return true
+	})
+	if err != nil {
+		return result, err
+	}
+	for _, key := range keys {
+		if !strings.HasSuffix(key, ".json") {
+			continue
+		}
+		manifest, err := readDeployManifest(s3client, awsConfig.TerraformStateBucket, key)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, manifest)
+	}
+	deploy.SortManifests(result)
+	return result, nil
+}
+
+func readDeployManifest(s3client *s3.S3, bucketName, key string) (deploy.Manifest, error) {
+	manifest := deploy.Manifest{}
+	object, err := s3client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return manifest, err
+	}
+	manifestBytes, err := ioutil.ReadAll(object.Body)
+	if err != nil {
+		return manifest, err
+	}
+	err = object.Body.Close()
+	if err != nil {
+		return manifest, err
+	}
+	err = json.Unmarshal(manifestBytes, &manifest)
+	if err != nil {
+		return manifest, errors.Wrap(err, fmt.Sprintf("cannot unmarshal deploy manifest '%s'", key))
+	}
+	return manifest, nil
+}
+
+func getDeployManifestKey(id string) string {
+	return fmt.Sprintf("%s%s.json", deployManifestsPrefix, id)
+}
@@ -0,0 +1,258 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/pkg/errors"
+)
+
+// how often RunTask checks whether the task stopped
+const runTaskPollInterval = 6 * time.Second
+
+// RunTask runs a single task of the latest revision of the given task definition family
+// on the given cluster and waits until it stopped, at most for the given duration. It returns an error
+// if the task could not be placed, did not stop in time or any of its containers exited with a non-zero code
+func RunTask(awsConfig types.AwsConfig, clusterName, taskDefinitionFamily string, timeout time.Duration) error {
+	ecsClient := createEcsClient(awsConfig)
+	runTaskOutput, err := ecsClient.RunTask(&ecs.RunTaskInput{
+		Cluster:        aws.String(clusterName),
+		TaskDefinition: aws.String(taskDefinitionFamily),
+		Count:          aws.Int64(1),
+		StartedBy:      aws.String("exosphere"),
+	})
+	if err != nil {
+		return err
+	}
+	if len(runTaskOutput.Failures) > 0 {
+		return fmt.Errorf("cannot run task '%s': %s", taskDefinitionFamily, aws.StringValue(runTaskOutput.Failures[0].Reason))
+	}
+	if len(runTaskOutput.Tasks) == 0 {
+		return fmt.Errorf("cannot run task '%s'", taskDefinitionFamily)
+	}
+	describeTasksInput := &ecs.DescribeTasksInput{
+		Cluster: aws.String(clusterName),
+		Tasks:   []*string{runTaskOutput.Tasks[0].TaskArn},
+	}
+	err = ecsClient.WaitUntilTasksStoppedWithContext(
+		aws.BackgroundContext(),
+		describeTasksInput,
+		request.WithWaiterDelay(request.ConstantWaiterDelay(runTaskPollInterval)),
+		request.WithWaiterMaxAttempts(int(timeout/runTaskPollInterval)+1),
+	)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("task '%s' did not stop within %s", taskDefinitionFamily, timeout))
+	}
+	describeTasksOutput, err := ecsClient.DescribeTasks(describeTasksInput)
+	if err != nil {
+		return err
+	}
+	if len(describeTasksOutput.Tasks) == 0 {
+		return errors.New("cannot find the stopped task")
+	}
+	task := describeTasksOutput.Tasks[0]
+	for _, container := range task.Containers {
+		if container.ExitCode == nil {
+			return fmt.Errorf("task '%s' stopped before its container exited: %s", taskDefinitionFamily, aws.StringValue(task.StoppedReason))
+		}
+		if *container.ExitCode != 0 {
+			return fmt.Errorf("task '%s' exited with code %d. See the CloudWatch log group 'services/production/%s' for details", taskDefinitionFamily, *container.ExitCode, taskDefinitionFamily)
+		}
+	}
+	return nil
+}
+
+// TaskStatus is the state of an ECS task with a single container
+type TaskStatus struct {
+	LastStatus    string
+	ExitCode      *int64
+	StoppedReason string
+}
+
+// IsStopped returns whether the task has stopped
+func (t TaskStatus) IsStopped() bool {
+	return t.LastStatus == ecs.DesiredStatusStopped
+}
+
+// StartTask starts a single task of the given task definition on the given cluster,
+// running the given command in the given container instead of its default one if any.
+// It returns the ARN of the task without waiting for it
+func StartTask(awsConfig types.AwsConfig, clusterName, taskDefinition, containerName string, command []string) (string, error) {
+	runTaskInput := &ecs.RunTaskInput{
+		Cluster:        aws.String(clusterName),
+		TaskDefinition: aws.String(taskDefinition),
+		Count:          aws.Int64(1),
+		StartedBy:      aws.String("exosphere"),
+	}
+	if len(command) > 0 {
+		runTaskInput.Overrides = &ecs.TaskOverride{
+			ContainerOverrides: []*ecs.ContainerOverride{{
+				Name:    aws.String(containerName),
+				Command: aws.StringSlice(command),
+			}},
+		}
+	}
+	runTaskOutput, err := createEcsClient(awsConfig).RunTask(runTaskInput)
+	if err != nil {
+		return "", err
+	}
+	if len(runTaskOutput.Failures) > 0 {
+		return "", fmt.Errorf("cannot run task '%s': %s", taskDefinition, aws.StringValue(runTaskOutput.Failures[0].Reason))
+	}
+	if len(runTaskOutput.Tasks) == 0 {
+		return "", fmt.Errorf("cannot run task '%s'", taskDefinition)
+	}
+	return aws.StringValue(runTaskOutput.Tasks[0].TaskArn), nil
+}
+
+// GetTaskStatus returns the state of the given task
+func GetTaskStatus(awsConfig types.AwsConfig, clusterName, taskArn string) (TaskStatus, error) {
+	describeTasksOutput, err := createEcsClient(awsConfig).DescribeTasks(&ecs.DescribeTasksInput{
+		Cluster: aws.String(clusterName),
+		Tasks:   []*string{aws.String(taskArn)},
+	})
+	if err != nil {
+		return TaskStatus{}, err
+	}
+	if len(describeTasksOutput.Tasks) == 0 {
+		return TaskStatus{}, fmt.Errorf("cannot find the task '%s'", taskArn)
+	}
+	task := describeTasksOutput.Tasks[0]
+	result := TaskStatus{
+		LastStatus:    aws.StringValue(task.LastStatus),
+		StoppedReason: aws.StringValue(task.StoppedReason),
+	}
+	if len(task.Containers) > 0 {
+		result.ExitCode = task.Containers[0].ExitCode
+	}
+	return result, nil
+}
+
+// GetServiceTaskDefinition returns the ARN of the task definition the given ECS service runs,
+// or an empty string if the service does not exist
+func GetServiceTaskDefinition(awsConfig types.AwsConfig, clusterName, serviceName string) (string, error) {
+	service, err := describeService(createEcsClient(awsConfig), clusterName, serviceName)
+	if err != nil || service == nil {
+		return "", err
+	}
+	return aws.StringValue(service.TaskDefinition), nil
+}
+
+// GetServiceImage returns the image of the task definition the given ECS service runs
+// and the number of tasks it keeps running. The image is empty if the service does not exist yet
+func GetServiceImage(awsConfig types.AwsConfig, clusterName, serviceName string) (string, int64, error) {
+	ecsClient := createEcsClient(awsConfig)
+	service, err := describeService(ecsClient, clusterName, serviceName)
+	if err != nil || service == nil {
+		return "", 0, err
+	}
+	describeTaskDefinitionOutput, err := ecsClient.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: service.TaskDefinition,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	containerDefinitions := describeTaskDefinitionOutput.TaskDefinition.ContainerDefinitions
+	if len(containerDefinitions) == 0 {
+		return "", 0, fmt.Errorf("the task definition of the service '%s' has no containers", serviceName)
+	}
+	return aws.StringValue(containerDefinitions[0].Image), aws.Int64Value(service.DesiredCount), nil
+}
+
+// ScaleService sets the number of tasks the given ECS service keeps running
+// and waits until the service reached a steady state
+func ScaleService(awsConfig types.AwsConfig, clusterName, serviceName string, desiredCount int64) error {
+	ecsClient := createEcsClient(awsConfig)
+	_, err := ecsClient.UpdateService(&ecs.UpdateServiceInput{
+		Cluster:      aws.String(clusterName),
+		Service:      aws.String(serviceName),
+		DesiredCount: aws.Int64(desiredCount),
+	})
+	if err != nil {
+		return err
+	}
+	return ecsClient.WaitUntilServicesStable(&ecs.DescribeServicesInput{
+		Cluster:  aws.String(clusterName),
+		Services: []*string{aws.String(serviceName)},
+	})
+}
+
+// GetServiceTargets returns the EC2 instances and host ports the running tasks of the given ECS service listen on
+func GetServiceTargets(awsConfig types.AwsConfig, clusterName, serviceName string) ([]Target, error) {
+	ecsClient := createEcsClient(awsConfig)
+	listTasksOutput, err := ecsClient.ListTasks(&ecs.ListTasksInput{
+		Cluster:       aws.String(clusterName),
+		ServiceName:   aws.String(serviceName),
+		DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+	})
+	if err != nil || len(listTasksOutput.TaskArns) == 0 {
+		return []Target{}, err
+	}
+	describeTasksOutput, err := ecsClient.DescribeTasks(&ecs.DescribeTasksInput{
+		Cluster: aws.String(clusterName),
+		Tasks:   listTasksOutput.TaskArns,
+	})
+	if err != nil {
+		return nil, err
+	}
+	containerInstanceArns := []*string{}
+	for _, task := range describeTasksOutput.Tasks {
+		containerInstanceArns = append(containerInstanceArns, task.ContainerInstanceArn)
+	}
+	describeContainerInstancesOutput, err := ecsClient.DescribeContainerInstances(&ecs.DescribeContainerInstancesInput{
+		Cluster:            aws.String(clusterName),
+		ContainerInstances: containerInstanceArns,
+	})
+	if err != nil {
+		return nil, err
+	}
+	instanceIDs := map[string]string{}
+	for _, containerInstance := range describeContainerInstancesOutput.ContainerInstances {
+		instanceIDs[aws.StringValue(containerInstance.ContainerInstanceArn)] = aws.StringValue(containerInstance.Ec2InstanceId)
+	}
+	result := []Target{}
+	for _, task := range describeTasksOutput.Tasks {
+		for _, container := range task.Containers {
+			for _, networkBinding := range container.NetworkBindings {
+				result = append(result, Target{
+					InstanceID: instanceIDs[aws.StringValue(task.ContainerInstanceArn)],
+					Port:       aws.Int64Value(networkBinding.HostPort),
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+func createEcsClient(awsConfig types.AwsConfig) *ecs.ECS {
+	config := CreateAwsConfig(awsConfig)
+	currSession := session.Must(session.NewSession())
+	return ecs.New(currSession, config)
+}
+
+// returns the given ECS service, or nil if it does not exist or was deleted
+func describeService(ecsClient *ecs.ECS, clusterName, serviceName string) (*ecs.Service, error) {
+	describeServicesOutput, err := ecsClient.DescribeServices(&ecs.DescribeServicesInput{
+		Cluster:  aws.String(clusterName),
+		Services: []*string{aws.String(serviceName)},
+	})
+	if err != nil {
+		awsErr, ok := err.(awserr.Error)
+		if ok && awsErr.Code() == ecs.ErrCodeClusterNotFoundException {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, service := range describeServicesOutput.Services {
+		if aws.StringValue(service.Status) == "ACTIVE" {
+			return service, nil
+		}
+	}
+	return nil, nil
+}
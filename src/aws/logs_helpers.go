@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"github.com/Originate/exosphere/src/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// GetLogMessages returns the messages of the given CloudWatch log stream following the given token,
+// or from the start of the stream if the token is empty, and the token to continue from.
+// It returns no messages while the stream does not exist yet
+func GetLogMessages(awsConfig types.AwsConfig, logGroupName, logStreamName, nextToken string) ([]string, string, error) {
+	config := CreateAwsConfig(awsConfig)
+	currSession := session.Must(session.NewSession())
+	logsClient := cloudwatchlogs.New(currSession, config)
+	getLogEventsInput := &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String(logGroupName),
+		LogStreamName: aws.String(logStreamName),
+		StartFromHead: aws.Bool(true),
+	}
+	if nextToken != "" {
+		getLogEventsInput.NextToken = aws.String(nextToken)
+	}
+	getLogEventsOutput, err := logsClient.GetLogEvents(getLogEventsInput)
+	if err != nil {
+		awsErr, ok := err.(awserr.Error)
+		if ok && awsErr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+			return []string{}, nextToken, nil
+		}
+		return nil, nextToken, err
+	}
+	result := []string{}
+	for _, event := range getLogEventsOutput.Events {
+		result = append(result, aws.StringValue(event.Message))
+	}
+	return result, aws.StringValue(getLogEventsOutput.NextForwardToken), nil
+}
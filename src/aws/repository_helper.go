@@ -3,9 +3,13 @@ package aws
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/Originate/exosphere/src/docker/tools"
+	"github.com/Originate/exosphere/src/types"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/moby/moby/client"
 )
@@ -46,15 +50,39 @@ func (r *RepositoryHelper) Push(writer io.Writer) error {
 // Helpers
 
 func (r *RepositoryHelper) hasImageVersion() (bool, error) {
-	result, err := r.EcrClient.DescribeImages(&ecr.DescribeImagesInput{
-		RepositoryName: aws.String(r.RepositoryName),
+	return hasImageTag(r.EcrClient, r.RepositoryName, r.ImageVersion)
+}
+
+// HasImage returns whether or not the given image, tagged with the URI of its ECR repository, still exists
+func HasImage(awsConfig types.AwsConfig, taggedImageName string) (bool, error) {
+	nameParts := strings.SplitN(taggedImageName, "/", 2)
+	if len(nameParts) != 2 {
+		return false, fmt.Errorf("'%s' is not an ECR image", taggedImageName)
+	}
+	separatorIndex := strings.LastIndex(nameParts[1], ":")
+	if separatorIndex == -1 {
+		return false, fmt.Errorf("'%s' has no tag", taggedImageName)
+	}
+	config := CreateAwsConfig(awsConfig)
+	currSession := session.Must(session.NewSession())
+	ecrClient := ecr.New(currSession, config)
+	return hasImageTag(ecrClient, nameParts[1][:separatorIndex], nameParts[1][separatorIndex+1:])
+}
+
+func hasImageTag(ecrClient *ecr.ECR, repositoryName, imageTag string) (bool, error) {
+	result, err := ecrClient.DescribeImages(&ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repositoryName),
 	})
 	if err != nil {
+		awsErr, ok := err.(awserr.Error)
+		if ok && awsErr.Code() == ecr.ErrCodeRepositoryNotFoundException {
+			return false, nil
+		}
 		return false, err
 	}
 	for _, imageDetail := range result.ImageDetails {
 		for _, tag := range imageDetail.ImageTags {
-			if *tag == r.ImageVersion {
+			if *tag == imageTag {
 				return true, nil
 			}
 		}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Originate/exosphere/src/application"
+	"github.com/Originate/exosphere/src/application/snapshotter"
+	"github.com/Originate/exosphere/src/docker/composebuilder"
+	"github.com/spf13/cobra"
+)
+
+var dataDependencyFlag []string
+
+var dataCmd = &cobra.Command{
+	Use:   "data",
+	Short: "Manages the data of the local dependencies",
+	Long:  "Manages the data that local dependencies persist in named volumes, by archiving them into snapshots in .exosphere/snapshots",
+}
+
+var dataSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <name>",
+	Short: "Archives the data of the local dependencies into a snapshot",
+	Long:  "Stops the application and archives the data of the local dependencies into a snapshot with the given name",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		if len(args) != 1 {
+			log.Fatal("Usage: exo data snapshot <name> [--dependency <name>]")
+		}
+		options := getSnapshotOptions()
+		err := snapshotter.Create(options, args[0], dataDependencyFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Created snapshot '%s'\n", args[0])
+	},
+}
+
+var dataRestoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "Restores the data of the local dependencies from a snapshot",
+	Long:  "Stops the application and replaces the data of the local dependencies contained in the snapshot with the given name",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		if len(args) != 1 {
+			log.Fatal("Usage: exo data restore <name>")
+		}
+		options := getSnapshotOptions()
+		err := snapshotter.Restore(options, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Restored snapshot '%s'\n", args[0])
+	},
+}
+
+var dataListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the snapshots of the application",
+	Long:  "Lists the snapshots of the application and the volumes they contain",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		userContext, err := GetUserContext()
+		if err != nil {
+			log.Fatal(err)
+		}
+		snapshots, err := snapshotter.List(userContext.AppContext)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(snapshots) == 0 {
+			fmt.Println("No snapshots found")
+			return
+		}
+		for _, snapshot := range snapshots {
+			fmt.Printf("%s: %s\n", snapshot.Name, strings.Join(snapshot.VolumeNames, ", "))
+		}
+	},
+}
+
+var dataResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Removes the data of the local dependencies",
+	Long:  "Stops the application and removes the named volumes of the local dependencies, so that they start out empty",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		options := getSnapshotOptions()
+		err := snapshotter.Reset(options, dataDependencyFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func getSnapshotOptions() snapshotter.SnapshotOptions {
+	userContext, err := GetUserContext()
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = application.GenerateComposeFiles(userContext.AppContext)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return snapshotter.SnapshotOptions{
+		AppContext:               userContext.AppContext,
+		DockerComposeProjectName: composebuilder.GetDockerComposeProjectName(userContext.AppContext.Config.Name),
+		Writer:                   os.Stdout,
+	}
+}
+
+func init() {
+	dataSnapshotCmd.PersistentFlags().StringSliceVarP(&dataDependencyFlag, "dependency", "d", []string{}, "Only archive the data of the given dependency, can be repeated")
+	dataResetCmd.PersistentFlags().StringSliceVarP(&dataDependencyFlag, "dependency", "d", []string{}, "Only remove the data of the given dependency, can be repeated")
+	dataCmd.AddCommand(dataSnapshotCmd)
+	dataCmd.AddCommand(dataRestoreCmd)
+	dataCmd.AddCommand(dataListCmd)
+	dataCmd.AddCommand(dataResetCmd)
+	RootCmd.AddCommand(dataCmd)
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/Originate/exosphere/src/application"
+	"github.com/Originate/exosphere/src/application/migrator"
+	"github.com/Originate/exosphere/src/docker/composebuilder"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/spf13/cobra"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manages the databases of the application",
+	Long:  "Manages the databases of the application",
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate [service]",
+	Short: "Runs the pending database migrations",
+	Long:  "Runs the pending database migrations of the given service, or of all services that declare migrations, against the local dependency containers",
+	Run: func(cmd *cobra.Command, args []string) {
+		runMigrations(cmd, args, types.MigrationActionMigrate)
+	},
+}
+
+var dbStatusCmd = &cobra.Command{
+	Use:   "status [service]",
+	Short: "Prints the status of the database migrations",
+	Long:  "Prints the status of the database migrations of the given service, or of all services that declare migrations, against the local dependency containers",
+	Run: func(cmd *cobra.Command, args []string) {
+		runMigrations(cmd, args, types.MigrationActionStatus)
+	},
+}
+
+var dbRollbackCmd = &cobra.Command{
+	Use:   "rollback [service]",
+	Short: "Rolls back the last database migration",
+	Long:  "Rolls back the last database migration of the given service against the local dependency containers",
+	Run: func(cmd *cobra.Command, args []string) {
+		runMigrations(cmd, args, types.MigrationActionRollback)
+	},
+}
+
+func runMigrations(cmd *cobra.Command, args []string, action string) {
+	if printHelpIfNecessary(cmd, args) {
+		return
+	}
+	userContext, err := GetUserContext()
+	if err != nil {
+		log.Fatal(err)
+	}
+	serviceRole := ""
+	if len(args) > 0 {
+		serviceRole = args[0]
+	} else if userContext.HasServiceContext {
+		serviceRole = userContext.ServiceContext.Role
+	}
+	err = application.GenerateComposeFiles(userContext.AppContext)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = migrator.Run(migrator.MigrateOptions{
+		AppContext:  userContext.AppContext,
+		ServiceRole: serviceRole,
+		Action:      action,
+		BuildMode: types.BuildMode{
+			Type:        types.BuildModeTypeLocal,
+			Mount:       true,
+			Environment: types.BuildModeEnvironmentDevelopment,
+		},
+		DockerComposeProjectName: composebuilder.GetDockerComposeProjectName(userContext.AppContext.Config.Name),
+		Writer:                   os.Stdout,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func init() {
+	dbCmd.AddCommand(dbMigrateCmd)
+	dbCmd.AddCommand(dbStatusCmd)
+	dbCmd.AddCommand(dbRollbackCmd)
+	RootCmd.AddCommand(dbCmd)
+}
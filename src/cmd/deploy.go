@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/Originate/exosphere/src/application"
 	"github.com/Originate/exosphere/src/application/deployer"
@@ -41,7 +42,68 @@ var deployCmd = &cobra.Command{
 	},
 }
 
+var deployHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Lists the previous deploys of the application",
+	Long:  "Lists the previous successful deploys of the application, from the newest to the oldest, with the images they applied",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		userContext, err := GetUserContext()
+		if err != nil {
+			log.Fatal(err)
+		}
+		deployConfig := getBaseDeployConfig(userContext.AppContext)
+		manifests, err := deployer.GetDeployHistory(deployConfig)
+		if err != nil {
+			log.Fatalf("Cannot read deploy history: %s", err)
+		}
+		if len(manifests) == 0 {
+			fmt.Println("No deploys found")
+			return
+		}
+		for _, manifest := range manifests {
+			fmt.Printf("%s  %s", manifest.ID, manifest.DeployedAt.Format(time.RFC1123))
+			if manifest.RollbackOf != "" {
+				fmt.Printf("  (rollback to %s)", manifest.RollbackOf)
+			}
+			fmt.Println()
+			for _, name := range manifest.GetSortedImageNames() {
+				fmt.Printf("    %s: %s\n", name, manifest.Images[name])
+			}
+		}
+	},
+}
+
+var deployRollbackCmd = &cobra.Command{
+	Use:   "rollback <id>",
+	Short: "Rolls the application back to a previous deploy",
+	Long:  "Re-applies the images of the previous deploy with the given ID, as listed by 'exo deploy history', without rebuilding them",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		if len(args) != 1 {
+			log.Fatal("Usage: exo deploy rollback <id>")
+		}
+		userContext, err := GetUserContext()
+		if err != nil {
+			log.Fatal(err)
+		}
+		deployConfig := getBaseDeployConfig(userContext.AppContext)
+		deployConfig.Writer = os.Stdout
+		deployConfig.AutoApprove = autoApproveFlag
+		err = deployer.Rollback(deployConfig, args[0])
+		if err != nil {
+			log.Fatalf("Rollback failed: %s", err)
+		}
+	},
+}
+
 func init() {
+	deployCmd.AddCommand(deployHistoryCmd)
+	deployCmd.AddCommand(deployRollbackCmd)
 	RootCmd.AddCommand(deployCmd)
 	deployCmd.PersistentFlags().StringVarP(&deployProfileFlag, "profile", "p", "default", "AWS profile to use")
 	deployCmd.PersistentFlags().BoolVarP(&autoApproveFlag, "auto-approve", "", false, "Deploy changes without prompting for approval")
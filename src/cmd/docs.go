@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Originate/exosphere/src/application"
+	"github.com/spf13/cobra"
+)
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generates documentation for the application",
+	Long:  "Generates documentation for the application",
+}
+
+var docsMessagesCmd = &cobra.Command{
+	Use:   "messages",
+	Short: "Prints a catalogue of all messages",
+	Long:  "Prints a markdown catalogue of all messages of the application with their producers, consumers and schemas",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		userContext, err := GetUserContext()
+		if err != nil {
+			log.Fatal(err)
+		}
+		docs, err := application.RenderMessageDocs(userContext.AppContext)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(docs)
+	},
+}
+
+func init() {
+	docsCmd.AddCommand(docsMessagesCmd)
+	RootCmd.AddCommand(docsCmd)
+}
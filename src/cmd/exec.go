@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"log"
+	"os"
+	"path"
+
+	"github.com/Originate/exosphere/src/docker/composebuilder"
+	"github.com/Originate/exosphere/src/docker/composerunner"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec <service> -- <command>",
+	Short: "Runs a command in a running service",
+	Long:  "Runs the given command in the container of the given service of the application started with 'exo run', with a TTY if the input is a terminal. Exits with the exit code of the command",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		if len(args) < 2 {
+			log.Fatal("Usage: exo exec <service> -- <command>")
+		}
+		userContext, err := GetUserContext()
+		if err != nil {
+			log.Fatal(err)
+		}
+		appContext := userContext.AppContext
+		if _, ok := appContext.ServiceContexts[args[0]]; !ok {
+			log.Fatalf("Unknown service '%s'", args[0])
+		}
+		buildMode := types.BuildMode{
+			Type:        types.BuildModeTypeLocal,
+			Mount:       true,
+			Environment: types.BuildModeEnvironmentDevelopment,
+		}
+		err = composerunner.ExecCommand(composerunner.RunOptions{
+			AppDir:                   appContext.Location,
+			DockerComposeDir:         path.Join(appContext.Location, "docker-compose"),
+			DockerComposeFileName:    buildMode.GetDockerComposeFileName(),
+			DockerComposeProjectName: composebuilder.GetDockerComposeProjectName(appContext.Config.Name),
+			Writer:                   os.Stdout,
+		}, args[0], args[1:], terminal.IsTerminal(int(os.Stdin.Fd())))
+		if err != nil {
+			exitWithCommandError(err)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(execCmd)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Originate/exosphere/src/application/graph"
+	"github.com/spf13/cobra"
+)
+
+var graphFormatFlag string
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Prints a graph of the services, messages and dependencies of the application",
+	Long:  "Prints a graph of the services, messages and dependencies of the application in the DOT, Mermaid or JSON format",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		userContext, err := GetUserContext()
+		if err != nil {
+			log.Fatal(err)
+		}
+		output, err := graph.NewGraph(userContext.AppContext).Render(graphFormatFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(output)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(graphCmd)
+	graphCmd.PersistentFlags().StringVarP(&graphFormatFlag, "format", "f", graph.FormatDot, "Output format: dot, mermaid or json")
+}
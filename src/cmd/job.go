@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/Originate/exosphere/src/application"
+	"github.com/Originate/exosphere/src/application/scheduler"
+	"github.com/Originate/exosphere/src/docker/composebuilder"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/spf13/cobra"
+)
+
+var jobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "Manages the scheduled services of the application",
+	Long:  "Manages the scheduled services of the application",
+}
+
+var jobRunCmd = &cobra.Command{
+	Use:   "run <service>",
+	Short: "Runs a scheduled service now",
+	Long:  "Runs the given scheduled service once in a one-off container, independent of its schedule",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		if len(args) != 1 {
+			log.Fatal("Usage: exo job run <service>")
+		}
+		userContext, err := GetUserContext()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = application.GenerateComposeFiles(userContext.AppContext)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = scheduler.RunJob(scheduler.ScheduleOptions{
+			AppContext: userContext.AppContext,
+			BuildMode: types.BuildMode{
+				Type:        types.BuildModeTypeLocal,
+				Mount:       true,
+				Environment: types.BuildModeEnvironmentDevelopment,
+			},
+			DockerComposeProjectName: composebuilder.GetDockerComposeProjectName(userContext.AppContext.Config.Name),
+			Writer:                   os.Stdout,
+		}, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	jobCmd.AddCommand(jobRunCmd)
+	RootCmd.AddCommand(jobCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Originate/exosphere/src/application/linter"
+	"github.com/spf13/cobra"
+)
+
+var lintFormatFlag string
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Checks the application for cross-service inconsistencies",
+	Long:  "Checks the application for cross-service inconsistencies such as unmatched messages, duplicate ports and colliding environment variables. Exits with a non-zero status if any errors are found",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		userContext, err := GetUserContext()
+		if err != nil {
+			log.Fatal(err)
+		}
+		issues := linter.Lint(userContext.AppContext)
+		output, err := linter.Render(issues, lintFormatFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(output)
+		if linter.HasErrors(issues) {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(lintCmd)
+	lintCmd.PersistentFlags().StringVarP(&lintFormatFlag, "format", "f", linter.FormatText, "Output format: text or json")
+}
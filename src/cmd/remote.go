@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/Originate/exosphere/src/application/deployer"
+	"github.com/spf13/cobra"
+)
+
+var remoteCmd = &cobra.Command{
+	Use:   "remote",
+	Short: "Manages the deployed application",
+	Long:  "Manages the application deployed with 'exo deploy'",
+}
+
+var remoteRunCmd = &cobra.Command{
+	Use:   "run <service> -- <command>",
+	Short: "Runs a command in a one-off task of a deployed service",
+	Long:  "Runs the given command in a one-off ECS task with the task definition of the deployed version of the given service, prints its logs until it stopped and exits with the exit code of the command",
+	Run: func(cmd *cobra.Command, args []string) {
+		if printHelpIfNecessary(cmd, args) {
+			return
+		}
+		if len(args) < 2 {
+			log.Fatal("Usage: exo remote run <service> -- <command>")
+		}
+		userContext, err := GetUserContext()
+		if err != nil {
+			log.Fatal(err)
+		}
+		deployConfig := getBaseDeployConfig(userContext.AppContext)
+		deployConfig.Writer = os.Stdout
+		exitCode, err := deployer.RunRemoteCommand(deployConfig, args[0], args[1:])
+		if err != nil {
+			log.Fatalf("Remote run failed: %s", err)
+		}
+		os.Exit(int(exitCode))
+	},
+}
+
+func init() {
+	remoteCmd.AddCommand(remoteRunCmd)
+	RootCmd.AddCommand(remoteCmd)
+	remoteCmd.PersistentFlags().StringVarP(&deployProfileFlag, "profile", "p", "default", "AWS profile to use")
+}
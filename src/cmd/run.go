@@ -12,6 +12,7 @@ import (
 )
 
 var productionFlag bool
+var withSchedulesFlag bool
 
 var runCmd = &cobra.Command{
 	Use:   "run",
@@ -41,7 +42,8 @@ var runCmd = &cobra.Command{
 			AppContext:               userContext.AppContext,
 			BuildMode:                buildMode,
 			DockerComposeProjectName: composebuilder.GetDockerComposeProjectName(userContext.AppContext.Config.Name),
-			Writer: os.Stdout,
+			Writer:                   os.Stdout,
+			WithSchedules:            withSchedulesFlag,
 		})
 		if err != nil {
 			panic(err)
@@ -52,4 +54,5 @@ var runCmd = &cobra.Command{
 func init() {
 	RootCmd.AddCommand(runCmd)
 	runCmd.PersistentFlags().BoolVarP(&productionFlag, "production", "", false, "Run in production mode")
+	runCmd.PersistentFlags().BoolVarP(&withSchedulesFlag, "with-schedules", "", false, "Run the scheduled services on their schedules")
 }
@@ -4,14 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
+	"syscall"
 
 	"github.com/Originate/exosphere/src/aws"
 	"github.com/Originate/exosphere/src/docker/composebuilder"
 	"github.com/Originate/exosphere/src/types"
 	"github.com/Originate/exosphere/src/types/context"
 	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -64,6 +68,17 @@ func getBaseDeployConfig(appContext *context.AppContext) deploy.Config {
 	}
 }
 
+// exits with the exit code of the command that caused the given error,
+// or logs the error if it was not caused by a command exiting with a non-zero code
+func exitWithCommandError(err error) {
+	if exitErr, ok := errors.Cause(err).(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			os.Exit(status.ExitStatus())
+		}
+	}
+	log.Fatal(err)
+}
+
 func prettyPrintSecrets(secrets map[string]string) {
 	secretsPretty, err := json.MarshalIndent(secrets, "", "  ")
 	if err != nil {
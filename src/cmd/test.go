@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"log"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/Originate/exosphere/src/application"
 	"github.com/Originate/exosphere/src/application/tester"
@@ -11,6 +14,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var sinceFlag string
+var noCacheFlag bool
+var timeoutFlag time.Duration
+var retriesFlag int
+var reportFlag string
+var strictContractsFlag bool
+
 var testCmd = &cobra.Command{
 	Use:   "test",
 	Short: "Runs tests for the application",
@@ -32,18 +42,34 @@ var testCmd = &cobra.Command{
 			Type:        types.BuildModeTypeLocal,
 			Environment: types.BuildModeEnvironmentTest,
 		}
+		testOptions := tester.TestOptions{
+			NoCache:         noCacheFlag,
+			Timeout:         timeoutFlag,
+			StrictContracts: strictContractsFlag,
+		}
+		if cmd.Flags().Changed("retries") {
+			testOptions.Retries = &retriesFlag
+		}
 		shutdownChannel := make(chan os.Signal, 1)
 		signal.Notify(shutdownChannel, os.Interrupt)
 		var testResult types.TestResult
 		if userContext.HasServiceContext {
-			testResult, err = tester.TestService(userContext.ServiceContext, writer, buildMode, shutdownChannel)
+			testResult, err = tester.TestService(userContext.ServiceContext, writer, buildMode, testOptions, shutdownChannel)
+		} else if sinceFlag != "" {
+			testResult, err = tester.TestAffectedServices(userContext.AppContext, sinceFlag, writer, buildMode, testOptions, shutdownChannel)
 		} else {
-			testResult, err = tester.TestApp(userContext.AppContext, writer, buildMode, shutdownChannel)
+			testResult, err = tester.TestApp(userContext.AppContext, writer, buildMode, testOptions, shutdownChannel)
 		}
 		if err != nil {
 			panic(err)
 		}
 		signal.Stop(shutdownChannel)
+		if reportFlag != "" {
+			err = writeTestReport(reportFlag, testResult)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
 		if !testResult.Passed {
 			os.Exit(1)
 		}
@@ -52,4 +78,18 @@ var testCmd = &cobra.Command{
 
 func init() {
 	RootCmd.AddCommand(testCmd)
+	testCmd.PersistentFlags().StringVarP(&sinceFlag, "since", "", "", "Only test services affected by changes since the given git ref")
+	testCmd.PersistentFlags().BoolVarP(&noCacheFlag, "no-cache", "", false, "Run the tests of all services, even if they passed before with the same content")
+	testCmd.PersistentFlags().DurationVarP(&timeoutFlag, "timeout", "", 0, "Timeout for the tests of each service, overrides 'development.test.timeout'")
+	testCmd.PersistentFlags().IntVarP(&retriesFlag, "retries", "", 0, "Number of retries for failing tests, overrides 'development.test.retries'")
+	testCmd.PersistentFlags().StringVarP(&reportFlag, "report", "", "", "Write the test results as JSON to the given file")
+	testCmd.PersistentFlags().BoolVarP(&strictContractsFlag, "strict-contracts", "", false, "Fail the tests if a service sends undeclared messages or never receives declared messages")
+}
+
+func writeTestReport(filePath string, testResult types.TestResult) error {
+	report, err := json.MarshalIndent(testResult, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, report, 0644)
 }
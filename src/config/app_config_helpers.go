@@ -11,13 +11,54 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-// GetBuiltLocalAppDependencies returns the LocalAppDependency objects for application dependencies only
+// GetBuiltLocalAppDependencies returns the LocalAppDependency objects for application dependencies only,
+// including the local databases synthesized for remote RDS dependencies
 func GetBuiltLocalAppDependencies(appContext *context.AppContext) map[string]LocalAppDependency {
 	result := map[string]LocalAppDependency{}
 	for _, dependency := range appContext.Config.Local.Dependencies {
 		builtDependency := NewLocalAppDependency(dependency, appContext)
 		result[dependency.Name] = builtDependency
 	}
+	addLocalRdsDependencies(result, appContext.Config.Remote.Dependencies, appContext)
+	return result
+}
+
+// GetLocalDependencyVolumeNames returns the named volumes of the local dependencies
+// of the application and all services, by dependency name. Dependencies that persist no data are omitted
+func GetLocalDependencyVolumeNames(appContext *context.AppContext) map[string][]string {
+	builtDependencies := GetBuiltLocalAppDependencies(appContext)
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		if serviceContext, ok := appContext.ServiceContexts[serviceRole]; ok {
+			for dependencyName, builtDependency := range GetBuiltLocalServiceDependencies(serviceContext.Config, appContext) {
+				if _, ok := builtDependencies[dependencyName]; !ok {
+					builtDependencies[dependencyName] = builtDependency
+				}
+			}
+		}
+	}
+	result := map[string][]string{}
+	for dependencyName, builtDependency := range builtDependencies {
+		if volumeNames := builtDependency.GetVolumeNames(); len(volumeNames) > 0 {
+			result[dependencyName] = volumeNames
+		}
+	}
+	return result
+}
+
+// returns the local dependencies with the given name declared by the application and all services
+func getAllLocalDependencies(appContext *context.AppContext, name string) []types.LocalDependency {
+	dependencies := appContext.Config.Local.Dependencies
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		if serviceContext, ok := appContext.ServiceContexts[serviceRole]; ok {
+			dependencies = append(dependencies, serviceContext.Config.Local.Dependencies...)
+		}
+	}
+	result := []types.LocalDependency{}
+	for _, dependency := range dependencies {
+		if dependency.Name == name {
+			result = append(result, dependency)
+		}
+	}
 	return result
 }
 
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/hoisie/mustache"
+	"github.com/pkg/errors"
+)
+
+// matches the raw tags {{{name}}}, which are kept, and the escaped tags {{name}}
+var definitionTemplateTagRegex = regexp.MustCompile(`\{\{\{[^}]*\}\}\}|\{\{\s*([^{#^/!>=\s][^}]*?)\s*\}\}`)
+
+// getDefinitionTemplateContext returns the values that can be used in the templates
+// of a dependency definition: {{name}}, {{version}}, {{appName}}, {{serviceRoutes}}
+// and {{config.<key>}} for the variables given to the dependency
+func getDefinitionTemplateContext(name, version string, variables map[string]string, appContext *context.AppContext) (map[string]interface{}, error) {
+	localExocomDependency := &localExocomDependency{types.LocalDependency{}, appContext}
+	serviceRoutes, err := localExocomDependency.getServiceRoutesString()
+	if err != nil {
+		return nil, err
+	}
+	if variables == nil {
+		variables = map[string]string{}
+	}
+	return map[string]interface{}{
+		"name":          name,
+		"version":       version,
+		"appName":       appContext.Config.Name,
+		"serviceRoutes": serviceRoutes,
+		"config":        variables,
+	}, nil
+}
+
+// renders the given template of a dependency definition. The values are used as they are:
+// they end up in environment variables, commands and Terraform, where HTML escaping would mangle them
+func renderDefinitionTemplate(template string, templateContext map[string]interface{}) (string, error) {
+	rawTemplate := definitionTemplateTagRegex.ReplaceAllStringFunc(template, func(tag string) string {
+		name := definitionTemplateTagRegex.FindStringSubmatch(tag)[1]
+		if name == "" {
+			return tag
+		}
+		return "{{{" + name + "}}}"
+	})
+	parsedTemplate, err := mustache.ParseString(rawTemplate)
+	if err != nil {
+		return "", err
+	}
+	return parsedTemplate.Render(templateContext), nil
+}
+
+// renders all values of the given map
+func renderDefinitionTemplates(templates map[string]string, templateContext map[string]interface{}) (map[string]string, error) {
+	result := map[string]string{}
+	for key, template := range templates {
+		value, err := renderDefinitionTemplate(template, templateContext)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("Invalid template for '%s'", key))
+		}
+		result[key] = value
+	}
+	return result, nil
+}
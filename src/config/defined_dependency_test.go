@@ -0,0 +1,101 @@
+package config_test
+
+import (
+	"github.com/Originate/exosphere/src/config"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/test/helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dependencies defined in .exosphere/dependencies", func() {
+	var appContext *context.AppContext
+
+	var _ = BeforeEach(func() {
+		appDir := helpers.GetTestApplicationDir("dependency-definitions")
+		var err error
+		appContext, err = context.GetAppContext(appDir)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	var _ = Describe("local dependency", func() {
+		var memcached config.LocalAppDependency
+
+		var _ = BeforeEach(func() {
+			memcached = config.NewLocalAppDependency(appContext.Config.Local.Dependencies[0], appContext)
+		})
+
+		It("should render the docker config of the definition", func() {
+			actual, err := memcached.GetDockerConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal(types.DockerConfig{
+				Image:         "memcached:1.5.3",
+				Command:       "memcached -m 128",
+				ContainerName: "memcached1.5.3",
+				Ports:         []string{"11211:11211"},
+				Volumes:       []string{},
+				Environment:   map[string]string{},
+				Restart:       "on-failure",
+				Healthcheck: &types.DockerHealthcheck{
+					Test:     []string{"CMD-SHELL", "echo stats | nc localhost 11211"},
+					Interval: "5s",
+					Timeout:  "3s",
+					Retries:  5,
+				},
+			}))
+		})
+
+		It("should render the service environment variables of the definition", func() {
+			Expect(memcached.GetServiceEnvVariables()).To(Equal(map[string]string{
+				"MEMCACHED_URL": "memcached1.5.3:11211",
+			}))
+		})
+	})
+
+	var _ = Describe("remote dependency", func() {
+		var memcached config.RemoteAppDependency
+
+		var _ = BeforeEach(func() {
+			memcached = config.NewRemoteAppDependency(appContext.Config.Remote.Dependencies[0], appContext)
+		})
+
+		It("should not build a docker image if the definition has none", func() {
+			Expect(memcached.HasDockerConfig()).To(BeFalse())
+		})
+
+		It("should render the module variables of the definition", func() {
+			actual, err := memcached.GetDeploymentConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal(map[string]string{
+				"engine_version": "1.5.3",
+				"name":           "dependency-definitions-memcached",
+				"node_type":      "cache.t2.micro",
+				"subnet_ids":     "${module.aws.private_subnet_ids}",
+				"auth_token":     "${var.MEMCACHED_TOKEN}",
+			}))
+		})
+
+		It("should render the service environment variables with secrets", func() {
+			Expect(memcached.GetDeploymentServiceEnvVariables(types.Secrets{"MEMCACHED_TOKEN": "secret"})).To(Equal(map[string]string{
+				"MEMCACHED_URL":   "memcached.dependency-definitions.local:11211",
+				"MEMCACHED_TOKEN": "secret",
+			}))
+		})
+
+		It("should not escape the values used in the templates", func() {
+			envVars := memcached.GetDeploymentServiceEnvVariables(types.Secrets{"MEMCACHED_TOKEN": `a&b<c>"d'`})
+			Expect(envVars["MEMCACHED_TOKEN"]).To(Equal(`a&b<c>"d'`))
+		})
+
+		It("should return an error for invalid templates", func() {
+			definition := appContext.DependencyDefinitions["memcached"]
+			definition.Remote.Module.Variables = map[string]string{"name": "{{#appName}}"}
+			appContext.DependencyDefinitions["memcached"] = definition
+			memcached = config.NewRemoteAppDependency(appContext.Config.Remote.Dependencies[0], appContext)
+			_, err := memcached.GetDeploymentConfig()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Invalid template for 'name'"))
+		})
+	})
+})
@@ -0,0 +1,41 @@
+package config_test
+
+import (
+	"github.com/Originate/exosphere/src/config"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetLocalDependencyVolumeNames", func() {
+	It("should return the volumes of the application and service dependencies that persist data", func() {
+		appContext := &context.AppContext{
+			Config: types.AppConfig{
+				Name:     "volumes-app",
+				Services: map[string]types.ServiceSource{"users": {}},
+				Local: types.LocalConfig{
+					Dependencies: []types.LocalDependency{
+						{Name: "mongo", Version: "3.4.0", Config: types.LocalDependencyConfig{Persist: []string{"/data/db"}}},
+						{Name: "exocom", Version: "0.27.0"},
+					},
+				},
+			},
+			ServiceContexts: map[string]*context.ServiceContext{
+				"users": {
+					Config: types.ServiceConfig{
+						Local: types.LocalConfig{
+							Dependencies: []types.LocalDependency{
+								{Name: "redis", Version: "4.0.2", Config: types.LocalDependencyConfig{Persist: []string{"/data"}}},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(config.GetLocalDependencyVolumeNames(appContext)).To(Equal(map[string][]string{
+			"mongo": {"mongo__data_db"},
+			"redis": {"redis__data"},
+		}))
+	})
+})
@@ -0,0 +1,140 @@
+package config_test
+
+import (
+	"github.com/Originate/exosphere/src/config"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("dynamodb dependency", func() {
+	usersTable := types.DynamoDBTable{
+		Name:       "user-sessions",
+		HashKey:    "userId",
+		RangeKey:   "createdAt",
+		Attributes: map[string]string{"userId": "S", "createdAt": "N"},
+	}
+	ordersTable := types.DynamoDBTable{
+		Name:       "orders",
+		HashKey:    "id",
+		Attributes: map[string]string{"id": "S", "customerId": "S"},
+		GlobalSecondaryIndexes: []types.DynamoDBSecondaryIndex{
+			{Name: "by-customer", HashKey: "customerId"},
+		},
+	}
+	appDependency := types.LocalDependency{
+		Name:    "dynamodb",
+		Version: "1.11.119",
+		Config: types.LocalDependencyConfig{
+			Dynamodb: types.DynamoDBConfig{Tables: []types.DynamoDBTable{usersTable}},
+		},
+	}
+	appContext := &context.AppContext{
+		Config: types.AppConfig{
+			Name:     "dynamodb-app",
+			Services: map[string]types.ServiceSource{"orders-service": {}},
+			Local:    types.LocalConfig{Dependencies: []types.LocalDependency{appDependency}},
+			Remote:   types.AppRemoteConfig{Region: "us-west-2"},
+		},
+		ServiceContexts: map[string]*context.ServiceContext{
+			"orders-service": {
+				Config: types.ServiceConfig{
+					Local: types.LocalConfig{
+						Dependencies: []types.LocalDependency{
+							{
+								Name:    "dynamodb",
+								Version: "1.11.119",
+								Config: types.LocalDependencyConfig{
+									Dynamodb: types.DynamoDBConfig{Tables: []types.DynamoDBTable{ordersTable}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var _ = Describe("local", func() {
+		It("should return the docker config of DynamoDB Local", func() {
+			dynamodb := config.NewLocalAppDependency(appDependency, appContext)
+			actual, err := dynamodb.GetDockerConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal(types.DockerConfig{
+				Image:         "amazon/dynamodb-local:1.11.119",
+				Command:       "-jar DynamoDBLocal.jar -sharedDb -inMemory",
+				ContainerName: "dynamodb1.11.119",
+				Volumes:       []string{},
+				Restart:       "on-failure",
+			}))
+		})
+
+		It("should inject the endpoint, the credentials and the names of the declared tables", func() {
+			dynamodb := config.NewLocalAppDependency(appDependency, appContext)
+			Expect(dynamodb.GetServiceEnvVariables()).To(Equal(map[string]string{
+				"DYNAMODB_ENDPOINT":            "http://dynamodb1.11.119:8000",
+				"AWS_ACCESS_KEY_ID":            "exosphere-access-key",
+				"AWS_SECRET_ACCESS_KEY":        "exosphere-secret-key",
+				"AWS_REGION":                   "us-east-1",
+				"DYNAMODB_TABLE_USER_SESSIONS": "dynamodb-app-user-sessions",
+			}))
+		})
+
+		It("should create the tables of the application and all services in an init container", func() {
+			dynamodb := config.NewLocalAppDependency(appDependency, appContext)
+			dockerConfigs, err := config.GetLocalAppDependencyDockerConfigs(dynamodb)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dockerConfigs).To(HaveKey("dynamodb1.11.119"))
+			initConfig := dockerConfigs["dynamodb1.11.119-init"]
+			Expect(initConfig.DependsOn).To(Equal([]string{"dynamodb1.11.119"}))
+			Expect(initConfig.Environment["DYNAMODB_ENDPOINT"]).To(Equal("http://dynamodb1.11.119:8000"))
+			Expect(initConfig.Environment["TABLE_0"]).To(MatchJSON(`{
+				"TableName": "dynamodb-app-user-sessions",
+				"AttributeDefinitions": [
+					{"AttributeName": "createdAt", "AttributeType": "N"},
+					{"AttributeName": "userId", "AttributeType": "S"}
+				],
+				"KeySchema": [
+					{"AttributeName": "userId", "KeyType": "HASH"},
+					{"AttributeName": "createdAt", "KeyType": "RANGE"}
+				],
+				"ProvisionedThroughput": {"ReadCapacityUnits": 5, "WriteCapacityUnits": 5}
+			}`))
+			Expect(initConfig.Environment["TABLE_1"]).To(MatchJSON(`{
+				"TableName": "dynamodb-app-orders",
+				"AttributeDefinitions": [
+					{"AttributeName": "customerId", "AttributeType": "S"},
+					{"AttributeName": "id", "AttributeType": "S"}
+				],
+				"KeySchema": [{"AttributeName": "id", "KeyType": "HASH"}],
+				"GlobalSecondaryIndexes": [
+					{
+						"IndexName": "by-customer",
+						"KeySchema": [{"AttributeName": "customerId", "KeyType": "HASH"}],
+						"Projection": {"ProjectionType": "ALL"},
+						"ProvisionedThroughput": {"ReadCapacityUnits": 5, "WriteCapacityUnits": 5}
+					}
+				],
+				"ProvisionedThroughput": {"ReadCapacityUnits": 5, "WriteCapacityUnits": 5}
+			}`))
+			Expect(initConfig.Entrypoint[2]).To(ContainSubstring("--table-name dynamodb-app-orders > /dev/null 2>&1 || aws dynamodb create-table --endpoint-url $$DYNAMODB_ENDPOINT --cli-input-json \"$$TABLE_1\""))
+		})
+	})
+
+	var _ = Describe("remote", func() {
+		It("should inject the regional endpoint and the names of the declared tables", func() {
+			dynamodb := config.NewRemoteAppDependency(types.RemoteDependency{
+				Name: "dynamodb",
+				Config: types.RemoteDependencyConfig{
+					Dynamodb: types.DynamoDBConfig{Tables: []types.DynamoDBTable{usersTable}},
+				},
+			}, appContext)
+			Expect(dynamodb.HasDockerConfig()).To(BeFalse())
+			Expect(dynamodb.GetDeploymentServiceEnvVariables(types.Secrets{})).To(Equal(map[string]string{
+				"DYNAMODB_ENDPOINT":            "https://dynamodb.us-west-2.amazonaws.com",
+				"DYNAMODB_TABLE_USER_SESSIONS": "dynamodb-app-user-sessions",
+			}))
+		})
+	})
+})
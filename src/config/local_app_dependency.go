@@ -13,14 +13,78 @@ type LocalAppDependency interface {
 	GetVolumeNames() []string
 }
 
-// NewLocalAppDependency returns a LocalAppDependency
+// NewLocalAppDependency returns a LocalAppDependency.
+// Dependency types defined in .exosphere/dependencies take precedence over the built-in ones
 func NewLocalAppDependency(dependency types.LocalDependency, appContext *context.AppContext) LocalAppDependency {
+	if definition, ok := appContext.DependencyDefinitions[dependency.Name]; ok {
+		return &localDefinedDependency{dependency, definition.Local, appContext}
+	}
 	switch dependency.Name {
 	case "exocom":
 		return &localExocomDependency{dependency, appContext}
 	case "nats":
 		return &localNatsDependency{dependency}
+	case "redis":
+		return &localRedisDependency{dependency}
+	case "dynamodb":
+		return &localDynamoDBDependency{dependency, appContext}
+	case "s3":
+		return &localS3Dependency{dependency, appContext}
+	case "queue":
+		return &localQueueDependency{dependency, appContext}
 	default:
-		return &localGenericDependency{dependency}
+		genericDependency := &localGenericDependency{dependency, appContext}
+		if len(dependency.Config.Seed) > 0 {
+			return &localSeededDependency{genericDependency}
+		}
+		return genericDependency
+	}
+}
+
+// the image of the init containers that set up local emulators of AWS services
+const awsCLIImage = "amazon/aws-cli"
+
+// the dummy credentials and region the local emulators of AWS services and their init containers use.
+// DynamoDB Local and LocalStack accept any credentials while MinIO is started with these,
+// so services using several emulators receive the same AWS variables from all of them
+const (
+	awsLocalAccessKeyID     = "exosphere-access-key"
+	awsLocalSecretAccessKey = "exosphere-secret-key"
+	awsLocalRegion          = "us-east-1"
+)
+
+// LocalAppDependencyInitializer is implemented by local dependencies that need a one-off
+// container to set them up (for example to create tables) once they are running
+type LocalAppDependencyInitializer interface {
+	GetInitContainerName() string
+	GetInitDockerConfig() (types.DockerConfig, error)
+}
+
+// GetLocalAppDependencyContainerNames returns the names of the containers of the given dependency,
+// including the one of its init container if it has any
+func GetLocalAppDependencyContainerNames(dependency LocalAppDependency) []string {
+	result := []string{dependency.GetContainerName()}
+	if initializer, ok := dependency.(LocalAppDependencyInitializer); ok {
+		result = append(result, initializer.GetInitContainerName())
+	}
+	return result
+}
+
+// GetLocalAppDependencyDockerConfigs returns the docker configs of the given dependency
+// keyed by container name, including the one of its init container if it has any
+func GetLocalAppDependencyDockerConfigs(dependency LocalAppDependency) (types.DockerConfigs, error) {
+	result := types.DockerConfigs{}
+	dockerConfig, err := dependency.GetDockerConfig()
+	if err != nil {
+		return result, err
+	}
+	result[dependency.GetContainerName()] = dockerConfig
+	if initializer, ok := dependency.(LocalAppDependencyInitializer); ok {
+		initDockerConfig, err := initializer.GetInitDockerConfig()
+		if err != nil {
+			return result, err
+		}
+		result[initializer.GetInitContainerName()] = initDockerConfig
 	}
+	return result, nil
 }
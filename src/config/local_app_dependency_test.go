@@ -201,6 +201,69 @@ var _ = Describe("LocalAppDependency", func() {
 		})
 	})
 
+	var _ = Describe("nats dependency with an auth token", func() {
+		var nats config.LocalAppDependency
+
+		var _ = BeforeEach(func() {
+			nats = config.NewLocalAppDependency(types.LocalDependency{
+				Name:    "nats",
+				Version: "0.9.6",
+				Config: types.LocalDependencyConfig{
+					Nats: types.NatsConfig{AuthTokenSecretName: "NATS_TOKEN"},
+				},
+			}, appContext)
+		})
+
+		It("should pass the auth token from the environment to the server", func() {
+			actual, err := nats.GetDockerConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Command).To(Equal("--auth ${NATS_TOKEN}"))
+		})
+
+		It("should pass the auth token to the services", func() {
+			Expect(nats.GetServiceEnvVariables()).To(Equal(map[string]string{
+				"NATS_HOST":       "nats0.9.6",
+				"NATS_AUTH_TOKEN": "${NATS_TOKEN}",
+			}))
+		})
+	})
+
+	var _ = Describe("nats prod dependency", func() {
+		var nats config.RemoteAppDependency
+
+		var _ = BeforeEach(func() {
+			nats = config.NewRemoteAppDependency(types.RemoteDependency{
+				Name:    "nats",
+				Version: "0.9.6",
+				Config: types.RemoteDependencyConfig{
+					Nats: types.NatsConfig{ClusterSize: "3", AuthTokenSecretName: "NATS_TOKEN"},
+				},
+			}, appContext)
+		})
+
+		var _ = Describe("GetDeploymentServiceEnvVariables", func() {
+			It("should return the NATS_HOST and the auth token", func() {
+				Expect(nats.GetDeploymentServiceEnvVariables(types.Secrets{"NATS_TOKEN": "secret"})).To(Equal(map[string]string{
+					"NATS_HOST":       "nats.complex-setup-app.local",
+					"NATS_AUTH_TOKEN": "secret",
+				}))
+			})
+		})
+
+		var _ = Describe("GetDeploymentConfig", func() {
+			It("should return the correct deployment config for nats", func() {
+				actual, err := nats.GetDeploymentConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual).To(Equal(map[string]string{
+					"appName":           "complex-setup-app",
+					"version":           "0.9.6",
+					"clusterSize":       "3",
+					"authTokenVariable": "NATS_TOKEN",
+				}))
+			})
+		})
+	})
+
 	var _ = Describe("rds dependency", func() {
 		var rds config.RemoteAppDependency
 		var _ = BeforeEach(func() {
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// localDefinedDependency is a dependency whose type is defined in .exosphere/dependencies
+type localDefinedDependency struct {
+	config     types.LocalDependency
+	definition types.LocalDependencyDefinition
+	appContext *context.AppContext
+}
+
+// GetContainerName returns the container name
+func (d *localDefinedDependency) GetContainerName() string {
+	return d.config.Name + d.config.Version
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (d *localDefinedDependency) GetDockerConfig() (types.DockerConfig, error) {
+	templateContext, err := d.getTemplateContext()
+	if err != nil {
+		return types.DockerConfig{}, err
+	}
+	volumes := []string{}
+	for _, path := range d.getPersistedPaths() {
+		volumes = append(volumes, fmt.Sprintf("%s:%s", d.getVolumeName(path), path))
+	}
+	environment, err := renderDefinitionTemplates(d.definition.DependencyEnvironment, templateContext)
+	if err != nil {
+		return types.DockerConfig{}, err
+	}
+	util.Merge(environment, d.config.Config.DependencyEnvironment)
+	image, err := renderDefinitionTemplate(d.definition.Image, templateContext)
+	if err != nil {
+		return types.DockerConfig{}, err
+	}
+	command, err := renderDefinitionTemplate(d.definition.Command, templateContext)
+	if err != nil {
+		return types.DockerConfig{}, err
+	}
+	healthcheck, err := d.getHealthcheck(templateContext)
+	if err != nil {
+		return types.DockerConfig{}, err
+	}
+	return types.DockerConfig{
+		Image:         image,
+		Command:       command,
+		ContainerName: d.GetContainerName(),
+		Ports:         util.JoinStringSlices(d.definition.Ports, d.config.Config.Ports),
+		Volumes:       volumes,
+		Environment:   environment,
+		Restart:       "on-failure",
+		Healthcheck:   healthcheck,
+	}, nil
+}
+
+// GetServiceEnvVariables returns the environment variables that need to
+// be passed to services that use it
+func (d *localDefinedDependency) GetServiceEnvVariables() map[string]string {
+	templateContext, err := d.getTemplateContext()
+	if err != nil {
+		return map[string]string{}
+	}
+	result, err := renderDefinitionTemplates(d.definition.ServiceEnvironment, templateContext)
+	if err != nil {
+		return map[string]string{}
+	}
+	util.Merge(result, d.config.Config.ServiceEnvironment)
+	return result
+}
+
+// GetVolumeNames returns the named volumes used by this dependency
+func (d *localDefinedDependency) GetVolumeNames() []string {
+	result := []string{}
+	for _, path := range d.getPersistedPaths() {
+		result = append(result, d.getVolumeName(path))
+	}
+	return result
+}
+
+func (d *localDefinedDependency) getHealthcheck(templateContext map[string]interface{}) (*types.DockerHealthcheck, error) {
+	healthcheck := d.definition.Healthcheck
+	if healthcheck.Command == "" {
+		return nil, nil
+	}
+	command, err := renderDefinitionTemplate(healthcheck.Command, templateContext)
+	if err != nil {
+		return nil, err
+	}
+	return &types.DockerHealthcheck{
+		Test:     []string{"CMD-SHELL", command},
+		Interval: healthcheck.Interval,
+		Timeout:  healthcheck.Timeout,
+		Retries:  healthcheck.Retries,
+	}, nil
+}
+
+func (d *localDefinedDependency) getPersistedPaths() []string {
+	result := []string{}
+	for _, path := range util.JoinStringSlices(d.definition.Persist, d.config.Config.Persist) {
+		if !util.DoesStringArrayContain(result, path) {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
+func (d *localDefinedDependency) getTemplateContext() (map[string]interface{}, error) {
+	templateContext, err := getDefinitionTemplateContext(d.config.Name, d.config.Version, d.config.Config.Variables, d.appContext)
+	if err != nil {
+		return nil, err
+	}
+	templateContext["containerName"] = d.GetContainerName()
+	return templateContext, nil
+}
+
+func (d *localDefinedDependency) getVolumeName(path string) string {
+	return util.ToSnake(d.config.Name + "_" + path)
+}
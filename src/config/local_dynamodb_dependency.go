@@ -0,0 +1,206 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+)
+
+type localDynamoDBDependency struct {
+	config     types.LocalDependency
+	appContext *context.AppContext
+}
+
+// GetContainerName returns the container name
+func (d *localDynamoDBDependency) GetContainerName() string {
+	return d.config.Name + d.config.Version
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (d *localDynamoDBDependency) GetDockerConfig() (types.DockerConfig, error) {
+	volumes := []string{}
+	for _, path := range d.config.Config.Persist {
+		volumes = append(volumes, fmt.Sprintf("%s:%s", d.getVolumeName(path), path))
+	}
+	// -sharedDb makes the tables visible regardless of the credentials and region clients use
+	command := "-jar DynamoDBLocal.jar -sharedDb"
+	if len(d.config.Config.Persist) > 0 {
+		command += " -dbPath " + d.config.Config.Persist[0]
+	} else {
+		command += " -inMemory"
+	}
+	return types.DockerConfig{
+		Image:         fmt.Sprintf("amazon/dynamodb-local:%s", d.config.Version),
+		Command:       command,
+		ContainerName: d.GetContainerName(),
+		Ports:         d.config.Config.Ports,
+		Volumes:       volumes,
+		Environment:   d.config.Config.DependencyEnvironment,
+		Restart:       "on-failure",
+	}, nil
+}
+
+// GetInitContainerName returns the name of the container that creates the tables
+func (d *localDynamoDBDependency) GetInitContainerName() string {
+	return d.GetContainerName() + "-init"
+}
+
+// GetInitDockerConfig returns the docker configuration of the container that creates
+// the tables declared by the application and all services once DynamoDB Local is up.
+// Tables that already exist (because they are persisted) are left untouched
+func (d *localDynamoDBDependency) GetInitDockerConfig() (types.DockerConfig, error) {
+	environment := map[string]string{
+		"AWS_ACCESS_KEY_ID":     awsLocalAccessKeyID,
+		"AWS_SECRET_ACCESS_KEY": awsLocalSecretAccessKey,
+		"AWS_DEFAULT_REGION":    awsLocalRegion,
+		"DYNAMODB_ENDPOINT":     d.getEndpoint(),
+	}
+	var script bytes.Buffer
+	script.WriteString("until aws dynamodb list-tables --endpoint-url $$DYNAMODB_ENDPOINT > /dev/null 2>&1; do sleep 1; done")
+	for i, table := range d.getAllTables() {
+		tableName := table.GetTableName(d.appContext.Config.Name)
+		input, err := json.Marshal(getDynamoDBCreateTableInput(table, tableName))
+		if err != nil {
+			return types.DockerConfig{}, err
+		}
+		variable := fmt.Sprintf("TABLE_%d", i)
+		environment[variable] = string(input)
+		fmt.Fprintf(&script, "; aws dynamodb describe-table --endpoint-url $$DYNAMODB_ENDPOINT --table-name %s > /dev/null 2>&1 || aws dynamodb create-table --endpoint-url $$DYNAMODB_ENDPOINT --cli-input-json \"$$%s\" > /dev/null", tableName, variable)
+	}
+	return types.DockerConfig{
+		Image:         awsCLIImage,
+		Entrypoint:    []string{"sh", "-c", script.String()},
+		ContainerName: d.GetInitContainerName(),
+		Environment:   environment,
+		DependsOn:     []string{d.GetContainerName()},
+		Restart:       "on-failure",
+	}, nil
+}
+
+// GetServiceEnvVariables returns the environment variables that need to
+// be passed to services that use it
+func (d *localDynamoDBDependency) GetServiceEnvVariables() map[string]string {
+	result := map[string]string{
+		"DYNAMODB_ENDPOINT":     d.getEndpoint(),
+		"AWS_ACCESS_KEY_ID":     awsLocalAccessKeyID,
+		"AWS_SECRET_ACCESS_KEY": awsLocalSecretAccessKey,
+		"AWS_REGION":            awsLocalRegion,
+	}
+	for _, table := range d.config.Config.Dynamodb.Tables {
+		result[table.GetEnvVarName()] = table.GetTableName(d.appContext.Config.Name)
+	}
+	util.Merge(result, d.config.Config.ServiceEnvironment)
+	return result
+}
+
+// GetVolumeNames returns the named volumes used by this dependency
+func (d *localDynamoDBDependency) GetVolumeNames() []string {
+	result := []string{}
+	for _, path := range d.config.Config.Persist {
+		result = append(result, d.getVolumeName(path))
+	}
+	return result
+}
+
+func (d *localDynamoDBDependency) getEndpoint() string {
+	return fmt.Sprintf("http://%s:8000", d.GetContainerName())
+}
+
+func (d *localDynamoDBDependency) getVolumeName(path string) string {
+	return util.ToSnake(d.config.Name + "_" + path)
+}
+
+// returns the tables declared by the application and all services, since they all share
+// the same DynamoDB Local container
+func (d *localDynamoDBDependency) getAllTables() []types.DynamoDBTable {
+	tableNames := []string{}
+	result := []types.DynamoDBTable{}
+	for _, dependency := range getAllLocalDependencies(d.appContext, d.config.Name) {
+		for _, table := range dependency.Config.Dynamodb.Tables {
+			if !util.DoesStringArrayContain(tableNames, table.Name) {
+				tableNames = append(tableNames, table.Name)
+				result = append(result, table)
+			}
+		}
+	}
+	return result
+}
+
+type dynamoDBKeySchemaElement struct {
+	AttributeName string
+	KeyType       string
+}
+
+type dynamoDBProvisionedThroughput struct {
+	ReadCapacityUnits  int
+	WriteCapacityUnits int
+}
+
+type dynamoDBGlobalSecondaryIndex struct {
+	IndexName             string
+	KeySchema             []dynamoDBKeySchemaElement
+	Projection            map[string]string
+	ProvisionedThroughput dynamoDBProvisionedThroughput
+}
+
+type dynamoDBCreateTableInput struct {
+	TableName              string
+	AttributeDefinitions   []map[string]string
+	KeySchema              []dynamoDBKeySchemaElement
+	GlobalSecondaryIndexes []dynamoDBGlobalSecondaryIndex `json:",omitempty"`
+	ProvisionedThroughput  dynamoDBProvisionedThroughput
+}
+
+// returns the input of 'aws dynamodb create-table' for the given table
+func getDynamoDBCreateTableInput(table types.DynamoDBTable, tableName string) dynamoDBCreateTableInput {
+	result := dynamoDBCreateTableInput{
+		TableName:             tableName,
+		AttributeDefinitions:  []map[string]string{},
+		KeySchema:             getDynamoDBKeySchema(table.HashKey, table.RangeKey),
+		ProvisionedThroughput: getDynamoDBProvisionedThroughput(table.GetReadCapacity(), table.GetWriteCapacity()),
+	}
+	attributes := []string{}
+	for attribute := range table.Attributes {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	for _, attribute := range attributes {
+		result.AttributeDefinitions = append(result.AttributeDefinitions, map[string]string{
+			"AttributeName": attribute,
+			"AttributeType": table.Attributes[attribute],
+		})
+	}
+	for _, index := range table.GlobalSecondaryIndexes {
+		result.GlobalSecondaryIndexes = append(result.GlobalSecondaryIndexes, dynamoDBGlobalSecondaryIndex{
+			IndexName:             index.Name,
+			KeySchema:             getDynamoDBKeySchema(index.HashKey, index.RangeKey),
+			Projection:            map[string]string{"ProjectionType": index.GetProjectionType()},
+			ProvisionedThroughput: getDynamoDBProvisionedThroughput(index.GetReadCapacity(), index.GetWriteCapacity()),
+		})
+	}
+	return result
+}
+
+func getDynamoDBKeySchema(hashKey, rangeKey string) []dynamoDBKeySchemaElement {
+	result := []dynamoDBKeySchemaElement{{AttributeName: hashKey, KeyType: "HASH"}}
+	if rangeKey != "" {
+		result = append(result, dynamoDBKeySchemaElement{AttributeName: rangeKey, KeyType: "RANGE"})
+	}
+	return result
+}
+
+// the capacities are validated to be integers when the configuration is loaded
+func getDynamoDBProvisionedThroughput(readCapacity, writeCapacity string) dynamoDBProvisionedThroughput {
+	readCapacityUnits, _ := strconv.Atoi(readCapacity)
+	writeCapacityUnits, _ := strconv.Atoi(writeCapacity)
+	return dynamoDBProvisionedThroughput{
+		ReadCapacityUnits:  readCapacityUnits,
+		WriteCapacityUnits: writeCapacityUnits,
+	}
+}
@@ -2,14 +2,18 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"path"
 	"strings"
 
 	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
 	"github.com/Originate/exosphere/src/util"
 )
 
 type localGenericDependency struct {
-	config types.LocalDependency
+	config     types.LocalDependency
+	appContext *context.AppContext
 }
 
 // GetContainerName returns the container name
@@ -21,14 +25,17 @@ func (g *localGenericDependency) GetContainerName() string {
 func (g *localGenericDependency) GetDockerConfig() (types.DockerConfig, error) {
 	volumes := []string{}
 	for _, path := range g.config.Config.Persist {
-		name := util.ToSnake(g.config.Name + "_" + path)
-		volumes = append(volumes, fmt.Sprintf("%s:%s", name, path))
+		volumes = append(volumes, fmt.Sprintf("%s:%s", getPersistedVolumeName(g.config, path), path))
+	}
+	initVolumes, err := g.getInitVolumes()
+	if err != nil {
+		return types.DockerConfig{}, err
 	}
 	return types.DockerConfig{
-		Image:         fmt.Sprintf("%s:%s", g.config.Name, g.config.Version),
+		Image:         g.getImage(),
 		ContainerName: g.GetContainerName(),
 		Ports:         g.config.Config.Ports,
-		Volumes:       volumes,
+		Volumes:       append(volumes, initVolumes...),
 		Environment:   g.config.Config.DependencyEnvironment,
 		Restart:       "on-failure",
 	}, nil
@@ -47,8 +54,37 @@ func (g *localGenericDependency) GetServiceEnvVariables() map[string]string {
 func (g *localGenericDependency) GetVolumeNames() []string {
 	result := []string{}
 	for _, path := range g.config.Config.Persist {
-		name := util.ToSnake(g.config.Name + "_" + path)
-		result = append(result, name)
+		result = append(result, getPersistedVolumeName(g.config, path))
 	}
 	return result
 }
+
+func getPersistedVolumeName(dependency types.LocalDependency, path string) string {
+	return util.ToSnake(dependency.Name + "_" + path)
+}
+
+func (g *localGenericDependency) getImage() string {
+	return fmt.Sprintf("%s:%s", g.config.Name, g.config.Version)
+}
+
+// returns the bind mounts of the init files into the init hook of the image.
+// A directory is mounted as the init hook itself, files are mounted into it
+func (g *localGenericDependency) getInitVolumes() ([]string, error) {
+	result := []string{}
+	initPath := g.config.GetInitPath()
+	for _, filePath := range g.config.Config.Init {
+		fileInfo, err := os.Stat(path.Join(g.appContext.Location, filePath))
+		if err != nil {
+			return result, fmt.Errorf("cannot find '%s' of 'config.init' of dependency '%s'", filePath, g.config.Name)
+		}
+		if fileInfo.IsDir() {
+			if len(g.config.Config.Init) > 1 {
+				return result, fmt.Errorf("'config.init' of dependency '%s' must contain either a single directory or files", g.config.Name)
+			}
+			result = append(result, fmt.Sprintf("${APP_PATH}/%s:%s:ro", path.Clean(filePath), initPath))
+		} else {
+			result = append(result, fmt.Sprintf("${APP_PATH}/%s:%s/%s:ro", path.Clean(filePath), initPath, path.Base(filePath)))
+		}
+	}
+	return result, nil
+}
@@ -19,11 +19,20 @@ func (n *localNatsDependency) GetContainerName() string {
 func (n *localNatsDependency) GetDockerConfig() (types.DockerConfig, error) {
 	return types.DockerConfig{
 		Image:         fmt.Sprintf("nats:%s", n.config.Version),
+		Command:       n.getCommand(),
 		ContainerName: n.GetContainerName(),
 		Restart:       "on-failure",
 	}, nil
 }
 
+// the auth token is read from the environment exo runs in, since docker-compose interpolates it
+func (n *localNatsDependency) getCommand() string {
+	if n.config.Config.Nats.AuthTokenSecretName == "" {
+		return ""
+	}
+	return fmt.Sprintf("--auth ${%s}", n.config.Config.Nats.AuthTokenSecretName)
+}
+
 // GetEnvVariables returns the environment variables
 func (n *localNatsDependency) GetEnvVariables() map[string]string {
 	return map[string]string{}
@@ -32,7 +41,11 @@ func (n *localNatsDependency) GetEnvVariables() map[string]string {
 // GetServiceEnvVariables returns the environment variables that need to
 // be passed to services that use it
 func (n *localNatsDependency) GetServiceEnvVariables() map[string]string {
-	return map[string]string{"NATS_HOST": n.GetContainerName()}
+	result := map[string]string{"NATS_HOST": n.GetContainerName()}
+	if n.config.Config.Nats.AuthTokenSecretName != "" {
+		result["NATS_AUTH_TOKEN"] = fmt.Sprintf("${%s}", n.config.Config.Nats.AuthTokenSecretName)
+	}
+	return result
 }
 
 // GetVolumeNames returns the named volumes used by this dependency
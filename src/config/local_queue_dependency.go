@@ -0,0 +1,188 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// the account ID LocalStack uses in the ARNs and URLs of the queues and topics
+const localStackAccountID = "000000000000"
+
+// localQueueDependency runs LocalStack rather than ElasticMQ
+// since it emulates SNS topics and their subscriptions as well
+type localQueueDependency struct {
+	config     types.LocalDependency
+	appContext *context.AppContext
+}
+
+// GetContainerName returns the container name
+func (q *localQueueDependency) GetContainerName() string {
+	return q.config.Name + q.config.Version
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (q *localQueueDependency) GetDockerConfig() (types.DockerConfig, error) {
+	environment := map[string]string{
+		"SERVICES":          "sqs,sns",
+		"DEFAULT_REGION":    awsLocalRegion,
+		"HOSTNAME_EXTERNAL": q.GetContainerName(),
+	}
+	util.Merge(environment, q.config.Config.DependencyEnvironment)
+	return types.DockerConfig{
+		Image:         fmt.Sprintf("localstack/localstack:%s", q.config.Version),
+		ContainerName: q.GetContainerName(),
+		Ports:         q.config.Config.Ports,
+		Environment:   environment,
+		Restart:       "on-failure",
+	}, nil
+}
+
+// GetInitContainerName returns the name of the container that creates the queues and topics
+func (q *localQueueDependency) GetInitContainerName() string {
+	return q.GetContainerName() + "-init"
+}
+
+// GetInitDockerConfig returns the docker configuration of the container that creates
+// the queues, topics and subscriptions declared by the application and all services once LocalStack is up
+func (q *localQueueDependency) GetInitDockerConfig() (types.DockerConfig, error) {
+	environment := map[string]string{
+		"AWS_ACCESS_KEY_ID":     awsLocalAccessKeyID,
+		"AWS_SECRET_ACCESS_KEY": awsLocalSecretAccessKey,
+		"AWS_DEFAULT_REGION":    awsLocalRegion,
+		"AWS_ENDPOINT":          q.getEndpoint(),
+	}
+	var script bytes.Buffer
+	script.WriteString("until aws sqs list-queues --endpoint-url $$AWS_ENDPOINT > /dev/null 2>&1; do sleep 1; done")
+	queues, topics := q.getAllQueuesAndTopics()
+	for i, queue := range queues {
+		fmt.Fprintf(&script, "; aws sqs create-queue --endpoint-url $$AWS_ENDPOINT --queue-name %s", queue.GetQueueName(q.appContext.Config.Name))
+		attributes := getQueueAttributes(queue, q.appContext.Config.Name, awsLocalRegion, localStackAccountID)
+		if len(attributes) > 0 {
+			encodedAttributes, err := json.Marshal(attributes)
+			if err != nil {
+				return types.DockerConfig{}, err
+			}
+			variable := fmt.Sprintf("QUEUE_ATTRIBUTES_%d", i)
+			environment[variable] = string(encodedAttributes)
+			fmt.Fprintf(&script, " --attributes \"$$%s\"", variable)
+		}
+		script.WriteString(" > /dev/null")
+	}
+	for _, topic := range topics {
+		topicName := topic.GetTopicName(q.appContext.Config.Name)
+		fmt.Fprintf(&script, "; aws sns create-topic --endpoint-url $$AWS_ENDPOINT --name %s > /dev/null", topicName)
+		for _, subscription := range topic.Subscriptions {
+			queueName := fmt.Sprintf("%s-%s", q.appContext.Config.Name, subscription)
+			fmt.Fprintf(&script, "; aws sns subscribe --endpoint-url $$AWS_ENDPOINT --topic-arn %s --protocol sqs --notification-endpoint %s > /dev/null",
+				getTopicArn(topicName, awsLocalRegion, localStackAccountID),
+				getQueueArn(queueName, awsLocalRegion, localStackAccountID))
+		}
+	}
+	return types.DockerConfig{
+		Image:         awsCLIImage,
+		Entrypoint:    []string{"sh", "-c", script.String()},
+		ContainerName: q.GetInitContainerName(),
+		Environment:   environment,
+		DependsOn:     []string{q.GetContainerName()},
+		Restart:       "on-failure",
+	}, nil
+}
+
+// GetServiceEnvVariables returns the environment variables that need to
+// be passed to services that use it
+func (q *localQueueDependency) GetServiceEnvVariables() map[string]string {
+	result := map[string]string{
+		"SQS_ENDPOINT":          q.getEndpoint(),
+		"SNS_ENDPOINT":          q.getEndpoint(),
+		"AWS_ACCESS_KEY_ID":     awsLocalAccessKeyID,
+		"AWS_SECRET_ACCESS_KEY": awsLocalSecretAccessKey,
+		"AWS_REGION":            awsLocalRegion,
+	}
+	for _, queue := range q.config.Config.Queue.Queues {
+		result[queue.GetURLEnvVarName()] = fmt.Sprintf("%s/%s/%s", q.getEndpoint(), localStackAccountID, queue.GetQueueName(q.appContext.Config.Name))
+	}
+	for _, topic := range q.config.Config.Queue.Topics {
+		result[topic.GetArnEnvVarName()] = getTopicArn(topic.GetTopicName(q.appContext.Config.Name), awsLocalRegion, localStackAccountID)
+	}
+	util.Merge(result, q.config.Config.ServiceEnvironment)
+	return result
+}
+
+// GetVolumeNames returns the named volumes used by this dependency
+func (q *localQueueDependency) GetVolumeNames() []string {
+	return []string{}
+}
+
+func (q *localQueueDependency) getEndpoint() string {
+	return fmt.Sprintf("http://%s:4566", q.GetContainerName())
+}
+
+// returns the queues and topics declared by the application and all services,
+// since they all share the same LocalStack container.
+// Dead-letter queues come first so they exist when the queues using them are created
+func (q *localQueueDependency) getAllQueuesAndTopics() ([]types.Queue, []types.QueueTopic) {
+	queueNames := []string{}
+	deadLetterQueueNames := []string{}
+	allQueues := []types.Queue{}
+	topicNames := []string{}
+	topics := []types.QueueTopic{}
+	for _, dependency := range getAllLocalDependencies(q.appContext, q.config.Name) {
+		for _, queue := range dependency.Config.Queue.Queues {
+			if !util.DoesStringArrayContain(queueNames, queue.Name) {
+				queueNames = append(queueNames, queue.Name)
+				allQueues = append(allQueues, queue)
+			}
+			if queue.DeadLetter.Queue != "" {
+				deadLetterQueueNames = append(deadLetterQueueNames, queue.DeadLetter.Queue)
+			}
+		}
+		for _, topic := range dependency.Config.Queue.Topics {
+			if !util.DoesStringArrayContain(topicNames, topic.Name) {
+				topicNames = append(topicNames, topic.Name)
+				topics = append(topics, topic)
+			}
+		}
+	}
+	queues := []types.Queue{}
+	for _, queue := range allQueues {
+		if util.DoesStringArrayContain(deadLetterQueueNames, queue.Name) {
+			queues = append(queues, queue)
+		}
+	}
+	for _, queue := range allQueues {
+		if !util.DoesStringArrayContain(deadLetterQueueNames, queue.Name) {
+			queues = append(queues, queue)
+		}
+	}
+	return queues, topics
+}
+
+// returns the SQS attributes of the given queue
+func getQueueAttributes(queue types.Queue, appName, region, accountID string) map[string]string {
+	result := map[string]string{}
+	if queue.VisibilityTimeout != "" {
+		result["VisibilityTimeout"] = queue.VisibilityTimeout
+	}
+	if queue.MessageRetentionPeriod != "" {
+		result["MessageRetentionPeriod"] = queue.MessageRetentionPeriod
+	}
+	if queue.DeadLetter.Queue != "" {
+		result["RedrivePolicy"] = fmt.Sprintf(`{"deadLetterTargetArn":"%s","maxReceiveCount":"%s"}`,
+			getQueueArn(fmt.Sprintf("%s-%s", appName, queue.DeadLetter.Queue), region, accountID),
+			queue.DeadLetter.GetMaxReceiveCount())
+	}
+	return result
+}
+
+func getQueueArn(queueName, region, accountID string) string {
+	return fmt.Sprintf("arn:aws:sqs:%s:%s:%s", region, accountID, queueName)
+}
+
+func getTopicArn(topicName, region, accountID string) string {
+	return fmt.Sprintf("arn:aws:sns:%s:%s:%s", region, accountID, topicName)
+}
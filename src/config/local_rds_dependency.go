@@ -0,0 +1,129 @@
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// localRdsDependency is the local database synthesized for a remote postgres or mysql dependency
+// that has no local counterpart, so services receive the same env vars in both modes
+type localRdsDependency struct {
+	config     types.RemoteDependency
+	appContext *context.AppContext
+}
+
+// GetContainerName returns the container name
+func (r *localRdsDependency) GetContainerName() string {
+	return r.config.Name + r.config.Version
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (r *localRdsDependency) GetDockerConfig() (types.DockerConfig, error) {
+	rdsConfig := r.config.Config.Rds
+	environment := map[string]string{}
+	switch r.config.Name {
+	case "postgres":
+		environment["POSTGRES_DB"] = rdsConfig.DbName
+		environment["POSTGRES_USER"] = rdsConfig.Username
+		environment["POSTGRES_PASSWORD"] = r.getPassword()
+	case "mysql":
+		environment["MYSQL_DATABASE"] = rdsConfig.DbName
+		environment["MYSQL_USER"] = rdsConfig.Username
+		environment["MYSQL_PASSWORD"] = r.getPassword()
+		environment["MYSQL_ROOT_PASSWORD"] = r.getPassword()
+	}
+	return types.DockerConfig{
+		Image:         fmt.Sprintf("%s:%s", r.config.Name, r.config.Version),
+		ContainerName: r.GetContainerName(),
+		Volumes:       []string{fmt.Sprintf("%s:%s", r.getVolumeName(), r.getDataPath())},
+		Environment:   environment,
+		Restart:       "on-failure",
+	}, nil
+}
+
+// GetServiceEnvVariables returns the environment variables that need to
+// be passed to services that use it
+func (r *localRdsDependency) GetServiceEnvVariables() map[string]string {
+	rdsConfig := r.config.Config.Rds
+	result := map[string]string{
+		strings.ToUpper(r.config.Name): r.GetContainerName(),
+	}
+	for variable, value := range map[string]string{
+		rdsConfig.ServiceEnvVarNames.DbName:   rdsConfig.DbName,
+		rdsConfig.ServiceEnvVarNames.Username: rdsConfig.Username,
+		rdsConfig.ServiceEnvVarNames.Password: r.getPassword(),
+	} {
+		if variable != "" {
+			result[variable] = value
+		}
+	}
+	return result
+}
+
+// GetVolumeNames returns the named volumes used by this dependency
+func (r *localRdsDependency) GetVolumeNames() []string {
+	return []string{r.getVolumeName()}
+}
+
+func (r *localRdsDependency) getDataPath() string {
+	if r.config.Name == "mysql" {
+		return "/var/lib/mysql"
+	}
+	return "/var/lib/postgresql/data"
+}
+
+// the password is derived from the application and database names rather than random,
+// since it is stored in the persisted data when the database is first initialized
+func (r *localRdsDependency) getPassword() string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", r.appContext.Config.Name, r.config.Name, r.config.Config.Rds.DbName)))
+	return fmt.Sprintf("%x", hash)[:20]
+}
+
+func (r *localRdsDependency) getVolumeName() string {
+	return util.ToSnake(r.config.Name + "_" + r.getDataPath())
+}
+
+// adds a local database for each remote RDS dependency in the given list
+// that has no local counterpart in the given built dependencies
+func addLocalRdsDependencies(builtDependencies map[string]LocalAppDependency, remoteDependencies []types.RemoteDependency, appContext *context.AppContext) {
+	for _, dependency := range remoteDependencies {
+		if dependency.GetDbDependency() != "rds" {
+			continue
+		}
+		if _, ok := builtDependencies[dependency.Name]; !ok {
+			builtDependencies[dependency.Name] = &localRdsDependency{dependency, appContext}
+		}
+	}
+}
+
+// returns the remote dependencies of the given service that the application does not declare as well,
+// since those are already provided to all services
+func getServiceOnlyRemoteDependencies(serviceConfig types.ServiceConfig, appContext *context.AppContext) []types.RemoteDependency {
+	result := []types.RemoteDependency{}
+	for _, dependency := range serviceConfig.Remote.Dependencies {
+		if !hasAppDependency(appContext, dependency.Name) {
+			result = append(result, dependency)
+		}
+	}
+	return result
+}
+
+// returns whether the application declares a local or remote dependency with the given name
+func hasAppDependency(appContext *context.AppContext, name string) bool {
+	for _, dependency := range appContext.Config.Local.Dependencies {
+		if dependency.Name == name {
+			return true
+		}
+	}
+	for _, dependency := range appContext.Config.Remote.Dependencies {
+		if dependency.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/util"
+)
+
+type localRedisDependency struct {
+	config types.LocalDependency
+}
+
+// GetContainerName returns the container name
+func (r *localRedisDependency) GetContainerName() string {
+	return r.config.Name + r.config.Version
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (r *localRedisDependency) GetDockerConfig() (types.DockerConfig, error) {
+	volumes := []string{}
+	for _, path := range r.config.Config.Persist {
+		volumes = append(volumes, fmt.Sprintf("%s:%s", r.getVolumeName(path), path))
+	}
+	command := ""
+	if len(r.config.Config.Persist) > 0 {
+		command = "redis-server --appendonly yes"
+	}
+	return types.DockerConfig{
+		Image:         fmt.Sprintf("redis:%s", r.config.Version),
+		Command:       command,
+		ContainerName: r.GetContainerName(),
+		Ports:         r.config.Config.Ports,
+		Volumes:       volumes,
+		Environment:   r.config.Config.DependencyEnvironment,
+		Restart:       "on-failure",
+	}, nil
+}
+
+// GetServiceEnvVariables returns the environment variables that need to
+// be passed to services that use it
+func (r *localRedisDependency) GetServiceEnvVariables() map[string]string {
+	result := map[string]string{
+		r.config.Config.Redis.GetURLEnvVarName(): fmt.Sprintf("redis://%s:6379", r.GetContainerName()),
+	}
+	util.Merge(result, r.config.Config.ServiceEnvironment)
+	return result
+}
+
+// GetVolumeNames returns the named volumes used by this dependency
+func (r *localRedisDependency) GetVolumeNames() []string {
+	result := []string{}
+	for _, path := range r.config.Config.Persist {
+		result = append(result, r.getVolumeName(path))
+	}
+	return result
+}
+
+func (r *localRedisDependency) getVolumeName(path string) string {
+	return util.ToSnake(r.config.Name + "_" + path)
+}
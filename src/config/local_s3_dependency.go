@@ -0,0 +1,121 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// the image of the container that creates the declared buckets in MinIO
+const s3InitImage = "minio/mc"
+
+type localS3Dependency struct {
+	config     types.LocalDependency
+	appContext *context.AppContext
+}
+
+// GetContainerName returns the container name
+func (s *localS3Dependency) GetContainerName() string {
+	return s.config.Name + s.config.Version
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (s *localS3Dependency) GetDockerConfig() (types.DockerConfig, error) {
+	volumes := []string{}
+	for _, path := range s.config.Config.Persist {
+		volumes = append(volumes, fmt.Sprintf("%s:%s", s.getVolumeName(path), path))
+	}
+	dataDir := "/data"
+	if len(s.config.Config.Persist) > 0 {
+		dataDir = s.config.Config.Persist[0]
+	}
+	environment := map[string]string{
+		"MINIO_ACCESS_KEY": awsLocalAccessKeyID,
+		"MINIO_SECRET_KEY": awsLocalSecretAccessKey,
+	}
+	util.Merge(environment, s.config.Config.DependencyEnvironment)
+	return types.DockerConfig{
+		Image:         fmt.Sprintf("minio/minio:%s", s.config.Version),
+		Command:       "server " + dataDir,
+		ContainerName: s.GetContainerName(),
+		Ports:         s.config.Config.Ports,
+		Volumes:       volumes,
+		Environment:   environment,
+		Restart:       "on-failure",
+	}, nil
+}
+
+// GetInitContainerName returns the name of the container that creates the buckets
+func (s *localS3Dependency) GetInitContainerName() string {
+	return s.GetContainerName() + "-init"
+}
+
+// GetInitDockerConfig returns the docker configuration of the container that creates
+// the buckets declared by the application and all services once MinIO is up.
+// CORS and lifecycle settings only apply remotely, MinIO allows all origins
+func (s *localS3Dependency) GetInitDockerConfig() (types.DockerConfig, error) {
+	var script bytes.Buffer
+	script.WriteString("until mc ls local > /dev/null 2>&1; do sleep 1; done")
+	for _, bucket := range s.getAllBuckets() {
+		bucketPath := "local/" + bucket.GetBucketName(s.appContext.Config.Name)
+		fmt.Fprintf(&script, "; mc ls %s > /dev/null 2>&1 || mc mb %s", bucketPath, bucketPath)
+	}
+	return types.DockerConfig{
+		Image:         s3InitImage,
+		Entrypoint:    []string{"sh", "-c", script.String()},
+		ContainerName: s.GetInitContainerName(),
+		Environment: map[string]string{
+			"MC_HOST_local": fmt.Sprintf("http://%s:%s@%s:9000", awsLocalAccessKeyID, awsLocalSecretAccessKey, s.GetContainerName()),
+		},
+		DependsOn: []string{s.GetContainerName()},
+		Restart:   "on-failure",
+	}, nil
+}
+
+// GetServiceEnvVariables returns the environment variables that need to
+// be passed to services that use it
+func (s *localS3Dependency) GetServiceEnvVariables() map[string]string {
+	result := map[string]string{
+		"S3_ENDPOINT":           fmt.Sprintf("http://%s:9000", s.GetContainerName()),
+		"AWS_ACCESS_KEY_ID":     awsLocalAccessKeyID,
+		"AWS_SECRET_ACCESS_KEY": awsLocalSecretAccessKey,
+		"AWS_REGION":            awsLocalRegion,
+	}
+	for _, bucket := range s.config.Config.S3.Buckets {
+		result[bucket.GetEnvVarName()] = bucket.GetBucketName(s.appContext.Config.Name)
+	}
+	util.Merge(result, s.config.Config.ServiceEnvironment)
+	return result
+}
+
+// GetVolumeNames returns the named volumes used by this dependency
+func (s *localS3Dependency) GetVolumeNames() []string {
+	result := []string{}
+	for _, path := range s.config.Config.Persist {
+		result = append(result, s.getVolumeName(path))
+	}
+	return result
+}
+
+func (s *localS3Dependency) getVolumeName(path string) string {
+	return util.ToSnake(s.config.Name + "_" + path)
+}
+
+// returns the buckets declared by the application and all services, since they all share
+// the same MinIO container
+func (s *localS3Dependency) getAllBuckets() []types.S3Bucket {
+	bucketNames := []string{}
+	result := []types.S3Bucket{}
+	for _, dependency := range getAllLocalDependencies(s.appContext, s.config.Name) {
+		for _, bucket := range dependency.Config.S3.Buckets {
+			if !util.DoesStringArrayContain(bucketNames, bucket.Name) {
+				bucketNames = append(bucketNames, bucket.Name)
+				result = append(result, bucket)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// the directory in the seed container the volume holding the seed marker is mounted at
+const seedMarkerDir = "/exosphere-seed"
+
+// the name of the seed marker file
+const seedMarkerFileName = ".exosphere-seeded"
+
+// localSeededDependency is a generic dependency with 'config.seed' commands,
+// which are run by its init container
+type localSeededDependency struct {
+	*localGenericDependency
+}
+
+// GetInitContainerName returns the name of the container that runs the seed commands
+func (s *localSeededDependency) GetInitContainerName() string {
+	return s.GetContainerName() + "-seed"
+}
+
+// GetInitDockerConfig returns the docker configuration of the container that runs the seed commands
+// in the application directory, with the image of the dependency. It is restarted until the commands
+// succeed, which they can't before the dependency is ready. If the data is persisted,
+// a marker inside the volume of the first persisted path prevents seeding it again,
+// so that it disappears together with the data
+func (s *localSeededDependency) GetInitDockerConfig() (types.DockerConfig, error) {
+	script := []string{"set -e", "cd /app"}
+	volumes := []string{"${APP_PATH}:/app:ro"}
+	if s.hasSeedMarker() {
+		script = append(script, fmt.Sprintf("if [ -f %s ]; then exit 0; fi", s.getSeedMarkerPath()))
+		volumes = append(volumes, fmt.Sprintf("%s:%s", getPersistedVolumeName(s.config, s.config.Config.Persist[0]), seedMarkerDir))
+	}
+	for _, command := range s.config.Config.Seed {
+		// docker-compose would otherwise interpolate the variables meant for the shell
+		script = append(script, strings.Replace(command, "$", "$$", -1))
+	}
+	if s.hasSeedMarker() {
+		script = append(script, fmt.Sprintf("touch %s", s.getSeedMarkerPath()))
+	}
+	environment := map[string]string{}
+	util.Merge(environment, s.config.Config.DependencyEnvironment)
+	util.Merge(environment, s.GetServiceEnvVariables())
+	return types.DockerConfig{
+		Image:         s.getImage(),
+		Entrypoint:    []string{"sh", "-c", strings.Join(script, "\n")},
+		ContainerName: s.GetInitContainerName(),
+		Volumes:       volumes,
+		Environment:   environment,
+		DependsOn:     []string{s.GetContainerName()},
+		Restart:       "on-failure",
+	}, nil
+}
+
+// the seed marker is only needed if the data outlives the dependency container
+func (s *localSeededDependency) hasSeedMarker() bool {
+	return len(s.config.Config.Persist) > 0
+}
+
+func (s *localSeededDependency) getSeedMarkerPath() string {
+	return fmt.Sprintf("%s/%s", seedMarkerDir, seedMarkerFileName)
+}
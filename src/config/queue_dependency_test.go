@@ -0,0 +1,86 @@
+package config_test
+
+import (
+	"github.com/Originate/exosphere/src/config"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("queue dependency", func() {
+	queueConfig := types.QueueConfig{
+		Queues: []types.Queue{
+			{Name: "email-jobs", VisibilityTimeout: "60", DeadLetter: types.QueueDeadLetter{Queue: "email-jobs-failed"}},
+			{Name: "email-jobs-failed"},
+		},
+		Topics: []types.QueueTopic{
+			{Name: "user-events", Subscriptions: []string{"email-jobs"}},
+		},
+	}
+	localDependency := types.LocalDependency{
+		Name:    "queue",
+		Version: "0.12.2",
+		Config:  types.LocalDependencyConfig{Queue: queueConfig},
+	}
+	appContext := &context.AppContext{
+		Config: types.AppConfig{
+			Name:   "queue-app",
+			Local:  types.LocalConfig{Dependencies: []types.LocalDependency{localDependency}},
+			Remote: types.AppRemoteConfig{Region: "us-west-2", AccountID: "12345"},
+		},
+	}
+
+	var _ = Describe("local", func() {
+		It("should inject the credentials, the URLs of the queues and the ARNs of the topics", func() {
+			queue := config.NewLocalAppDependency(localDependency, appContext)
+			Expect(queue.GetServiceEnvVariables()).To(Equal(map[string]string{
+				"SQS_ENDPOINT":                "http://queue0.12.2:4566",
+				"SNS_ENDPOINT":                "http://queue0.12.2:4566",
+				"AWS_ACCESS_KEY_ID":           "exosphere-access-key",
+				"AWS_SECRET_ACCESS_KEY":       "exosphere-secret-key",
+				"AWS_REGION":                  "us-east-1",
+				"QUEUE_EMAIL_JOBS_URL":        "http://queue0.12.2:4566/000000000000/queue-app-email-jobs",
+				"QUEUE_EMAIL_JOBS_FAILED_URL": "http://queue0.12.2:4566/000000000000/queue-app-email-jobs-failed",
+				"TOPIC_USER_EVENTS_ARN":       "arn:aws:sns:us-east-1:000000000000:queue-app-user-events",
+			}))
+		})
+
+		It("should create the dead-letter queues first, then the queues, topics and subscriptions", func() {
+			queue := config.NewLocalAppDependency(localDependency, appContext)
+			dockerConfigs, err := config.GetLocalAppDependencyDockerConfigs(queue)
+			Expect(err).NotTo(HaveOccurred())
+			initConfig := dockerConfigs["queue0.12.2-init"]
+			Expect(initConfig.Entrypoint).To(Equal([]string{
+				"sh",
+				"-c",
+				"until aws sqs list-queues --endpoint-url $$AWS_ENDPOINT > /dev/null 2>&1; do sleep 1; done" +
+					"; aws sqs create-queue --endpoint-url $$AWS_ENDPOINT --queue-name queue-app-email-jobs-failed > /dev/null" +
+					"; aws sqs create-queue --endpoint-url $$AWS_ENDPOINT --queue-name queue-app-email-jobs --attributes \"$$QUEUE_ATTRIBUTES_1\" > /dev/null" +
+					"; aws sns create-topic --endpoint-url $$AWS_ENDPOINT --name queue-app-user-events > /dev/null" +
+					"; aws sns subscribe --endpoint-url $$AWS_ENDPOINT --topic-arn arn:aws:sns:us-east-1:000000000000:queue-app-user-events --protocol sqs --notification-endpoint arn:aws:sqs:us-east-1:000000000000:queue-app-email-jobs > /dev/null",
+			}))
+			Expect(initConfig.Environment["QUEUE_ATTRIBUTES_1"]).To(MatchJSON(`{
+				"VisibilityTimeout": "60",
+				"RedrivePolicy": "{\"deadLetterTargetArn\":\"arn:aws:sqs:us-east-1:000000000000:queue-app-email-jobs-failed\",\"maxReceiveCount\":\"5\"}"
+			}`))
+		})
+	})
+
+	var _ = Describe("remote", func() {
+		It("should inject the URLs of the queues and the ARNs of the topics", func() {
+			queue := config.NewRemoteAppDependency(types.RemoteDependency{
+				Name:   "queue",
+				Config: types.RemoteDependencyConfig{Queue: queueConfig},
+			}, appContext)
+			Expect(queue.HasDockerConfig()).To(BeFalse())
+			Expect(queue.GetDeploymentServiceEnvVariables(types.Secrets{})).To(Equal(map[string]string{
+				"SQS_ENDPOINT":                "https://sqs.us-west-2.amazonaws.com",
+				"SNS_ENDPOINT":                "https://sns.us-west-2.amazonaws.com",
+				"QUEUE_EMAIL_JOBS_URL":        "https://sqs.us-west-2.amazonaws.com/12345/queue-app-email-jobs",
+				"QUEUE_EMAIL_JOBS_FAILED_URL": "https://sqs.us-west-2.amazonaws.com/12345/queue-app-email-jobs-failed",
+				"TOPIC_USER_EVENTS_ARN":       "arn:aws:sns:us-west-2:12345:queue-app-user-events",
+			}))
+		})
+	})
+})
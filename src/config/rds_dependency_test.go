@@ -0,0 +1,103 @@
+package config_test
+
+import (
+	"github.com/Originate/exosphere/src/config"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("local database for remote rds dependencies", func() {
+	postgresDependency := types.RemoteDependency{
+		Name:    "postgres",
+		Version: "9.6.4",
+		Config: types.RemoteDependencyConfig{
+			Rds: types.RdsConfig{
+				DbName:             "my-db",
+				Username:           "originate-user",
+				PasswordSecretName: "POSTGRES_PASSWORD",
+				ServiceEnvVarNames: types.ServiceEnvVarNames{
+					DbName:   "DATABASE_NAME",
+					Username: "DATABASE_USERNAME",
+					Password: "DATABASE_PASSWORD",
+				},
+			},
+		},
+	}
+	mysqlDependency := types.RemoteDependency{
+		Name:    "mysql",
+		Version: "5.6.17",
+		Config: types.RemoteDependencyConfig{
+			Rds: types.RdsConfig{
+				DbName:             "my-sql-db",
+				Username:           "originate-user",
+				PasswordSecretName: "MYSQL_PASSWORD",
+				ServiceEnvVarNames: types.ServiceEnvVarNames{
+					DbName:   "MYSQL_DB_NAME",
+					Username: "MYSQL_USERNAME",
+					Password: "MYSQL_PASSWORD",
+				},
+			},
+		},
+	}
+	appContext := &context.AppContext{
+		Config: types.AppConfig{
+			Name:   "rds-app",
+			Remote: types.AppRemoteConfig{Dependencies: []types.RemoteDependency{postgresDependency}},
+		},
+	}
+
+	It("should synthesize a local postgres container for the application", func() {
+		builtDependencies := config.GetBuiltLocalAppDependencies(appContext)
+		Expect(builtDependencies).To(HaveKey("postgres"))
+		postgres := builtDependencies["postgres"]
+		dockerConfig, err := postgres.GetDockerConfig()
+		Expect(err).NotTo(HaveOccurred())
+		password := dockerConfig.Environment["POSTGRES_PASSWORD"]
+		Expect(password).To(HaveLen(20))
+		Expect(dockerConfig).To(Equal(types.DockerConfig{
+			Image:         "postgres:9.6.4",
+			ContainerName: "postgres9.6.4",
+			Volumes:       []string{"postgres__var_lib_postgresql_data:/var/lib/postgresql/data"},
+			Environment: map[string]string{
+				"POSTGRES_DB":       "my-db",
+				"POSTGRES_USER":     "originate-user",
+				"POSTGRES_PASSWORD": password,
+			},
+			Restart: "on-failure",
+		}))
+		Expect(postgres.GetVolumeNames()).To(Equal([]string{"postgres__var_lib_postgresql_data"}))
+		Expect(postgres.GetServiceEnvVariables()).To(Equal(map[string]string{
+			"POSTGRES":          "postgres9.6.4",
+			"DATABASE_NAME":     "my-db",
+			"DATABASE_USERNAME": "originate-user",
+			"DATABASE_PASSWORD": password,
+		}))
+	})
+
+	It("should synthesize a local mysql container for a service", func() {
+		serviceConfig := types.ServiceConfig{
+			Remote: types.ServiceRemoteConfig{Dependencies: []types.RemoteDependency{mysqlDependency, postgresDependency}},
+		}
+		builtDependencies := config.GetBuiltLocalServiceDependencies(serviceConfig, appContext)
+		Expect(builtDependencies).To(HaveLen(1))
+		dockerConfig, err := builtDependencies["mysql"].GetDockerConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dockerConfig.Image).To(Equal("mysql:5.6.17"))
+		Expect(dockerConfig.Volumes).To(Equal([]string{"mysql__var_lib_mysql:/var/lib/mysql"}))
+		Expect(dockerConfig.Environment["MYSQL_DATABASE"]).To(Equal("my-sql-db"))
+		Expect(dockerConfig.Environment["MYSQL_USER"]).To(Equal("originate-user"))
+	})
+
+	It("should not synthesize a container when a local dependency is declared", func() {
+		serviceConfig := types.ServiceConfig{
+			Local:  types.LocalConfig{Dependencies: []types.LocalDependency{{Name: "mysql", Version: "5.6.17"}}},
+			Remote: types.ServiceRemoteConfig{Dependencies: []types.RemoteDependency{mysqlDependency}},
+		}
+		builtDependencies := config.GetBuiltLocalServiceDependencies(serviceConfig, appContext)
+		Expect(builtDependencies["mysql"].GetServiceEnvVariables()).To(Equal(map[string]string{
+			"MYSQL": "mysql5.6.17",
+		}))
+	})
+})
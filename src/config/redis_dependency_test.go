@@ -0,0 +1,109 @@
+package config_test
+
+import (
+	"github.com/Originate/exosphere/src/config"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("redis dependency", func() {
+	appContext := &context.AppContext{
+		Config: types.AppConfig{Name: "redis-app"},
+	}
+
+	var _ = Describe("local", func() {
+		It("should return the docker config with persisted data", func() {
+			redis := config.NewLocalAppDependency(types.LocalDependency{
+				Name:    "redis",
+				Version: "4.0.2",
+				Config:  types.LocalDependencyConfig{Persist: []string{"/data"}},
+			}, appContext)
+			actual, err := redis.GetDockerConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal(types.DockerConfig{
+				Image:         "redis:4.0.2",
+				Command:       "redis-server --appendonly yes",
+				ContainerName: "redis4.0.2",
+				Volumes:       []string{"redis__data:/data"},
+				Restart:       "on-failure",
+			}))
+			Expect(redis.GetVolumeNames()).To(Equal([]string{"redis__data"}))
+		})
+
+		It("should inject REDIS_URL by default", func() {
+			redis := config.NewLocalAppDependency(types.LocalDependency{Name: "redis", Version: "4.0.2"}, appContext)
+			Expect(redis.GetServiceEnvVariables()).To(Equal(map[string]string{
+				"REDIS_URL": "redis://redis4.0.2:6379",
+			}))
+		})
+
+		It("should use the configured env var name", func() {
+			redis := config.NewLocalAppDependency(types.LocalDependency{
+				Name:    "redis",
+				Version: "4.0.2",
+				Config: types.LocalDependencyConfig{
+					Redis: types.RedisConfig{ServiceEnvVarNames: types.RedisServiceEnvVarNames{URL: "CACHE_URL"}},
+				},
+			}, appContext)
+			Expect(redis.GetServiceEnvVariables()).To(Equal(map[string]string{
+				"CACHE_URL": "redis://redis4.0.2:6379",
+			}))
+		})
+	})
+
+	var _ = Describe("remote", func() {
+		var redis config.RemoteAppDependency
+
+		var _ = BeforeEach(func() {
+			redis = config.NewRemoteAppDependency(types.RemoteDependency{
+				Name:    "redis",
+				Version: "3.2.10",
+				Config: types.RemoteDependencyConfig{
+					Redis: types.RedisConfig{
+						Name:               "sessions",
+						NodeType:           "cache.m4.large",
+						NumCacheClusters:   "2",
+						ServiceEnvVarNames: types.RedisServiceEnvVarNames{URL: "SESSIONS_URL"},
+					},
+				},
+			}, appContext)
+		})
+
+		It("should not build a docker image", func() {
+			Expect(redis.HasDockerConfig()).To(BeFalse())
+		})
+
+		It("should return the deployment config", func() {
+			Expect(redis.GetDeploymentConfig()).To(Equal(map[string]string{
+				"appName":            "redis-app",
+				"name":               "sessions",
+				"engineVersion":      "3.2.10",
+				"nodeType":           "cache.m4.large",
+				"numCacheClusters":   "2",
+				"replicationGroupID": "redis-app-sessions",
+			}))
+		})
+
+		It("should shorten replication group IDs longer than ElastiCache allows", func() {
+			redis = config.NewRemoteAppDependency(types.RemoteDependency{
+				Name:    "redis",
+				Version: "3.2.10",
+				Config: types.RemoteDependencyConfig{
+					Redis: types.RedisConfig{Name: "user-sessions"},
+				},
+			}, appContext)
+			deploymentConfig, err := redis.GetDeploymentConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentConfig["replicationGroupID"]).To(HaveLen(20))
+			Expect(deploymentConfig["replicationGroupID"]).To(HavePrefix("redis-app-use-"))
+		})
+
+		It("should inject the URL of the replication group", func() {
+			Expect(redis.GetDeploymentServiceEnvVariables(types.Secrets{})).To(Equal(map[string]string{
+				"SESSIONS_URL": "redis://sessions.redis-app.local:6379",
+			}))
+		})
+	})
+})
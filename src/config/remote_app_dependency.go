@@ -15,11 +15,25 @@ type RemoteAppDependency interface {
 	GetDeploymentVariables() (map[string]string, error)
 }
 
-// NewRemoteAppDependency returns an AppProductionDependency
+// NewRemoteAppDependency returns an AppProductionDependency.
+// Dependency types defined in .exosphere/dependencies take precedence over the built-in ones
 func NewRemoteAppDependency(dependency types.RemoteDependency, appContext *context.AppContext) RemoteAppDependency {
+	if definition, ok := appContext.DependencyDefinitions[dependency.Name]; ok {
+		return &remoteDefinedDependency{dependency, definition.Remote, appContext}
+	}
 	switch dependency.Name {
 	case "exocom":
 		return &remoteExocomDependency{dependency, appContext}
+	case "nats":
+		return &remoteNatsDependency{dependency, appContext}
+	case "redis":
+		return &remoteRedisDependency{dependency, appContext}
+	case "dynamodb":
+		return &remoteDynamoDBDependency{dependency, appContext}
+	case "s3":
+		return &remoteS3Dependency{dependency, appContext}
+	case "queue":
+		return &remoteQueueDependency{dependency, appContext}
 	case "postgres":
 		fallthrough
 	case "mysql":
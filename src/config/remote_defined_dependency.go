@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/pkg/errors"
+)
+
+// remoteDefinedDependency is a dependency whose type is defined in .exosphere/dependencies
+type remoteDefinedDependency struct {
+	config     types.RemoteDependency
+	definition types.RemoteDependencyDefinition
+	appContext *context.AppContext
+}
+
+// HasDockerConfig returns a boolean indicating if a docker-compose.yml entry should be generated for the dependency
+func (d *remoteDefinedDependency) HasDockerConfig() bool {
+	return d.definition.Image != ""
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (d *remoteDefinedDependency) GetDockerConfig() (types.DockerConfig, error) {
+	templateContext, err := getDefinitionTemplateContext(d.config.Name, d.config.Version, d.config.Config.Variables, d.appContext)
+	if err != nil {
+		return types.DockerConfig{}, err
+	}
+	image, err := renderDefinitionTemplate(d.definition.Image, templateContext)
+	if err != nil {
+		return types.DockerConfig{}, err
+	}
+	return types.DockerConfig{
+		Image: image,
+	}, nil
+}
+
+// GetServiceName returns the name used as the key of this dependency in docker-compose.yml
+func (d *remoteDefinedDependency) GetServiceName() string {
+	return d.config.Name + d.config.Version
+}
+
+// GetDeploymentConfig returns the rendered variables of the Terraform module of the dependency
+func (d *remoteDefinedDependency) GetDeploymentConfig() (map[string]string, error) {
+	templateContext, err := getDefinitionTemplateContext(d.config.Name, d.config.Version, d.config.Config.Variables, d.appContext)
+	if err != nil {
+		return map[string]string{}, err
+	}
+	return renderDefinitionTemplates(d.definition.Module.Variables, templateContext)
+}
+
+// GetDefinedDependencyModules returns the Terraform modules of the given remote dependency,
+// whose type is defined in .exosphere/dependencies, by module name with their variables rendered
+func GetDefinedDependencyModules(dependency types.RemoteDependency, definition types.DependencyDefinition, appContext *context.AppContext) (map[string]types.TerraformModuleMapping, error) {
+	result := map[string]types.TerraformModuleMapping{}
+	templateContext, err := getDefinitionTemplateContext(dependency.Name, dependency.Version, dependency.Config.Variables, appContext)
+	if err != nil {
+		return result, err
+	}
+	for moduleName, module := range definition.GetRemoteModules(dependency.Name) {
+		variables, err := renderDefinitionTemplates(module.Variables, templateContext)
+		if err != nil {
+			return result, errors.Wrap(err, fmt.Sprintf("Invalid variables of module '%s'", moduleName))
+		}
+		result[moduleName] = types.TerraformModuleMapping{Source: module.Source, Variables: variables}
+	}
+	return result, nil
+}
+
+// GetDeploymentServiceEnvVariables returns configuration needed for each service in deployment.
+// Secrets can be used in the templates as {{secrets.<name>}}
+func (d *remoteDefinedDependency) GetDeploymentServiceEnvVariables(secrets types.Secrets) map[string]string {
+	templateContext, err := getDefinitionTemplateContext(d.config.Name, d.config.Version, d.config.Config.Variables, d.appContext)
+	if err != nil {
+		return map[string]string{}
+	}
+	templateContext["secrets"] = map[string]string(secrets)
+	result, err := renderDefinitionTemplates(d.definition.ServiceEnvironment, templateContext)
+	if err != nil {
+		return map[string]string{}
+	}
+	return result
+}
+
+// GetDeploymentVariables returns a map from string to string of variables that a dependency Terraform module needs
+func (d *remoteDefinedDependency) GetDeploymentVariables() (map[string]string, error) {
+	return map[string]string{}, nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+)
+
+type remoteDynamoDBDependency struct {
+	config     types.RemoteDependency
+	appContext *context.AppContext
+}
+
+// HasDockerConfig returns a boolean indicating if a docker-compose.yml entry should be generated for the dependency
+func (d *remoteDynamoDBDependency) HasDockerConfig() bool {
+	return false
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (d *remoteDynamoDBDependency) GetDockerConfig() (types.DockerConfig, error) {
+	return types.DockerConfig{}, nil
+}
+
+// GetServiceName returns the name used as the key of this dependency in docker-compose.yml
+func (d *remoteDynamoDBDependency) GetServiceName() string {
+	return d.config.Name + d.config.Version
+}
+
+// GetDeploymentConfig returns configuration needed in deployment.
+// The tables are generated from the dependency config directly, see terraform.generateDynamoDBModules
+func (d *remoteDynamoDBDependency) GetDeploymentConfig() (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// GetDeploymentServiceEnvVariables returns env vars for a service
+func (d *remoteDynamoDBDependency) GetDeploymentServiceEnvVariables(secrets types.Secrets) map[string]string {
+	result := map[string]string{
+		"DYNAMODB_ENDPOINT": fmt.Sprintf("https://dynamodb.%s.amazonaws.com", d.appContext.Config.Remote.Region),
+	}
+	for _, table := range d.config.Config.Dynamodb.Tables {
+		result[table.GetEnvVarName()] = table.GetTableName(d.appContext.Config.Name)
+	}
+	return result
+}
+
+// GetDeploymentVariables returns a map from string to string of variables that a dependency Terraform module needs
+func (d *remoteDynamoDBDependency) GetDeploymentVariables() (map[string]string, error) {
+	return map[string]string{}, nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+)
+
+// defaultNatsAuthTokenVariable is the Terraform variable holding the auth token
+// when no 'auth-token-secret-name' is configured. It defaults to no authentication
+const defaultNatsAuthTokenVariable = "nats_auth_token"
+
+type remoteNatsDependency struct {
+	config     types.RemoteDependency
+	appContext *context.AppContext
+}
+
+// HasDockerConfig returns a boolean indicating if a docker-compose.yml entry should be generated for the dependency
+func (n *remoteNatsDependency) HasDockerConfig() bool {
+	return true
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (n *remoteNatsDependency) GetDockerConfig() (types.DockerConfig, error) {
+	return types.DockerConfig{
+		Image: fmt.Sprintf("nats:%s", n.config.Version),
+	}, nil
+}
+
+// GetServiceName returns the name used as the key of this dependency in docker-compose.yml
+func (n *remoteNatsDependency) GetServiceName() string {
+	return n.config.Name + n.config.Version
+}
+
+// GetDeploymentConfig returns NATS configuration needed in deployment
+func (n *remoteNatsDependency) GetDeploymentConfig() (map[string]string, error) {
+	authTokenVariable := n.config.Config.Nats.AuthTokenSecretName
+	if authTokenVariable == "" {
+		authTokenVariable = defaultNatsAuthTokenVariable
+	}
+	config := map[string]string{
+		"appName":           n.appContext.Config.Name,
+		"version":           n.config.Version,
+		"clusterSize":       n.config.Config.Nats.GetClusterSize(),
+		"authTokenVariable": authTokenVariable,
+	}
+	return config, nil
+}
+
+// GetDeploymentServiceEnvVariables returns configuration needed for each service in deployment
+func (n *remoteNatsDependency) GetDeploymentServiceEnvVariables(secrets types.Secrets) map[string]string {
+	result := map[string]string{
+		"NATS_HOST": fmt.Sprintf("nats.%s.local", n.appContext.Config.Name),
+	}
+	if n.config.Config.Nats.AuthTokenSecretName != "" {
+		result["NATS_AUTH_TOKEN"] = secrets[n.config.Config.Nats.AuthTokenSecretName]
+	}
+	return result
+}
+
+// GetDeploymentVariables returns a map from string to string of variables that a dependency Terraform module needs
+func (n *remoteNatsDependency) GetDeploymentVariables() (map[string]string, error) {
+	return map[string]string{}, nil
+}
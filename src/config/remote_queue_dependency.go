@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+)
+
+type remoteQueueDependency struct {
+	config     types.RemoteDependency
+	appContext *context.AppContext
+}
+
+// HasDockerConfig returns a boolean indicating if a docker-compose.yml entry should be generated for the dependency
+func (q *remoteQueueDependency) HasDockerConfig() bool {
+	return false
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (q *remoteQueueDependency) GetDockerConfig() (types.DockerConfig, error) {
+	return types.DockerConfig{}, nil
+}
+
+// GetServiceName returns the name used as the key of this dependency in docker-compose.yml
+func (q *remoteQueueDependency) GetServiceName() string {
+	return q.config.Name + q.config.Version
+}
+
+// GetDeploymentConfig returns configuration needed in deployment.
+// The queues and topics are generated from the dependency config directly, see terraform.generateQueueModules
+func (q *remoteQueueDependency) GetDeploymentConfig() (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// GetDeploymentServiceEnvVariables returns env vars for a service
+func (q *remoteQueueDependency) GetDeploymentServiceEnvVariables(secrets types.Secrets) map[string]string {
+	remoteConfig := q.appContext.Config.Remote
+	sqsEndpoint := fmt.Sprintf("https://sqs.%s.amazonaws.com", remoteConfig.Region)
+	result := map[string]string{
+		"SQS_ENDPOINT": sqsEndpoint,
+		"SNS_ENDPOINT": fmt.Sprintf("https://sns.%s.amazonaws.com", remoteConfig.Region),
+	}
+	for _, queue := range q.config.Config.Queue.Queues {
+		result[queue.GetURLEnvVarName()] = fmt.Sprintf("%s/%s/%s", sqsEndpoint, remoteConfig.AccountID, queue.GetQueueName(q.appContext.Config.Name))
+	}
+	for _, topic := range q.config.Config.Queue.Topics {
+		result[topic.GetArnEnvVarName()] = getTopicArn(topic.GetTopicName(q.appContext.Config.Name), remoteConfig.Region, remoteConfig.AccountID)
+	}
+	return result
+}
+
+// GetDeploymentVariables returns a map from string to string of variables that a dependency Terraform module needs
+func (q *remoteQueueDependency) GetDeploymentVariables() (map[string]string, error) {
+	return map[string]string{}, nil
+}
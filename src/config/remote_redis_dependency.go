@@ -0,0 +1,70 @@
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+)
+
+// maxRedisReplicationGroupIDLength is the maximum length ElastiCache allows for the IDs of replication groups
+const maxRedisReplicationGroupIDLength = 20
+
+type remoteRedisDependency struct {
+	config     types.RemoteDependency
+	appContext *context.AppContext
+}
+
+// HasDockerConfig returns a boolean indicating if a docker-compose.yml entry should be generated for the dependency
+func (r *remoteRedisDependency) HasDockerConfig() bool {
+	return false
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (r *remoteRedisDependency) GetDockerConfig() (types.DockerConfig, error) {
+	return types.DockerConfig{}, nil
+}
+
+// GetServiceName returns the name used as the key of this dependency in docker-compose.yml
+func (r *remoteRedisDependency) GetServiceName() string {
+	return r.config.Name + r.config.Version
+}
+
+// GetDeploymentConfig returns configuration needed in deployment
+func (r *remoteRedisDependency) GetDeploymentConfig() (map[string]string, error) {
+	config := map[string]string{
+		"appName":            r.appContext.Config.Name,
+		"name":               r.config.Config.Redis.GetName(),
+		"engineVersion":      r.config.Version,
+		"nodeType":           r.config.Config.Redis.GetNodeType(),
+		"numCacheClusters":   r.config.Config.Redis.GetNumCacheClusters(),
+		"replicationGroupID": r.getReplicationGroupID(),
+	}
+	return config, nil
+}
+
+// GetDeploymentServiceEnvVariables returns env vars for a service
+func (r *remoteRedisDependency) GetDeploymentServiceEnvVariables(secrets types.Secrets) map[string]string {
+	return map[string]string{
+		r.config.Config.Redis.GetURLEnvVarName(): fmt.Sprintf("redis://%s.%s.local:6379", r.config.Config.Redis.GetName(), r.appContext.Config.Name),
+	}
+}
+
+// GetDeploymentVariables returns a map from string to string of variables that a dependency Terraform module needs
+func (r *remoteRedisDependency) GetDeploymentVariables() (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// returns the ID of the replication group, prefixed with the name of the application.
+// IDs that are too long for ElastiCache are shortened and made unique by a hash of the full ID
+func (r *remoteRedisDependency) getReplicationGroupID() string {
+	id := fmt.Sprintf("%s-%s", r.appContext.Config.Name, r.config.Config.Redis.GetName())
+	if len(id) <= maxRedisReplicationGroupIDLength {
+		return id
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(id)))[:6]
+	prefix := strings.TrimRight(id[:maxRedisReplicationGroupIDLength-len(hash)-1], "-")
+	return fmt.Sprintf("%s-%s", prefix, hash)
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+)
+
+type remoteS3Dependency struct {
+	config     types.RemoteDependency
+	appContext *context.AppContext
+}
+
+// HasDockerConfig returns a boolean indicating if a docker-compose.yml entry should be generated for the dependency
+func (s *remoteS3Dependency) HasDockerConfig() bool {
+	return false
+}
+
+// GetDockerConfig returns docker configuration and an error if any
+func (s *remoteS3Dependency) GetDockerConfig() (types.DockerConfig, error) {
+	return types.DockerConfig{}, nil
+}
+
+// GetServiceName returns the name used as the key of this dependency in docker-compose.yml
+func (s *remoteS3Dependency) GetServiceName() string {
+	return s.config.Name + s.config.Version
+}
+
+// GetDeploymentConfig returns configuration needed in deployment.
+// The buckets are generated from the dependency config directly, see terraform.generateS3Modules
+func (s *remoteS3Dependency) GetDeploymentConfig() (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// GetDeploymentServiceEnvVariables returns env vars for a service.
+// Services access the buckets with the credentials of their task role
+func (s *remoteS3Dependency) GetDeploymentServiceEnvVariables(secrets types.Secrets) map[string]string {
+	result := map[string]string{
+		"S3_ENDPOINT": fmt.Sprintf("https://s3.%s.amazonaws.com", s.appContext.Config.Remote.Region),
+	}
+	for _, bucket := range s.config.Config.S3.Buckets {
+		result[bucket.GetEnvVarName()] = bucket.GetBucketName(s.appContext.Config.Name)
+	}
+	return result
+}
+
+// GetDeploymentVariables returns a map from string to string of variables that a dependency Terraform module needs
+func (s *remoteS3Dependency) GetDeploymentVariables() (map[string]string, error) {
+	return map[string]string{}, nil
+}
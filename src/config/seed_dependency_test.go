@@ -0,0 +1,127 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/Originate/exosphere/src/config"
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("init and seed of generic dependencies", func() {
+	var appContext *context.AppContext
+
+	BeforeEach(func() {
+		appDir, err := ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.MkdirAll(path.Join(appDir, "db", "init"), 0777)).To(Succeed())
+		Expect(ioutil.WriteFile(path.Join(appDir, "db", "schema.sql"), []byte{}, 0644)).To(Succeed())
+		appContext = &context.AppContext{
+			Location: appDir,
+			Config:   types.AppConfig{Name: "seed-app"},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(appContext.Location)).To(Succeed())
+	})
+
+	It("should mount init files into the init hook of the image", func() {
+		postgres := config.NewLocalAppDependency(types.LocalDependency{
+			Name:    "postgres",
+			Version: "9.6.4",
+			Config:  types.LocalDependencyConfig{Init: []string{"db/schema.sql"}},
+		}, appContext)
+		dockerConfig, err := postgres.GetDockerConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dockerConfig.Volumes).To(Equal([]string{"${APP_PATH}/db/schema.sql:/docker-entrypoint-initdb.d/schema.sql:ro"}))
+	})
+
+	It("should mount an init directory as the configured init path", func() {
+		mongo := config.NewLocalAppDependency(types.LocalDependency{
+			Name:    "mongo",
+			Version: "3.4.0",
+			Config: types.LocalDependencyConfig{
+				Persist:  []string{"/data/db"},
+				Init:     []string{"db/init/"},
+				InitPath: "/init",
+			},
+		}, appContext)
+		dockerConfig, err := mongo.GetDockerConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dockerConfig.Volumes).To(Equal([]string{"mongo__data_db:/data/db", "${APP_PATH}/db/init:/init:ro"}))
+	})
+
+	It("should return an error if an init file does not exist", func() {
+		postgres := config.NewLocalAppDependency(types.LocalDependency{
+			Name:    "postgres",
+			Version: "9.6.4",
+			Config:  types.LocalDependencyConfig{Init: []string{"db/missing.sql"}},
+		}, appContext)
+		_, err := postgres.GetDockerConfig()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("cannot find 'db/missing.sql' of 'config.init' of dependency 'postgres'"))
+	})
+
+	It("should return an error if an init directory is combined with other paths", func() {
+		postgres := config.NewLocalAppDependency(types.LocalDependency{
+			Name:    "postgres",
+			Version: "9.6.4",
+			Config:  types.LocalDependencyConfig{Init: []string{"db/init", "db/schema.sql"}},
+		}, appContext)
+		_, err := postgres.GetDockerConfig()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("'config.init' of dependency 'postgres' must contain either a single directory or files"))
+	})
+
+	It("should run the seed commands once for persisted data", func() {
+		mongo := config.NewLocalAppDependency(types.LocalDependency{
+			Name:    "mongo",
+			Version: "3.4.0",
+			Config: types.LocalDependencyConfig{
+				Persist: []string{"/data/db"},
+				Seed:    []string{"mongoimport --host $MONGO --db app --collection users --file db/users.json"},
+			},
+		}, appContext)
+		dockerConfigs, err := config.GetLocalAppDependencyDockerConfigs(mongo)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dockerConfigs["mongo3.4.0-seed"]).To(Equal(types.DockerConfig{
+			Image: "mongo:3.4.0",
+			Entrypoint: []string{"sh", "-c", `set -e
+cd /app
+if [ -f /exosphere-seed/.exosphere-seeded ]; then exit 0; fi
+mongoimport --host $$MONGO --db app --collection users --file db/users.json
+touch /exosphere-seed/.exosphere-seeded`},
+			ContainerName: "mongo3.4.0-seed",
+			Volumes:       []string{"${APP_PATH}:/app:ro", "mongo__data_db:/exosphere-seed"},
+			Environment:   map[string]string{"MONGO": "mongo3.4.0"},
+			DependsOn:     []string{"mongo3.4.0"},
+			Restart:       "on-failure",
+		}))
+		Expect(mongo.GetVolumeNames()).To(Equal([]string{"mongo__data_db"}))
+	})
+
+	It("should seed data that is not persisted every time", func() {
+		postgres := config.NewLocalAppDependency(types.LocalDependency{
+			Name:    "postgres",
+			Version: "9.6.4",
+			Config:  types.LocalDependencyConfig{Seed: []string{"psql -h postgres9.6.4 -U postgres -f db/seed.sql"}},
+		}, appContext)
+		dockerConfigs, err := config.GetLocalAppDependencyDockerConfigs(postgres)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dockerConfigs["postgres9.6.4-seed"].Entrypoint).To(Equal([]string{"sh", "-c", "set -e\ncd /app\npsql -h postgres9.6.4 -U postgres -f db/seed.sql"}))
+		Expect(dockerConfigs["postgres9.6.4-seed"].Volumes).To(Equal([]string{"${APP_PATH}:/app:ro"}))
+		Expect(postgres.GetVolumeNames()).To(BeEmpty())
+	})
+
+	It("should not add a seed container without seed commands", func() {
+		postgres := config.NewLocalAppDependency(types.LocalDependency{Name: "postgres", Version: "9.6.4"}, appContext)
+		dockerConfigs, err := config.GetLocalAppDependencyDockerConfigs(postgres)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dockerConfigs).To(HaveLen(1))
+	})
+})
@@ -12,6 +12,7 @@ func GetBuiltLocalServiceDependencies(serviceConfig types.ServiceConfig, appCont
 		builtDependency := NewLocalAppDependency(dependency, appContext)
 		result[dependency.Name] = builtDependency
 	}
+	addLocalRdsDependencies(result, getServiceOnlyRemoteDependencies(serviceConfig, appContext), appContext)
 	return result
 }
 
@@ -22,6 +23,7 @@ func GetBuiltServiceDevelopmentDependencies(serviceConfig types.ServiceConfig, a
 		builtDependency := NewLocalAppDependency(dependency, appContext)
 		result[dependency.Name] = builtDependency
 	}
+	addLocalRdsDependencies(result, getServiceOnlyRemoteDependencies(serviceConfig, appContext), appContext)
 	return result
 }
 
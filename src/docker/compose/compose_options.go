@@ -12,5 +12,6 @@ type CommandOptions struct {
 	ImageNames            []string
 	Writer                io.Writer
 	AbortOnExit           bool
+	ExitCodeFrom          string
 	Build                 bool
 }
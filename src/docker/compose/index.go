@@ -18,6 +18,13 @@ func KillContainers(opts CommandOptions) error {
 	return util.RunAndPipe(opts.DockerComposeDir, opts.Env, opts.Writer, cmd...)
 }
 
+// PrintLogs prints the logs of the containers in opts.ImageNames, or of all containers if opts.ImageNames is empty
+func PrintLogs(opts CommandOptions) error {
+	cmd := []string{"docker-compose", "--file", opts.DockerComposeFileName, "logs"}
+	cmd = append(cmd, opts.ImageNames...)
+	return util.RunAndPipe(opts.DockerComposeDir, opts.Env, opts.Writer, cmd...)
+}
+
 // PullImages pulls images in opts.ImageNames, or pulls all images if opts.ImageNames is empty
 func PullImages(opts CommandOptions) error {
 	cmd := []string{"docker-compose", "--file", opts.DockerComposeFileName, "pull"}
@@ -31,9 +38,32 @@ func RunImages(opts CommandOptions) error {
 	if opts.AbortOnExit {
 		cmd = append(cmd, "--abort-on-container-exit")
 	}
+	if opts.ExitCodeFrom != "" {
+		cmd = append(cmd, "--exit-code-from", opts.ExitCodeFrom)
+	}
 	if opts.Build {
 		cmd = append(cmd, "--build")
 	}
 	cmd = append(cmd, opts.ImageNames...)
 	return util.RunAndPipe(opts.DockerComposeDir, opts.Env, opts.Writer, cmd...)
 }
+
+// RunContainer runs the given command in a one-off container of the given service,
+// starting the services it depends on first, and removes the container afterwards
+func RunContainer(opts CommandOptions, serviceName string, command []string) error {
+	cmd := []string{"docker-compose", "--file", opts.DockerComposeFileName, "run", "--rm", serviceName}
+	cmd = append(cmd, command...)
+	return util.RunAndPipe(opts.DockerComposeDir, opts.Env, opts.Writer, cmd...)
+}
+
+// ExecContainer runs the given command in the running container of the given service,
+// allocating a pseudo-TTY if tty is true
+func ExecContainer(opts CommandOptions, serviceName string, command []string, tty bool) error {
+	cmd := []string{"docker-compose", "--file", opts.DockerComposeFileName, "exec"}
+	if !tty {
+		cmd = append(cmd, "-T")
+	}
+	cmd = append(cmd, serviceName)
+	cmd = append(cmd, command...)
+	return util.RunAndPipe(opts.DockerComposeDir, opts.Env, opts.Writer, cmd...)
+}
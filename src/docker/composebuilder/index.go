@@ -2,7 +2,9 @@ package composebuilder
 
 import (
 	"fmt"
+	"path"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/Originate/exosphere/src/config"
@@ -10,6 +12,9 @@ import (
 	"github.com/Originate/exosphere/src/types/endpoints"
 )
 
+// E2EServiceName is the docker-compose service name of the end-to-end tests container
+const E2EServiceName = "e2e-tests"
+
 // GetDockerComposeProjectName creates a docker compose project name the same way docker-compose mutates the COMPOSE_PROJECT_NAME env var
 func GetDockerComposeProjectName(appName string) string {
 	reg := regexp.MustCompile("[^a-zA-Z0-9]")
@@ -22,6 +27,11 @@ func GetTestDockerComposeProjectName(appName string) string {
 	return GetDockerComposeProjectName(fmt.Sprintf("%stests", appName))
 }
 
+// GetE2EDockerComposeProjectName creates a docker compose project name for end-to-end tests
+func GetE2EDockerComposeProjectName(appName string) string {
+	return GetDockerComposeProjectName(fmt.Sprintf("%se2e", appName))
+}
+
 // GetApplicationDockerCompose returns the docker compose for a application
 func GetApplicationDockerCompose(options ApplicationOptions) (*types.DockerCompose, error) {
 	dependencyDockerCompose, err := getDependenciesDockerConfigs(options)
@@ -32,7 +42,40 @@ func GetApplicationDockerCompose(options ApplicationOptions) (*types.DockerCompo
 	if err != nil {
 		return nil, err
 	}
-	return dependencyDockerCompose.Merge(serviceDockerCompose), nil
+	result := dependencyDockerCompose.Merge(serviceDockerCompose)
+	if options.BuildMode.Environment == types.BuildModeEnvironmentE2E {
+		result = result.Merge(getE2EDockerCompose(options, result))
+	}
+	return result, nil
+}
+
+// getE2EDockerCompose returns the docker compose for the end-to-end tests container
+// which depends on all other containers of the application
+func getE2EDockerCompose(options ApplicationOptions, applicationDockerCompose *types.DockerCompose) *types.DockerCompose {
+	result := types.NewDockerCompose()
+	e2eConfig := options.AppContext.Config.Local.E2E
+	dependsOn := []string{}
+	for serviceName := range applicationDockerCompose.Services {
+		dependsOn = append(dependsOn, serviceName)
+	}
+	sort.Strings(dependsOn)
+	serviceEndpoints := endpoints.NewServiceEndpoints(options.AppContext, options.BuildMode)
+	dockerConfig := types.DockerConfig{
+		ContainerName: E2EServiceName,
+		Command:       e2eConfig.Command,
+		Environment:   serviceEndpoints.GetE2EEndpointEnvVars(),
+		DependsOn:     dependsOn,
+	}
+	if e2eConfig.Location != "" {
+		dockerConfig.Build = map[string]string{
+			"context":    path.Join("${APP_PATH}", e2eConfig.Location),
+			"dockerfile": "Dockerfile",
+		}
+	} else {
+		dockerConfig.Image = e2eConfig.DockerImage
+	}
+	result.Services[E2EServiceName] = dockerConfig
+	return result
 }
 
 // getDependenciesDockerConfigs returns the docker configs for all the application dependencies
@@ -52,11 +95,11 @@ func getDependenciesDockerConfigs(options ApplicationOptions) (*types.DockerComp
 	} else {
 		appDependencies := config.GetBuiltLocalAppDependencies(options.AppContext)
 		for _, builtDependency := range appDependencies {
-			dockerConfig, err := builtDependency.GetDockerConfig()
+			dockerConfigs, err := config.GetLocalAppDependencyDockerConfigs(builtDependency)
 			if err != nil {
 				return result, err
 			}
-			result.Services[builtDependency.GetContainerName()] = dockerConfig
+			result.Services = result.Services.Merge(dockerConfigs)
 			for _, name := range builtDependency.GetVolumeNames() {
 				result.Volumes[name] = nil
 			}
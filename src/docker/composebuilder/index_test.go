@@ -141,6 +141,39 @@ var _ = Describe("composebuilder", func() {
 		})
 	})
 
+	var _ = Describe("GetApplicationDockerCompose for end-to-end tests", func() {
+		It("should include the end-to-end tests container", func() {
+			appDir := helpers.GetTestApplicationDir("e2e")
+			appContext, err := context.GetAppContext(appDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			dockerCompose, err := composebuilder.GetApplicationDockerCompose(composebuilder.ApplicationOptions{
+				AppContext: appContext,
+				BuildMode: types.BuildMode{
+					Type:        types.BuildModeTypeLocal,
+					Environment: types.BuildModeEnvironmentE2E,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dockerCompose.Services[composebuilder.E2EServiceName]).To(Equal(types.DockerConfig{
+				Build: map[string]string{
+					"context":    "${APP_PATH}/e2e-tests",
+					"dockerfile": "Dockerfile",
+				},
+				ContainerName: "e2e-tests",
+				Command:       "node test.js",
+				Environment: map[string]string{
+					"WEB_EXTERNAL_ORIGIN": "http://web:8080",
+				},
+				DependsOn: []string{"exocom0.26.1", "web"},
+			}))
+
+			By("should run the services in development mode")
+			Expect(dockerCompose.Services["web"].Command).To(Equal("node server.js"))
+			Expect(dockerCompose.Services["web"].Ports).To(Equal([]string{"3000:8080"}))
+		})
+	})
+
 	var _ = Describe("compiles the docker compose project name properly", func() {
 		expected := "spacetweet123"
 
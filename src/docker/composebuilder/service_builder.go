@@ -81,6 +81,8 @@ func (d *ServiceComposeBuilder) getDockerPorts() []string {
 	case types.BuildModeEnvironmentProduction:
 		fallthrough
 	case types.BuildModeEnvironmentDevelopment:
+		fallthrough
+	case types.BuildModeEnvironmentE2E:
 		return d.ServiceEndpoints.GetServicePortMappings(d.Role)
 	default:
 		return []string{}
@@ -94,8 +96,9 @@ func (d *ServiceComposeBuilder) getDockerVolumes() []string {
 	return []string{d.getServiceFilePath() + ":" + "/mnt"}
 }
 
+// scheduled services exit after each run, so they are never restarted
 func (d *ServiceComposeBuilder) getRestartPolicy() string {
-	if d.Mode.Environment != types.BuildModeEnvironmentTest {
+	if d.Mode.Environment != types.BuildModeEnvironmentTest && d.ServiceConfig.Type != types.ServiceTypeScheduled {
 		return "on-failure"
 	}
 	return ""
@@ -166,13 +169,19 @@ func (d *ServiceComposeBuilder) getDockerEnvVars() map[string]string {
 	return result
 }
 
+// returns the containers of the dependencies of the service, including their init containers
+// so that the service starts after the tables, buckets, queues and seeds are being set up
 func (d *ServiceComposeBuilder) getServiceDependsOn() []string {
 	result := []string{}
 	for _, builtDependency := range d.BuiltAppDependencies {
-		result = append(result, builtDependency.GetContainerName())
+		result = append(result, config.GetLocalAppDependencyContainerNames(builtDependency)...)
 	}
 	for _, builtDependency := range d.BuiltServiceDependencies {
-		result = append(result, builtDependency.GetContainerName())
+		for _, containerName := range config.GetLocalAppDependencyContainerNames(builtDependency) {
+			if !util.DoesStringArrayContain(result, containerName) {
+				result = append(result, containerName)
+			}
+		}
 	}
 	sort.Strings(result)
 	return result
@@ -182,11 +191,11 @@ func (d *ServiceComposeBuilder) getServiceDependsOn() []string {
 func (d *ServiceComposeBuilder) getServiceDependenciesDockerCompose() (*types.DockerCompose, error) {
 	result := types.NewDockerCompose()
 	for _, builtDependency := range d.BuiltServiceDependencies {
-		dockerConfig, err := builtDependency.GetDockerConfig()
+		dockerConfigs, err := config.GetLocalAppDependencyDockerConfigs(builtDependency)
 		if err != nil {
 			return result, err
 		}
-		result.Services[builtDependency.GetContainerName()] = dockerConfig
+		result.Services = result.Services.Merge(dockerConfigs)
 		for _, name := range builtDependency.GetVolumeNames() {
 			result.Volumes[name] = nil
 		}
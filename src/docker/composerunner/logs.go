@@ -0,0 +1,21 @@
+package composerunner
+
+import (
+	"fmt"
+
+	"github.com/Originate/exosphere/src/docker/compose"
+)
+
+// PrintLogs prints the logs of the given service based on the given options
+func PrintLogs(options RunOptions, serviceName string) error {
+	return compose.PrintLogs(compose.CommandOptions{
+		DockerComposeDir:      options.DockerComposeDir,
+		DockerComposeFileName: options.DockerComposeFileName,
+		Writer:                options.Writer,
+		Env: []string{
+			fmt.Sprintf("COMPOSE_PROJECT_NAME=%s", options.DockerComposeProjectName),
+			fmt.Sprintf("APP_PATH=%s", options.AppDir),
+		},
+		ImageNames: []string{serviceName},
+	})
+}
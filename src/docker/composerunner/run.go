@@ -25,6 +25,24 @@ func Run(options RunOptions) error {
 // RunService runs a service based on the given options
 func RunService(options RunOptions, serviceName string) error {
 	err := compose.RunImages(compose.CommandOptions{
+		DockerComposeDir:      options.DockerComposeDir,
+		DockerComposeFileName: options.DockerComposeFileName,
+		Writer:                options.Writer,
+		Env: []string{
+			fmt.Sprintf("COMPOSE_PROJECT_NAME=%s", options.DockerComposeProjectName),
+			fmt.Sprintf("APP_PATH=%s", options.AppDir),
+		},
+		AbortOnExit:  options.AbortOnExit,
+		ExitCodeFrom: options.ExitCodeFrom,
+		Build:        true,
+		ImageNames:   []string{serviceName},
+	})
+	return err
+}
+
+// RunServices runs the given services and the services they depend on based on the given options
+func RunServices(options RunOptions, serviceNames []string) error {
+	return compose.RunImages(compose.CommandOptions{
 		DockerComposeDir:      options.DockerComposeDir,
 		DockerComposeFileName: options.DockerComposeFileName,
 		Writer:                options.Writer,
@@ -34,7 +52,46 @@ func RunService(options RunOptions, serviceName string) error {
 		},
 		AbortOnExit: options.AbortOnExit,
 		Build:       true,
-		ImageNames:  []string{serviceName},
+		ImageNames:  serviceNames,
 	})
-	return err
+}
+
+// BuildServices builds the images of the given services based on the given options
+func BuildServices(options RunOptions, serviceNames []string) error {
+	return compose.BuildImages(compose.CommandOptions{
+		DockerComposeDir:      options.DockerComposeDir,
+		DockerComposeFileName: options.DockerComposeFileName,
+		Writer:                options.Writer,
+		Env: []string{
+			fmt.Sprintf("COMPOSE_PROJECT_NAME=%s", options.DockerComposeProjectName),
+			fmt.Sprintf("APP_PATH=%s", options.AppDir),
+		},
+		ImageNames: serviceNames,
+	})
+}
+
+// RunCommand runs the given command in a one-off container of the given service based on the given options
+func RunCommand(options RunOptions, serviceName string, command []string) error {
+	return compose.RunContainer(compose.CommandOptions{
+		DockerComposeDir:      options.DockerComposeDir,
+		DockerComposeFileName: options.DockerComposeFileName,
+		Writer:                options.Writer,
+		Env: []string{
+			fmt.Sprintf("COMPOSE_PROJECT_NAME=%s", options.DockerComposeProjectName),
+			fmt.Sprintf("APP_PATH=%s", options.AppDir),
+		},
+	}, serviceName, command)
+}
+
+// ExecCommand runs the given command in the running container of the given service based on the given options
+func ExecCommand(options RunOptions, serviceName string, command []string, tty bool) error {
+	return compose.ExecContainer(compose.CommandOptions{
+		DockerComposeDir:      options.DockerComposeDir,
+		DockerComposeFileName: options.DockerComposeFileName,
+		Writer:                options.Writer,
+		Env: []string{
+			fmt.Sprintf("COMPOSE_PROJECT_NAME=%s", options.DockerComposeProjectName),
+			fmt.Sprintf("APP_PATH=%s", options.AppDir),
+		},
+	}, serviceName, command, tty)
 }
@@ -13,4 +13,5 @@ type RunOptions struct {
 	DockerServiceName        string
 	Writer                   io.Writer
 	AbortOnExit              bool
+	ExitCodeFrom             string
 }
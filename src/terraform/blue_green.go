@@ -0,0 +1,63 @@
+package terraform
+
+import "fmt"
+
+// The colors of the two ECS services running a service deployed with the blue-green or canary strategy.
+// The live color serves the traffic, the idle one runs the new version before the traffic is shifted to it
+const (
+	ColorBlue  = "blue"
+	ColorGreen = "green"
+)
+
+// The ports of the listeners of the ALB of a service deployed with the blue-green or canary strategy.
+// The listener forwards the traffic to the live color, the test listener to the idle one
+const (
+	ListenerPort     = 443
+	TestListenerPort = 8443
+)
+
+// Colors are the colors of a service deployed with the blue-green or canary strategy
+var Colors = []string{ColorBlue, ColorGreen}
+
+// GetOtherColor returns the color that is not the given one
+func GetOtherColor(color string) string {
+	if color == ColorBlue {
+		return ColorGreen
+	}
+	return ColorBlue
+}
+
+// GetColorImageKey returns the key of the image of the given color in the images map passed to Terraform commands.
+// The color images default to the image of the service
+func GetColorImageKey(serviceRole, color string) string {
+	return fmt.Sprintf("%s_%s", serviceRole, color)
+}
+
+// GetColorServiceName returns the name of the ECS service of the given color
+func GetColorServiceName(serviceRole, color string) string {
+	return fmt.Sprintf("%s-%s", serviceRole, color)
+}
+
+// GetLoadBalancerName returns the name of the ALB of a public service,
+// mirroring the substr() call the Terraform modules use to fit it into 32 characters
+func GetLoadBalancerName(serviceRole string) string {
+	if len(serviceRole) <= 32 {
+		return serviceRole
+	}
+	return serviceRole[:31]
+}
+
+// GetColorTargetGroupName returns the name of the ALB target group of the given color
+func GetColorTargetGroupName(serviceRole, color string) string {
+	if len(serviceRole) > 26 {
+		serviceRole = serviceRole[:26]
+	}
+	return fmt.Sprintf("%s-%s", serviceRole, color)
+}
+
+func getColorImage(serviceRole, color string, imagesMap map[string]string) string {
+	if image, ok := imagesMap[GetColorImageKey(serviceRole, color)]; ok {
+		return image
+	}
+	return imagesMap[serviceRole]
+}
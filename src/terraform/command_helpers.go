@@ -1,8 +1,11 @@
 package terraform
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/Originate/exosphere/src/config"
 	"github.com/Originate/exosphere/src/types"
@@ -30,6 +33,21 @@ func CompileVarFlags(deployConfig deploy.Config, secrets types.Secrets, imagesMa
 	return append(vars, "-var", fmt.Sprintf("aws_profile=%s", deployConfig.AwsConfig.Profile)), nil
 }
 
+// GetVarsHash returns a hash of the variables passed into Terraform commands,
+// which identifies the configuration a deploy applied without revealing the secrets
+func GetVarsHash(deployConfig deploy.Config, secrets types.Secrets, imagesMap map[string]string) (string, error) {
+	varFlags, err := CompileVarFlags(deployConfig, secrets, imagesMap)
+	if err != nil {
+		return "", err
+	}
+	vars := []string{}
+	for i := 1; i < len(varFlags); i += 2 {
+		vars = append(vars, varFlags[i])
+	}
+	sort.Strings(vars)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(vars, "\n")))), nil
+}
+
 // compile all secrets into var flags
 func compileSecrets(secrets types.Secrets) []string {
 	vars := []string{}
@@ -43,7 +61,15 @@ func compileSecrets(secrets types.Secrets) []string {
 func compileDockerImageVars(deployConfig deploy.Config, imagesMap map[string]string) []string {
 	vars := []string{}
 	for serviceRole, serviceContext := range deployConfig.AppContext.ServiceContexts {
-		vars = append(vars, "-var", fmt.Sprintf("%s_docker_image=%s", serviceRole, imagesMap[serviceRole]))
+		// services that shift traffic run the images of their colors, only their migrations need the new image
+		if !serviceContext.Config.Remote.Deploy.ShiftsTraffic() || serviceContext.Config.Migrations.IsConfigured() {
+			vars = append(vars, "-var", fmt.Sprintf("%s_docker_image=%s", serviceRole, imagesMap[serviceRole]))
+		}
+		if serviceContext.Config.Remote.Deploy.ShiftsTraffic() {
+			for _, color := range Colors {
+				vars = append(vars, "-var", fmt.Sprintf("%s_%s_docker_image=%s", serviceRole, color, getColorImage(serviceRole, color, imagesMap)))
+			}
+		}
 		for _, dependency := range serviceContext.Config.Remote.Dependencies {
 			vars = append(vars, "-var", fmt.Sprintf("%s_docker_image=%s", dependency.Name, imagesMap[dependency.Name]))
 		}
@@ -110,13 +136,19 @@ func compileServiceEnvVars(deployConfig deploy.Config, secrets types.Secrets) ([
 
 // convert an env var key pair in the format of a task definition
 // marshals a map[string]string object twice so that it can be escaped properly
-// and passed as a command line flag, then properly decoded in Terraform
+// and passed as a command line flag, then properly decoded in Terraform.
+// The variables are sorted by name so that the result only changes with them
 func createEnvVarString(envVars map[string]string) (string, error) {
+	keys := []string{}
+	for k := range envVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
 	terraformEnvVars := []map[string]string{}
-	for k, v := range envVars {
+	for _, k := range keys {
 		envVarPair := map[string]string{
 			"name":  k,
-			"value": v,
+			"value": envVars[k],
 		}
 		terraformEnvVars = append(terraformEnvVars, envVarPair)
 	}
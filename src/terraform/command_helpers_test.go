@@ -102,6 +102,18 @@ var _ = Describe("CompileVarFlags", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(service1ExpectedValue).To(ConsistOf(actualValue))
 		})
+
+		It("should hash the var flags deterministically", func() {
+			hash, err := terraform.GetVarsHash(deployConfig, secrets, imageMap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).To(HaveLen(64))
+			for i := 0; i < 10; i++ {
+				Expect(terraform.GetVarsHash(deployConfig, secrets, imageMap)).To(Equal(hash))
+			}
+			otherHash, err := terraform.GetVarsHash(deployConfig, secrets, map[string]string{"service1": "other-image"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherHash).NotTo(Equal(hash))
+		})
 	})
 
 	var _ = Describe("with exocom dependency", func() {
@@ -2,7 +2,6 @@ package terraform
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/Originate/exosphere/src/docker/tools"
 	"github.com/Originate/exosphere/src/types"
@@ -26,13 +25,18 @@ func RunInit(deployConfig deploy.Config) error {
 	})
 }
 
-// RunApply runs the 'terraform apply' command and passes variables in as command flags
-func RunApply(deployConfig deploy.Config, secrets types.Secrets, imagesMap map[string]string, autoApprove bool) error {
+// RunApply runs the 'terraform apply' command for the given targets, or for all resources if none are given,
+// and passes variables in as command flags. It returns an error if the changes are not applied,
+// for example because they were not approved
+func RunApply(deployConfig deploy.Config, secrets types.Secrets, imagesMap map[string]string, autoApprove bool, targets ...string) error {
 	vars, err := CompileVarFlags(deployConfig, secrets, imagesMap)
 	if err != nil {
 		return err
 	}
 	command := append([]string{"apply"}, vars...)
+	for _, target := range targets {
+		command = append(command, fmt.Sprintf("-target=%s", target))
+	}
 	if autoApprove {
 		command = append(command, "-auto-approve")
 	}
@@ -40,7 +44,7 @@ func RunApply(deployConfig deploy.Config, secrets types.Secrets, imagesMap map[s
 	if err != nil {
 		return err
 	}
-	err = tools.RunInDockerContainer(tools.RunConfig{
+	return tools.RunInDockerContainer(tools.RunConfig{
 		Volumes:     []string{fmt.Sprintf("%s:/app", deployConfig.TerraformDir), fmt.Sprintf("%s/.aws:/root/.aws", homeDir)},
 		Interactive: true,
 		WorkingDir:  "/app",
@@ -48,8 +52,4 @@ func RunApply(deployConfig deploy.Config, secrets types.Secrets, imagesMap map[s
 		Command:     command,
 		Writer:      deployConfig.Writer,
 	})
-	if err != nil && strings.Contains(err.Error(), "exit status") {
-		return nil
-	}
-	return err
 }
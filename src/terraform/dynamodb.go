@@ -0,0 +1,130 @@
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// the actions services are allowed to perform on the tables they declare
+var dynamoDBTableActions = []string{
+	"dynamodb:BatchGetItem",
+	"dynamodb:BatchWriteItem",
+	"dynamodb:DeleteItem",
+	"dynamodb:DescribeTable",
+	"dynamodb:GetItem",
+	"dynamodb:PutItem",
+	"dynamodb:Query",
+	"dynamodb:Scan",
+	"dynamodb:UpdateItem",
+}
+
+// generates the tables of all dynamodb dependencies and a policy for each service
+// that allows it to access the tables declared in application.yml and its own service.yml
+func generateDynamoDBModules(deployConfig deploy.Config) (string, error) {
+	appContext := deployConfig.AppContext
+	if _, ok := appContext.DependencyDefinitions["dynamodb"]; ok {
+		return "", nil
+	}
+	tables := map[string]types.DynamoDBTable{}
+	for _, dependency := range appContext.Config.Remote.Dependencies {
+		if dependency.Name == "dynamodb" {
+			for _, table := range dependency.Config.Dynamodb.Tables {
+				tables[table.Name] = table
+			}
+		}
+	}
+	serviceTableNames := map[string][]string{}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		for _, dependency := range getServiceRemoteDependencies(appContext, serviceRole, "dynamodb") {
+			for _, table := range dependency.Config.Dynamodb.Tables {
+				tables[table.Name] = table
+				if !util.DoesStringArrayContain(serviceTableNames[serviceRole], table.Name) {
+					serviceTableNames[serviceRole] = append(serviceTableNames[serviceRole], table.Name)
+				}
+			}
+		}
+	}
+	tableNames := []string{}
+	for tableName := range tables {
+		tableNames = append(tableNames, tableName)
+	}
+	sort.Strings(tableNames)
+	result := []string{}
+	for _, tableName := range tableNames {
+		table, err := generateDynamoDBTable(tables[tableName], appContext.Config.Name)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, table)
+	}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		if len(serviceTableNames[serviceRole]) == 0 {
+			continue
+		}
+		sort.Strings(serviceTableNames[serviceRole])
+		resources := []string{}
+		for _, tableName := range serviceTableNames[serviceRole] {
+			tableArn := fmt.Sprintf("${aws_dynamodb_table.%s.arn}", getDynamoDBTableResourceName(tableName))
+			resources = append(resources, tableArn, tableArn+"/index/*")
+		}
+		policy, err := generateTaskRolePolicy(appContext, serviceRole, "dynamodb", []iamPolicyStatement{
+			{Effect: "Allow", Action: dynamoDBTableActions, Resource: resources},
+		})
+		if err != nil {
+			return "", err
+		}
+		result = append(result, policy)
+	}
+	return strings.Join(result, "\n"), nil
+}
+
+func generateDynamoDBTable(table types.DynamoDBTable, appName string) (string, error) {
+	attributeNames := []string{}
+	for attributeName := range table.Attributes {
+		attributeNames = append(attributeNames, attributeName)
+	}
+	sort.Strings(attributeNames)
+	attributes := []map[string]string{}
+	for _, attributeName := range attributeNames {
+		attributes = append(attributes, map[string]string{
+			"name": attributeName,
+			"type": table.Attributes[attributeName],
+		})
+	}
+	indexes := []map[string]string{}
+	for _, index := range table.GlobalSecondaryIndexes {
+		indexVars := map[string]string{
+			"hashKey":        index.HashKey,
+			"name":           index.Name,
+			"projectionType": index.GetProjectionType(),
+			"readCapacity":   index.GetReadCapacity(),
+			"writeCapacity":  index.GetWriteCapacity(),
+		}
+		if index.RangeKey != "" {
+			indexVars["indexRangeKey"] = index.RangeKey
+		}
+		indexes = append(indexes, indexVars)
+	}
+	context := map[string]interface{}{
+		"attributes":    attributes,
+		"hashKey":       table.HashKey,
+		"indexes":       indexes,
+		"readCapacity":  table.GetReadCapacity(),
+		"resourceName":  getDynamoDBTableResourceName(table.Name),
+		"tableName":     table.GetTableName(appName),
+		"writeCapacity": table.GetWriteCapacity(),
+	}
+	if table.RangeKey != "" {
+		context["rangeKey"] = table.RangeKey
+	}
+	return renderTemplateWithSections("dynamodb_table.tf", context)
+}
+
+func getDynamoDBTableResourceName(tableName string) string {
+	return util.ToSnake(tableName)
+}
@@ -3,6 +3,8 @@ package terraform
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Originate/exosphere/src/config"
@@ -40,6 +42,14 @@ func Generate(deployConfig deploy.Config) (string, error) {
 	}
 	fileData = append(fileData, moduleData)
 
+	moduleData, err = generateSharedAlbModule(deployConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to generate the shared ALB")
+	}
+	if moduleData != "" {
+		fileData = append(fileData, moduleData)
+	}
+
 	moduleData, err = generateServiceModules(deployConfig)
 	if err != nil {
 		return "", errors.Wrap(err, "Failed to generate service Terraform modules")
@@ -52,6 +62,30 @@ func Generate(deployConfig deploy.Config) (string, error) {
 	}
 	fileData = append(fileData, moduleData)
 
+	moduleData, err = generateDynamoDBModules(deployConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to generate DynamoDB tables")
+	}
+	if moduleData != "" {
+		fileData = append(fileData, moduleData)
+	}
+
+	moduleData, err = generateS3Modules(deployConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to generate S3 buckets")
+	}
+	if moduleData != "" {
+		fileData = append(fileData, moduleData)
+	}
+
+	moduleData, err = generateQueueModules(deployConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to generate SQS queues and SNS topics")
+	}
+	if moduleData != "" {
+		fileData = append(fileData, moduleData)
+	}
+
 	return strings.Join(fileData, "\n"), nil
 }
 
@@ -77,12 +111,15 @@ func GenerateCheck(deployConfig deploy.Config) error {
 
 func generateAwsModule(deployConfig deploy.Config) (string, error) {
 	varsMap := map[string]string{
-		"appName":     deployConfig.AppContext.Config.Name,
-		"stateBucket": deployConfig.AwsConfig.TerraformStateBucket,
-		"lockTable":   deployConfig.AwsConfig.TerraformLockTable,
-		"region":      deployConfig.AwsConfig.Region,
-		"accountID":   deployConfig.AwsConfig.AccountID,
-		"url":         deployConfig.AppContext.Config.Remote.URL,
+		"appName":             deployConfig.AppContext.Config.Name,
+		"stateBucket":         deployConfig.AwsConfig.TerraformStateBucket,
+		"lockTable":           deployConfig.AwsConfig.TerraformLockTable,
+		"region":              deployConfig.AwsConfig.Region,
+		"accountID":           deployConfig.AwsConfig.AccountID,
+		"url":                 deployConfig.AppContext.Config.Remote.URL,
+		"instanceType":        deployConfig.AppContext.Config.Remote.Cluster.GetInstanceType(),
+		"clusterMinSize":      strconv.Itoa(deployConfig.AppContext.Config.Remote.Cluster.GetMinSize()),
+		"clusterMaxSize":      strconv.Itoa(deployConfig.AppContext.Config.Remote.Cluster.GetMaxSize()),
 		"terraformCommitHash": TerraformModulesRef,
 		"terraformVersion":    TerraformVersion,
 	}
@@ -93,17 +130,39 @@ func generateServiceModules(deployConfig deploy.Config) (string, error) {
 	serviceModules := []string{}
 	for _, serviceRole := range deployConfig.AppContext.Config.GetSortedServiceRoles() {
 		serviceConfig := deployConfig.AppContext.ServiceContexts[serviceRole].Config
-		module, err := generateServiceModule(serviceRole, deployConfig, serviceConfig, fmt.Sprintf("%s_service.tf", serviceConfig.Type))
+		module, err := generateServiceModule(serviceRole, deployConfig, serviceConfig, getServiceTemplateName(serviceConfig))
 		if err != nil {
 			return "", err
 		}
 		serviceModules = append(serviceModules, module)
+		if hasScalableTarget(serviceConfig) {
+			module, err = generateScalingResources(serviceRole, deployConfig)
+			if err != nil {
+				return "", err
+			}
+			serviceModules = append(serviceModules, module)
+		}
+		if len(serviceConfig.Remote.Permissions) > 0 {
+			module, err = generatePermissionsPolicy(serviceRole, deployConfig)
+			if err != nil {
+				return "", err
+			}
+			serviceModules = append(serviceModules, module)
+		}
+		if serviceConfig.Migrations.IsConfigured() {
+			module, err = generateMigrationsModule(serviceRole, deployConfig)
+			if err != nil {
+				return "", err
+			}
+			serviceModules = append(serviceModules, module)
+		}
 	}
 	return strings.Join(serviceModules, "\n"), nil
 }
 
 func generateServiceModule(serviceRole string, deployConfig deploy.Config, serviceConfig types.ServiceConfig, filename string) (string, error) {
 	varsMap := map[string]string{
+		"appName":             deployConfig.AppContext.Config.Name,
 		"serviceRole":         serviceRole,
 		"publicPort":          serviceConfig.Production.Port,
 		"cpu":                 serviceConfig.Remote.CPU,
@@ -111,11 +170,45 @@ func generateServiceModule(serviceRole string, deployConfig deploy.Config, servi
 		"url":                 serviceConfig.Remote.URL,
 		"sslCertificateArn":   deployConfig.AwsConfig.SslCertificateArn,
 		"healthCheck":         serviceConfig.Remote.HealthCheck,
+		"desiredCount":        strconv.Itoa(serviceConfig.Remote.Scaling.GetDesired()),
 		"terraformCommitHash": TerraformModulesRef,
 	}
+	if serviceConfig.Type == types.ServiceTypeScheduled {
+		schedule, err := serviceConfig.GetCronSchedule()
+		if err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("Invalid schedule of %s", serviceRole))
+		}
+		varsMap["scheduleExpression"] = schedule.GetCloudWatchExpression()
+	}
+	if len(serviceConfig.Remote.Routes) > 0 {
+		addRouteVars(varsMap, serviceConfig.Remote.Routes, deployConfig.AppContext.Config.Remote.URL)
+	}
 	return RenderTemplates(filename, varsMap)
 }
 
+// returns whether Application Auto Scaling manages the number of tasks of the given service,
+// which its module leaves alone once the service exists. The deployer scales the colors
+// of services that shift traffic, and scheduled services run a single task on each run
+func hasScalableTarget(serviceConfig types.ServiceConfig) bool {
+	if serviceConfig.Type == types.ServiceTypeScheduled {
+		return false
+	}
+	return len(serviceConfig.Remote.Routes) > 0 || !serviceConfig.Remote.Deploy.ShiftsTraffic()
+}
+
+// returns the name of the template of the module of the given service. Services that shift traffic
+// to their new version run it next to the previous one, in two ECS services called colors.
+// Services with routes are reached through the shared ALB instead of their own
+func getServiceTemplateName(serviceConfig types.ServiceConfig) string {
+	if len(serviceConfig.Remote.Routes) > 0 {
+		return fmt.Sprintf("%s_service_routed.tf", serviceConfig.Type)
+	}
+	if serviceConfig.Remote.Deploy.ShiftsTraffic() {
+		return fmt.Sprintf("%s_service_blue_green.tf", serviceConfig.Type)
+	}
+	return fmt.Sprintf("%s_service.tf", serviceConfig.Type)
+}
+
 func generateDependencyModules(deployConfig deploy.Config) (string, error) {
 	dependencyModules := []string{}
 	for _, dependency := range deployConfig.AppContext.Config.Remote.Dependencies {
@@ -139,14 +232,68 @@ func generateDependencyModules(deployConfig deploy.Config) (string, error) {
 }
 
 func generateDependencyModule(dependency types.RemoteDependency, deployConfig deploy.Config) (string, error) {
+	if definition, ok := deployConfig.AppContext.DependencyDefinitions[dependency.Name]; ok {
+		modules, err := config.GetDefinedDependencyModules(dependency, definition, deployConfig.AppContext)
+		if err != nil {
+			return "", err
+		}
+		return generateDefinedDependencyModules(definition, modules)
+	}
 	deploymentConfig, err := config.NewRemoteAppDependency(dependency, deployConfig.AppContext).GetDeploymentConfig()
 	if err != nil {
 		return "", err
 	}
+	switch dependency.Name {
+	case "dynamodb", "s3", "queue":
+		// generated at once for all services by generateDynamoDBModules, generateS3Modules and generateQueueModules
+		return "", nil
+	}
 	deploymentConfig["terraformCommitHash"] = TerraformModulesRef
 	return RenderTemplates(fmt.Sprintf("%s.tf", getTerraformFileName(dependency)), deploymentConfig)
 }
 
+// generates the modules of a dependency defined in .exosphere/dependencies,
+// passing the rendered variable mappings of the definition to them
+func generateDefinedDependencyModules(definition types.DependencyDefinition, modules map[string]types.TerraformModuleMapping) (string, error) {
+	if !definition.HasRemoteModule() {
+		return "", nil
+	}
+	secrets := []map[string]string{}
+	for _, secret := range definition.Remote.Secrets {
+		secrets = append(secrets, map[string]string{"name": secret})
+	}
+	moduleNames := []string{}
+	for moduleName := range modules {
+		moduleNames = append(moduleNames, moduleName)
+	}
+	sort.Strings(moduleNames)
+	moduleVars := []map[string]interface{}{}
+	for i, moduleName := range moduleNames {
+		variableNames := []string{}
+		for variableName := range modules[moduleName].Variables {
+			variableNames = append(variableNames, variableName)
+		}
+		sort.Strings(variableNames)
+		variables := []map[string]string{}
+		for _, variableName := range variableNames {
+			variables = append(variables, map[string]string{
+				"name":  variableName,
+				"value": strconv.Quote(modules[moduleName].Variables[variableName]),
+			})
+		}
+		moduleVars = append(moduleVars, map[string]interface{}{
+			"leadingBlankLine": i > 0,
+			"name":             moduleName,
+			"source":           strconv.Quote(modules[moduleName].Source),
+			"variables":        variables,
+		})
+	}
+	return renderTemplateWithSections("defined_dependency.tf", map[string]interface{}{
+		"modules": moduleVars,
+		"secrets": secrets,
+	})
+}
+
 func getTerraformFileName(dependency types.RemoteDependency) string {
 	dbDependency := dependency.GetDbDependency()
 	if dbDependency != "" {
@@ -1,6 +1,7 @@
 package terraform_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 
@@ -47,6 +48,9 @@ var _ = Describe("Template builder", func() {
 				"key_name":          "${var.key_name}",
 				"name":              "example-app",
 				"env":               "production",
+				"ecs_instance_type": "t2.micro",
+				"ecs_max_size":      100,
+				"ecs_min_size":      3,
 				"external_dns_name": "example-app.com",
 			}))
 		})
@@ -110,6 +114,7 @@ var _ = Describe("Template builder", func() {
 			Expect(hclFile).To(matchers.HaveHCLVariable("public-service_docker_image"))
 			Expect(hclFile.Module["public-service"]).To(Equal(hcl.Module{
 				"source":             fmt.Sprintf("github.com/Originate/exosphere.git//terraform//aws//public-service?ref=%s", terraform.TerraformModulesRef),
+				"app_name":           "example-app",
 				"alb_security_group": "${module.aws.external_alb_security_group}",
 				"alb_subnet_ids":     []interface{}{"${module.aws.public_subnet_ids}"},
 				"cluster_id":         "${module.aws.ecs_cluster_id}",
@@ -139,6 +144,7 @@ var _ = Describe("Template builder", func() {
 			Expect(hclFile).To(matchers.HaveHCLVariable("worker-service_docker_image"))
 			Expect(hclFile.Module["worker-service"]).To(Equal(hcl.Module{
 				"source":        fmt.Sprintf("github.com/Originate/exosphere.git//terraform//aws//worker-service?ref=%s", terraform.TerraformModulesRef),
+				"app_name":      "example-app",
 				"cluster_id":    "${module.aws.ecs_cluster_id}",
 				"cpu":           "128",
 				"desired_count": 1,
@@ -150,6 +156,540 @@ var _ = Describe("Template builder", func() {
 				"region":                "${module.aws.region}",
 			}))
 		})
+
+		It("should generate the scalable targets of services without scaling policies", func() {
+			Expect(hclFile.Resource["aws_appautoscaling_target"]["public-service"]["min_capacity"]).To(Equal(1))
+			Expect(hclFile.Resource["aws_appautoscaling_target"]["public-service"]["max_capacity"]).To(Equal(1))
+			Expect(hclFile.Resource["aws_appautoscaling_target"]["worker-service"]["min_capacity"]).To(Equal(1))
+			Expect(hclFile.Resource["aws_appautoscaling_target"]["worker-service"]["max_capacity"]).To(Equal(1))
+			Expect(hclFile.Resource["aws_appautoscaling_policy"]).To(BeEmpty())
+		})
+	})
+
+	var _ = Describe("Given a public service deployed with the canary strategy", func() {
+		deployConfig := deploy.Config{
+			AppContext: &context.AppContext{
+				Config: types.AppConfig{
+					Name:     "example-app",
+					Services: map[string]types.ServiceSource{"web": {}},
+				},
+				ServiceContexts: map[string]*context.ServiceContext{
+					"web": {
+						Config: types.ServiceConfig{
+							Type:       "public",
+							Production: types.ServiceProductionConfig{Port: "3000"},
+							Remote: types.ServiceRemoteConfig{
+								CPU:         "128",
+								URL:         "originate.com",
+								HealthCheck: "/health-check",
+								Memory:      "128",
+								Deploy:      types.ServiceDeployConfig{Strategy: "canary"},
+							},
+						},
+					},
+				},
+			},
+			AwsConfig: types.AwsConfig{
+				SslCertificateArn: "sslcert123",
+			},
+		}
+
+		It("should generate a service module running the live and the new version next to each other", func() {
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile).NotTo(matchers.HaveHCLVariable("web_docker_image"))
+			Expect(hclFile).To(matchers.HaveHCLVariable("web_blue_docker_image"))
+			Expect(hclFile).To(matchers.HaveHCLVariable("web_green_docker_image"))
+			Expect(hclFile.Module["web"]["source"]).To(Equal(fmt.Sprintf("github.com/Originate/exosphere.git//terraform//aws//public-service-blue-green?ref=%s", terraform.TerraformModulesRef)))
+			Expect(hclFile.Module["web"]["blue_docker_image"]).To(Equal("${var.web_blue_docker_image}"))
+			Expect(hclFile.Module["web"]["green_docker_image"]).To(Equal("${var.web_green_docker_image}"))
+			Expect(hclFile.Module["web"]).NotTo(HaveKey("docker_image"))
+		})
+
+		It("should keep the image of the live color and deploy the new image to the idle one", func() {
+			vars, err := terraform.CompileVarFlags(deployConfig, types.Secrets{}, map[string]string{
+				"web":      "web:2.0",
+				"web_blue": "web:1.0",
+			})
+			Expect(err).To(BeNil())
+			Expect(vars).NotTo(ContainElement("web_docker_image=web:2.0"))
+			Expect(vars).To(ContainElement("web_blue_docker_image=web:1.0"))
+			Expect(vars).To(ContainElement("web_green_docker_image=web:2.0"))
+		})
+
+		It("should declare the new image for the migrations of the service", func() {
+			migrationsDeployConfig := deploy.Config{
+				AppContext: &context.AppContext{
+					Config:          deployConfig.AppContext.Config,
+					ServiceContexts: map[string]*context.ServiceContext{"web": {Config: deployConfig.AppContext.ServiceContexts["web"].Config}},
+				},
+				AwsConfig: deployConfig.AwsConfig,
+			}
+			migrationsDeployConfig.AppContext.ServiceContexts["web"].Config.Migrations = types.ServiceMigrationsConfig{Command: "bin/migrations", Directory: "migrations"}
+			result, err := terraform.Generate(migrationsDeployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile).To(matchers.HaveHCLVariable("web_docker_image"))
+			Expect(hclFile.Module["web_migrations"]["docker_image"]).To(Equal("${var.web_docker_image}"))
+			vars, err := terraform.CompileVarFlags(migrationsDeployConfig, types.Secrets{}, map[string]string{"web": "web:2.0"})
+			Expect(err).To(BeNil())
+			Expect(vars).To(ContainElement("web_docker_image=web:2.0"))
+		})
+
+		It("should mirror the names of the load balancer and target groups in the Terraform module", func() {
+			longRole := "a-public-service-with-a-very-long-role"
+			Expect(terraform.GetLoadBalancerName("web")).To(Equal("web"))
+			Expect(terraform.GetLoadBalancerName(longRole)).To(Equal("a-public-service-with-a-very-lo"))
+			Expect(terraform.GetColorTargetGroupName("web", terraform.ColorGreen)).To(Equal("web-green"))
+			Expect(terraform.GetColorTargetGroupName(longRole, terraform.ColorBlue)).To(Equal("a-public-service-with-a-ve-blue"))
+		})
+	})
+
+	var _ = Describe("Given the tasks of a service", func() {
+		It("should mirror the names of the task definition and its logs in the Terraform modules", func() {
+			Expect(terraform.GetTaskDefinitionFamily("web")).To(Equal("production-web"))
+			Expect(terraform.GetContainerName("web")).To(Equal("production-web"))
+			Expect(terraform.GetLogGroupName("web")).To(Equal("services/production/production-web"))
+		})
+
+		It("should name the log stream of a task after the prefix, the container and the task ID", func() {
+			taskArn := "arn:aws:ecs:us-west-2:12345678:task/0a1b2c3d-4e5f-6789-abcd-ef0123456789"
+			Expect(terraform.GetLogStreamName(terraform.LogStreamPrefix, "web", taskArn)).To(Equal("ecs/production-web/0a1b2c3d-4e5f-6789-abcd-ef0123456789"))
+			Expect(terraform.GetLogStreamName(terraform.ColorGreen, "web", taskArn)).To(Equal("green/production-web/0a1b2c3d-4e5f-6789-abcd-ef0123456789"))
+		})
+	})
+
+	var _ = Describe("Given services with scaling policies", func() {
+		var hclFile *hcl.File
+		deployConfig := deploy.Config{
+			AppContext: &context.AppContext{
+				Config: types.AppConfig{
+					Name:     "example-app",
+					Services: map[string]types.ServiceSource{"web": {}, "mailer": {}},
+					Remote: types.AppRemoteConfig{
+						Cluster: types.AppClusterConfig{InstanceType: "m4.large", MaxSize: 10},
+					},
+				},
+				ServiceContexts: map[string]*context.ServiceContext{
+					"web": {
+						Config: types.ServiceConfig{
+							Type:       "public",
+							Production: types.ServiceProductionConfig{Port: "3000"},
+							Remote: types.ServiceRemoteConfig{
+								CPU:    "128",
+								Memory: "128",
+								Scaling: types.ServiceScalingConfig{
+									Min:     2,
+									Max:     6,
+									Desired: 3,
+									Policies: []types.ServiceScalingPolicy{
+										{Metric: "cpu", Target: 60},
+										{Metric: "requests", Target: 1000},
+									},
+								},
+							},
+						},
+					},
+					"mailer": {
+						Config: types.ServiceConfig{
+							Type: "worker",
+							Remote: types.ServiceRemoteConfig{
+								CPU:    "128",
+								Memory: "128",
+								Dependencies: []types.RemoteDependency{
+									{
+										Name: "queue",
+										Config: types.RemoteDependencyConfig{
+											Queue: types.QueueConfig{Queues: []types.Queue{{Name: "email-jobs"}}},
+										},
+									},
+								},
+								Scaling: types.ServiceScalingConfig{
+									Max:      4,
+									Policies: []types.ServiceScalingPolicy{{Metric: "queue-depth", Target: 100, Queue: "email-jobs"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		BeforeEach(func() {
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err = hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+		})
+
+		It("should size the cluster as configured", func() {
+			Expect(hclFile.Module["aws"]["ecs_instance_type"]).To(Equal("m4.large"))
+			Expect(hclFile.Module["aws"]["ecs_max_size"]).To(Equal(10))
+			Expect(hclFile.Module["aws"]["ecs_min_size"]).To(Equal(3))
+		})
+
+		It("should start the services with the desired number of tasks", func() {
+			Expect(hclFile.Module["web"]["desired_count"]).To(Equal(3))
+			Expect(hclFile.Module["mailer"]["desired_count"]).To(Equal(1))
+		})
+
+		It("should generate the scalable targets of the services", func() {
+			Expect(hclFile.Resource["aws_appautoscaling_target"]["web"]).To(Equal(hcl.Resource{
+				"max_capacity":       6,
+				"min_capacity":       2,
+				"resource_id":        "service/production-example-app/${module.web.service_name}",
+				"role_arn":           "${module.aws.ecs_autoscale_iam_role_arn}",
+				"scalable_dimension": "ecs:service:DesiredCount",
+				"service_namespace":  "ecs",
+			}))
+			Expect(hclFile.Resource["aws_appautoscaling_target"]["mailer"]["min_capacity"]).To(Equal(1))
+			Expect(hclFile.Resource["aws_appautoscaling_target"]["mailer"]["max_capacity"]).To(Equal(4))
+		})
+
+		It("should generate a target tracking policy for each scaling policy", func() {
+			Expect(hclFile.Resource["aws_appautoscaling_policy"]).To(HaveLen(3))
+			cpuPolicy := hclFile.Resource["aws_appautoscaling_policy"]["web_cpu"]
+			Expect(cpuPolicy["name"]).To(Equal("web-cpu"))
+			Expect(cpuPolicy["policy_type"]).To(Equal("TargetTrackingScaling"))
+			Expect(cpuPolicy["resource_id"]).To(Equal("${aws_appautoscaling_target.web.resource_id}"))
+			Expect(cpuPolicy["target_tracking_scaling_policy_configuration"]).To(ConsistOf(map[string]interface{}{
+				"target_value": 60,
+				"predefined_metric_specification": []map[string]interface{}{
+					{"predefined_metric_type": "ECSServiceAverageCPUUtilization"},
+				},
+			}))
+			requestsPolicy := hclFile.Resource["aws_appautoscaling_policy"]["web_requests"]
+			Expect(requestsPolicy["target_tracking_scaling_policy_configuration"]).To(ConsistOf(map[string]interface{}{
+				"target_value": 1000,
+				"predefined_metric_specification": []map[string]interface{}{
+					{"predefined_metric_type": "ALBRequestCountPerTarget", "resource_label": "${module.web.alb_resource_label}"},
+				},
+			}))
+			queuePolicy := hclFile.Resource["aws_appautoscaling_policy"]["mailer_queue_depth_email_jobs"]
+			Expect(queuePolicy["name"]).To(Equal("mailer-queue-depth-email-jobs"))
+			Expect(queuePolicy["target_tracking_scaling_policy_configuration"]).To(ConsistOf(map[string]interface{}{
+				"target_value": 100,
+				"customized_metric_specification": []map[string]interface{}{
+					{
+						"metric_name": "ApproximateNumberOfMessagesVisible",
+						"namespace":   "AWS/SQS",
+						"statistic":   "Average",
+						"dimensions": []map[string]interface{}{
+							{"name": "QueueName", "value": "${aws_sqs_queue.email_jobs.name}"},
+						},
+					},
+				},
+			}))
+		})
+
+		It("should not target the scaling resources when applying the changes needed by the migrations", func() {
+			terraformDir, err := ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+			targetsDeployConfig := deployConfig
+			targetsDeployConfig.TerraformDir = terraformDir
+			err = terraform.GenerateFile(targetsDeployConfig)
+			Expect(err).NotTo(HaveOccurred())
+			targets, err := terraform.GetMigrationsTargets(targetsDeployConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(Equal([]string{
+				"aws_sqs_queue.email_jobs",
+				"module.aws",
+			}))
+		})
+	})
+
+	var _ = Describe("Given a scheduled service", func() {
+		deployConfig := deploy.Config{
+			AppContext: &context.AppContext{
+				Config: types.AppConfig{
+					Name:     "example-app",
+					Services: map[string]types.ServiceSource{"reports": {}},
+				},
+				ServiceContexts: map[string]*context.ServiceContext{
+					"reports": {
+						Config: types.ServiceConfig{
+							Type:     types.ServiceTypeScheduled,
+							Schedule: "30 2 * * MON-FRI",
+							Remote: types.ServiceRemoteConfig{
+								CPU:    "128",
+								Memory: "256",
+							},
+						},
+					},
+				},
+			},
+		}
+
+		It("should generate a scheduled service module running on the schedule of the service", func() {
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile).To(matchers.HaveHCLVariable("reports_docker_image"))
+			Expect(hclFile.Module["reports"]).To(Equal(hcl.Module{
+				"source":                fmt.Sprintf("github.com/Originate/exosphere.git//terraform//aws//scheduled-service?ref=%s", terraform.TerraformModulesRef),
+				"app_name":              "example-app",
+				"name":                  "reports",
+				"cluster_id":            "${module.aws.ecs_cluster_id}",
+				"cpu":                   "128",
+				"docker_image":          "${var.reports_docker_image}",
+				"env":                   "production",
+				"environment_variables": "${var.reports_env_vars}",
+				"memory_reservation":    "256",
+				"region":                "${module.aws.region}",
+				"schedule_expression":   "cron(30 2 ? * 2,3,4,5,6 *)",
+			}))
+		})
+	})
+
+	var _ = Describe("Given a service with permissions", func() {
+		deployConfig := deploy.Config{
+			AppContext: &context.AppContext{
+				Config: types.AppConfig{
+					Name:     "example-app",
+					Services: map[string]types.ServiceSource{"reports": {}},
+					Remote: types.AppRemoteConfig{
+						Dependencies: []types.RemoteDependency{
+							{
+								Name: "s3",
+								Config: types.RemoteDependencyConfig{
+									S3: types.S3Config{Buckets: []types.S3Bucket{{Name: "uploads"}}},
+								},
+							},
+						},
+					},
+				},
+				ServiceContexts: map[string]*context.ServiceContext{
+					"reports": {
+						Config: types.ServiceConfig{
+							Type: types.ServiceTypeWorker,
+							Remote: types.ServiceRemoteConfig{
+								CPU:    "128",
+								Memory: "128",
+								Permissions: []types.ServicePermission{
+									{Preset: "s3:read:exports"},
+									{Preset: "s3:write:uploads"},
+									{
+										Action:    types.StringList{"ses:SendEmail"},
+										Resource:  types.StringList{"*"},
+										Condition: map[string]map[string]types.StringList{"StringEquals": {"ses:FromAddress": {"reports@example.com"}}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			AwsConfig: types.AwsConfig{Region: "us-west-2", AccountID: "12345678"},
+		}
+
+		It("should attach a policy granting the permissions to the task role of the service", func() {
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			policy := hclFile.Resource["aws_iam_role_policy"]["reports_permissions"]
+			Expect(policy["name"]).To(Equal("reports-permissions"))
+			Expect(policy["role"]).To(Equal("${module.reports.task_role_name}"))
+			var document map[string]interface{}
+			Expect(json.Unmarshal([]byte(policy["policy"].(string)), &document)).To(Succeed())
+			Expect(document["Statement"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"Effect":   "Allow",
+					"Action":   []interface{}{"s3:GetObject", "s3:ListBucket"},
+					"Resource": []interface{}{"arn:aws:s3:::exports", "arn:aws:s3:::exports/*"},
+				},
+				map[string]interface{}{
+					"Effect":   "Allow",
+					"Action":   []interface{}{"s3:DeleteObject", "s3:PutObject"},
+					"Resource": []interface{}{"arn:aws:s3:::example-app-uploads/*"},
+				},
+				map[string]interface{}{
+					"Effect":    "Allow",
+					"Action":    []interface{}{"ses:SendEmail"},
+					"Resource":  []interface{}{"*"},
+					"Condition": map[string]interface{}{"StringEquals": map[string]interface{}{"ses:FromAddress": []interface{}{"reports@example.com"}}},
+				},
+			}))
+		})
+	})
+
+	var _ = Describe("Given public services with routes", func() {
+		deployConfig := deploy.Config{
+			AppContext: &context.AppContext{
+				Config: types.AppConfig{
+					Name:     "example-app",
+					Remote:   types.AppRemoteConfig{URL: "example.com"},
+					Services: map[string]types.ServiceSource{"api": {}, "web": {}},
+				},
+				ServiceContexts: map[string]*context.ServiceContext{
+					"api": {
+						Config: types.ServiceConfig{
+							Type:       types.ServiceTypePublic,
+							Production: types.ServiceProductionConfig{Port: "3000"},
+							Remote: types.ServiceRemoteConfig{
+								CPU:         "128",
+								Memory:      "128",
+								HealthCheck: "/health",
+								Routes: []types.ServiceRoute{
+									{Path: "/api/", Priority: 10},
+									{Host: "api.example.com", Priority: 20},
+								},
+							},
+						},
+					},
+					"web": {
+						Config: types.ServiceConfig{
+							Type:       types.ServiceTypePublic,
+							Production: types.ServiceProductionConfig{Port: "80"},
+							Remote: types.ServiceRemoteConfig{
+								CPU:         "128",
+								Memory:      "128",
+								HealthCheck: "/",
+								Routes:      []types.ServiceRoute{{Priority: 100}},
+							},
+						},
+					},
+				},
+			},
+			AwsConfig: types.AwsConfig{SslCertificateArn: "sslcert123"},
+		}
+
+		It("should generate a shared ALB for the hosts of the routes", func() {
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile.Module["shared_alb"]).To(Equal(hcl.Module{
+				"source":              fmt.Sprintf("github.com/Originate/exosphere.git//terraform//aws//shared-alb?ref=%s", terraform.TerraformModulesRef),
+				"name":                "example-app-routes",
+				"alb_security_group":  "${module.aws.external_alb_security_group}",
+				"alb_subnet_ids":      []interface{}{"${module.aws.public_subnet_ids}"},
+				"env":                 "production",
+				"external_dns_names":  []interface{}{"example.com", "api.example.com"},
+				"external_zone_id":    "${module.aws.external_zone_id}",
+				"log_bucket":          "${module.aws.log_bucket_id}",
+				"ssl_certificate_arn": "sslcert123",
+				"vpc_id":              "${module.aws.vpc_id}",
+			}))
+		})
+
+		It("should generate routed service modules with a listener rule for each route", func() {
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile).To(matchers.HaveHCLVariable("api_docker_image"))
+			Expect(hclFile.Module["api"]).To(Equal(hcl.Module{
+				"source":                fmt.Sprintf("github.com/Originate/exosphere.git//terraform//aws//public-service-routed?ref=%s", terraform.TerraformModulesRef),
+				"app_name":              "example-app",
+				"name":                  "api",
+				"alb_arn_suffix":        "${module.shared_alb.arn_suffix}",
+				"cluster_id":            "${module.aws.ecs_cluster_id}",
+				"container_port":        "3000",
+				"cpu":                   "128",
+				"desired_count":         1,
+				"docker_image":          "${var.api_docker_image}",
+				"ecs_role_arn":          "${module.aws.ecs_service_iam_role_arn}",
+				"env":                   "production",
+				"environment_variables": "${var.api_env_vars}",
+				"health_check_endpoint": "/health",
+				"listener_arn":          "${module.shared_alb.listener_arn}",
+				"memory_reservation":    "128",
+				"region":                "${module.aws.region}",
+				"route_hosts":           []interface{}{"example.com", "api.example.com"},
+				"route_path_patterns":   []interface{}{"/api/*", "/*"},
+				"route_priorities":      []interface{}{"10", "20"},
+				"vpc_id":                "${module.aws.vpc_id}",
+			}))
+			Expect(hclFile.Module["web"]["route_priorities"]).To(Equal([]interface{}{"100"}))
+		})
+	})
+
+	var _ = Describe("Given a service with migrations", func() {
+		deployConfig := deploy.Config{
+			AppContext: &context.AppContext{
+				Config: types.AppConfig{
+					Name:     "example-app",
+					Services: map[string]types.ServiceSource{"users": {}},
+					Remote: types.AppRemoteConfig{
+						Dependencies: []types.RemoteDependency{
+							{
+								Name: "s3",
+								Config: types.RemoteDependencyConfig{
+									S3: types.S3Config{Buckets: []types.S3Bucket{{Name: "avatars"}}},
+								},
+							},
+						},
+					},
+				},
+				ServiceContexts: map[string]*context.ServiceContext{
+					"users": {
+						Config: types.ServiceConfig{
+							Type: types.ServiceTypeWorker,
+							Remote: types.ServiceRemoteConfig{
+								CPU:    "128",
+								Memory: "256",
+							},
+							Migrations: types.ServiceMigrationsConfig{
+								Command:   "bin/migrations",
+								Directory: "migrations",
+							},
+						},
+					},
+				},
+			},
+		}
+
+		It("should generate a task definition running the migrations", func() {
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile.Module["users_migrations"]).To(Equal(hcl.Module{
+				"source":                fmt.Sprintf("github.com/Originate/exosphere.git//terraform//aws//ecs-task-definition?ref=%s", terraform.TerraformModulesRef),
+				"app_name":              "example-app",
+				"command":               []interface{}{"env", "MIGRATIONS_DIR=migrations", "sh", "-c", "bin/migrations migrate"},
+				"cpu":                   "128",
+				"docker_image":          "${var.users_docker_image}",
+				"env":                   "production",
+				"environment_variables": "${var.users_env_vars}",
+				"memory_reservation":    "256",
+				"name":                  "production-users-migrations",
+				"region":                "${module.aws.region}",
+			}))
+		})
+
+		It("should target everything but the services when applying the changes needed by the migrations", func() {
+			terraformDir, err := ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+			deployConfig.TerraformDir = terraformDir
+			err = terraform.GenerateFile(deployConfig)
+			Expect(err).NotTo(HaveOccurred())
+			targets, err := terraform.GetMigrationsTargets(deployConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(Equal([]string{
+				"aws_iam_role_policy.users_migrations_s3",
+				"aws_s3_bucket.avatars",
+				"module.aws",
+				"module.users_migrations",
+			}))
+		})
+
+		It("should grant the migrations the permissions of the service", func() {
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			servicePolicy := hclFile.Resource["aws_iam_role_policy"]["users_s3"]
+			migrationsPolicy := hclFile.Resource["aws_iam_role_policy"]["users_migrations_s3"]
+			Expect(migrationsPolicy["name"]).To(Equal("users-migrations-s3"))
+			Expect(migrationsPolicy["role"]).To(Equal("${module.users_migrations.task_role_name}"))
+			Expect(migrationsPolicy["policy"]).To(Equal(servicePolicy["policy"]))
+		})
 	})
 
 	var _ = Describe("Given an application with dependencies", func() {
@@ -196,6 +736,348 @@ var _ = Describe("Template builder", func() {
 			}))
 		})
 
+		It("should generate dependency modules for nats", func() {
+			deployConfig := deploy.Config{
+				AppContext: &context.AppContext{
+					Config: types.AppConfig{
+						Name: "example-app",
+						Remote: types.AppRemoteConfig{
+							Dependencies: []types.RemoteDependency{
+								{
+									Name:    "nats",
+									Version: "0.9.6",
+									Config: types.RemoteDependencyConfig{
+										Nats: types.NatsConfig{
+											ClusterSize:         "3",
+											AuthTokenSecretName: "NATS_TOKEN",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile).To(matchers.HaveHCLVariable("nats_env_vars"))
+			Expect(hclFile).To(matchers.HaveHCLVariable("NATS_TOKEN"))
+			Expect(hclFile.Module["nats_cluster"]).To(Equal(hcl.Module{
+				"source":                      fmt.Sprintf("github.com/Originate/exosphere.git//terraform//aws//dependencies//nats//nats-cluster?ref=%s", terraform.TerraformModulesRef),
+				"app_name":                    "example-app",
+				"availability_zones":          "${module.aws.availability_zones}",
+				"bastion_security_group":      []interface{}{"${module.aws.bastion_security_group}"},
+				"cluster_size":                "3",
+				"ecs_cluster_security_groups": []interface{}{"${module.aws.ecs_cluster_security_group}"},
+				"env":                     "production",
+				"instance_type":           "t2.micro",
+				"internal_hosted_zone_id": "${module.aws.internal_zone_id}",
+				"key_name":                "${var.key_name}",
+				"name":                    "nats",
+				"region":                  "${module.aws.region}",
+				"subnet_ids":              "${module.aws.private_subnet_ids}",
+				"vpc_id":                  "${module.aws.vpc_id}",
+			}))
+			Expect(hclFile.Module["nats_service"]).To(Equal(hcl.Module{
+				"source":                fmt.Sprintf("github.com/Originate/exosphere.git//terraform//aws//dependencies//nats//nats-service?ref=%s", terraform.TerraformModulesRef),
+				"app_name":              "example-app",
+				"auth_token":            "${var.NATS_TOKEN}",
+				"cluster_id":            "${module.nats_cluster.cluster_id}",
+				"cluster_size":          "3",
+				"cpu_units":             "128",
+				"dns_name":              "${module.nats_cluster.nats_address}",
+				"docker_image":          "${var.nats_docker_image}",
+				"env":                   "production",
+				"environment_variables": "${var.nats_env_vars}",
+				"memory_reservation":    "128",
+				"name":                  "nats",
+				"region":                "${module.aws.region}",
+			}))
+		})
+
+		It("should generate modules for dependencies defined in .exosphere/dependencies", func() {
+			appDir, err := ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+			err = helpers.CheckoutApp(appDir, "dependency-definitions")
+			Expect(err).NotTo(HaveOccurred())
+			appContext, err := context.GetAppContext(appDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			deployConfig := deploy.Config{
+				AppContext: appContext,
+			}
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile).To(matchers.HaveHCLVariable("MEMCACHED_TOKEN"))
+			Expect(hclFile.Module["memcached"]).To(Equal(hcl.Module{
+				"source":         "github.com/example/terraform-modules//memcached",
+				"auth_token":     "${var.MEMCACHED_TOKEN}",
+				"engine_version": "1.5.3",
+				"name":           "dependency-definitions-memcached",
+				"node_type":      "cache.t2.micro",
+				"subnet_ids":     "${module.aws.private_subnet_ids}",
+			}))
+		})
+
+		It("should generate the additional modules of dependencies defined in .exosphere/dependencies", func() {
+			appDir, err := ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+			err = helpers.CheckoutApp(appDir, "dependency-definitions")
+			Expect(err).NotTo(HaveOccurred())
+			appContext, err := context.GetAppContext(appDir)
+			Expect(err).NotTo(HaveOccurred())
+			definition := appContext.DependencyDefinitions["memcached"]
+			definition.Remote.Module.Variables["parameter_group"] = "${module.memcached_parameters.name}"
+			definition.Remote.Modules = map[string]types.TerraformModuleMapping{
+				"parameters": {
+					Source:    "github.com/example/terraform-modules//memcached-parameters",
+					Variables: map[string]string{"name": "{{appName}}-{{name}}"},
+				},
+			}
+			appContext.DependencyDefinitions["memcached"] = definition
+
+			result, err := terraform.Generate(deploy.Config{AppContext: appContext})
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile.Module["memcached"]["parameter_group"]).To(Equal("${module.memcached_parameters.name}"))
+			Expect(hclFile.Module["memcached_parameters"]).To(Equal(hcl.Module{
+				"source": "github.com/example/terraform-modules//memcached-parameters",
+				"name":   "dependency-definitions-memcached",
+			}))
+		})
+
+		It("should generate elasticache modules for redis", func() {
+			deployConfig := deploy.Config{
+				AppContext: &context.AppContext{
+					Config: types.AppConfig{
+						Name: "example-app",
+						Remote: types.AppRemoteConfig{
+							Dependencies: []types.RemoteDependency{
+								{
+									Name:    "redis",
+									Version: "3.2.10",
+									Config: types.RemoteDependencyConfig{
+										Redis: types.RedisConfig{Name: "sessions", NodeType: "cache.m4.large", NumCacheClusters: "2"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile.Module["sessions_elasticache"]).To(Equal(hcl.Module{
+				"source":                  fmt.Sprintf("github.com/Originate/exosphere.git//terraform//aws//dependencies//elasticache?ref=%s", terraform.TerraformModulesRef),
+				"app_name":                "example-app",
+				"bastion_security_group":  "${module.aws.bastion_security_group}",
+				"ecs_security_group":      "${module.aws.ecs_cluster_security_group}",
+				"engine_version":          "3.2.10",
+				"env":                     "production",
+				"internal_hosted_zone_id": "${module.aws.internal_zone_id}",
+				"name":                 "sessions",
+				"node_type":            "cache.m4.large",
+				"num_cache_clusters":   "2",
+				"replication_group_id": "example-app-sessions",
+				"subnet_ids":         []interface{}{"${module.aws.private_subnet_ids}"},
+				"vpc_id":             "${module.aws.vpc_id}",
+			}))
+		})
+
+		It("should generate dynamodb tables and policies for the services using them", func() {
+			usersTable := types.DynamoDBTable{
+				Name:       "users",
+				HashKey:    "id",
+				Attributes: map[string]string{"id": "S", "email": "S"},
+				GlobalSecondaryIndexes: []types.DynamoDBSecondaryIndex{
+					{Name: "by-email", HashKey: "email", ProjectionType: "KEYS_ONLY"},
+				},
+			}
+			deployConfig := deploy.Config{
+				AppContext: &context.AppContext{
+					Config: types.AppConfig{
+						Name: "example-app",
+						Services: map[string]types.ServiceSource{
+							"users-service": {},
+							"web":           {},
+						},
+					},
+					ServiceContexts: map[string]*context.ServiceContext{
+						"users-service": {
+							Config: types.ServiceConfig{
+								Type: types.ServiceTypeWorker,
+								Remote: types.ServiceRemoteConfig{
+									Dependencies: []types.RemoteDependency{
+										{
+											Name: "dynamodb",
+											Config: types.RemoteDependencyConfig{
+												Dynamodb: types.DynamoDBConfig{Tables: []types.DynamoDBTable{usersTable}},
+											},
+										},
+									},
+								},
+							},
+						},
+						"web": {
+							Config: types.ServiceConfig{Type: types.ServiceTypeWorker},
+						},
+					},
+				},
+			}
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile.Resource["aws_dynamodb_table"]).To(HaveLen(1))
+			Expect(hclFile.Resource["aws_dynamodb_table"]["users"]).To(Equal(hcl.Resource{
+				"name":           "example-app-users",
+				"hash_key":       "id",
+				"read_capacity":  5,
+				"write_capacity": 5,
+				"attribute": []map[string]interface{}{
+					{"name": "email", "type": "S"},
+					{"name": "id", "type": "S"},
+				},
+				"global_secondary_index": []map[string]interface{}{
+					{
+						"name":            "by-email",
+						"hash_key":        "email",
+						"projection_type": "KEYS_ONLY",
+						"read_capacity":   5,
+						"write_capacity":  5,
+					},
+				},
+				"tags": []map[string]interface{}{{"env": "production"}},
+			}))
+			Expect(hclFile.Resource["aws_iam_role_policy"]).To(HaveLen(1))
+			policy := hclFile.Resource["aws_iam_role_policy"]["users-service_dynamodb"]
+			Expect(policy["role"]).To(Equal("${module.users-service.task_role_name}"))
+			Expect(policy["policy"]).To(ContainSubstring(`"${aws_dynamodb_table.users.arn}"`))
+			Expect(policy["policy"]).To(ContainSubstring(`"${aws_dynamodb_table.users.arn}/index/*"`))
+		})
+
+		It("should generate s3 buckets and policies for the services using them", func() {
+			deployConfig := deploy.Config{
+				AppContext: &context.AppContext{
+					Config: types.AppConfig{
+						Name:     "example-app",
+						Services: map[string]types.ServiceSource{"web": {}},
+						Remote: types.AppRemoteConfig{
+							Dependencies: []types.RemoteDependency{
+								{
+									Name: "s3",
+									Config: types.RemoteDependencyConfig{
+										S3: types.S3Config{
+											Buckets: []types.S3Bucket{
+												{
+													Name: "uploads",
+													Cors: []types.S3CorsRule{
+														{AllowedOrigins: []string{"https://example.com"}, AllowedMethods: []string{"GET", "PUT"}, MaxAgeSeconds: "3000"},
+													},
+													Lifecycle: []types.S3Lifecycle{{Prefix: "tmp/", ExpirationDays: "7"}},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					ServiceContexts: map[string]*context.ServiceContext{
+						"web": {Config: types.ServiceConfig{Type: types.ServiceTypeWorker}},
+					},
+				},
+			}
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile.Resource["aws_s3_bucket"]["uploads"]).To(Equal(hcl.Resource{
+				"bucket": "example-app-uploads",
+				"cors_rule": []map[string]interface{}{
+					{
+						"allowed_origins": []interface{}{"https://example.com"},
+						"allowed_methods": []interface{}{"GET", "PUT"},
+						"max_age_seconds": 3000,
+					},
+				},
+				"lifecycle_rule": []map[string]interface{}{
+					{
+						"id":         "uploads-expiration-0",
+						"prefix":     "tmp/",
+						"enabled":    true,
+						"expiration": []map[string]interface{}{{"days": 7}},
+					},
+				},
+				"tags": []map[string]interface{}{{"Name": "example-app-uploads", "Environment": "production"}},
+			}))
+			policy := hclFile.Resource["aws_iam_role_policy"]["web_s3"]
+			Expect(policy["role"]).To(Equal("${module.web.task_role_name}"))
+			Expect(policy["policy"]).To(ContainSubstring(`"${aws_s3_bucket.uploads.arn}"`))
+			Expect(policy["policy"]).To(ContainSubstring(`"${aws_s3_bucket.uploads.arn}/*"`))
+		})
+
+		It("should generate sqs queues, sns topics and policies for the services using them", func() {
+			deployConfig := deploy.Config{
+				AppContext: &context.AppContext{
+					Config: types.AppConfig{
+						Name:     "example-app",
+						Services: map[string]types.ServiceSource{"mailer": {}},
+					},
+					ServiceContexts: map[string]*context.ServiceContext{
+						"mailer": {
+							Config: types.ServiceConfig{
+								Type: types.ServiceTypeWorker,
+								Remote: types.ServiceRemoteConfig{
+									Dependencies: []types.RemoteDependency{
+										{
+											Name: "queue",
+											Config: types.RemoteDependencyConfig{
+												Queue: types.QueueConfig{
+													Queues: []types.Queue{
+														{Name: "email-jobs", DeadLetter: types.QueueDeadLetter{Queue: "email-jobs-failed", MaxReceiveCount: "3"}},
+														{Name: "email-jobs-failed"},
+													},
+													Topics: []types.QueueTopic{
+														{Name: "user-events", Subscriptions: []string{"email-jobs"}},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			result, err := terraform.Generate(deployConfig)
+			Expect(err).To(BeNil())
+			hclFile, err := hcl.GetHCLFileFromTerraform(result)
+			Expect(err).To(BeNil())
+			Expect(hclFile.Resource["aws_sqs_queue"]).To(HaveLen(2))
+			Expect(hclFile.Resource["aws_sqs_queue"]["email_jobs"]["name"]).To(Equal("example-app-email-jobs"))
+			Expect(hclFile.Resource["aws_sqs_queue"]["email_jobs"]["redrive_policy"]).To(Equal(`{"deadLetterTargetArn":"${aws_sqs_queue.email_jobs_failed.arn}","maxReceiveCount":3}`))
+			Expect(hclFile.Resource["aws_sns_topic"]["user_events"]["name"]).To(Equal("example-app-user-events"))
+			Expect(hclFile.Resource["aws_sns_topic_subscription"]["user_events_email_jobs"]).To(Equal(hcl.Resource{
+				"topic_arn": "${aws_sns_topic.user_events.arn}",
+				"protocol":  "sqs",
+				"endpoint":  "${aws_sqs_queue.email_jobs.arn}",
+			}))
+			Expect(hclFile.Resource["aws_sqs_queue_policy"]["email_jobs"]["policy"]).To(ContainSubstring(`"${aws_sns_topic.user_events.arn}"`))
+			policy := hclFile.Resource["aws_iam_role_policy"]["mailer_queue"]
+			Expect(policy["role"]).To(Equal("${module.mailer.task_role_name}"))
+			Expect(policy["policy"]).To(ContainSubstring(`"${aws_sqs_queue.email_jobs_failed.arn}"`))
+			Expect(policy["policy"]).To(ContainSubstring(`"sns:Publish"`))
+		})
+
 		It("should generate rds modules for dependencies", func() {
 			appDir, err := ioutil.TempDir("", "")
 			Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,97 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/Originate/exosphere/src/types/hcl"
+)
+
+// GetMigrationsTaskDefinitionFamily returns the family of the task definition
+// that runs the migrations of the given service
+func GetMigrationsTaskDefinitionFamily(serviceRole string) string {
+	return fmt.Sprintf("production-%s-migrations", serviceRole)
+}
+
+// GetClusterName returns the name of the ECS cluster of the application
+func GetClusterName(appName string) string {
+	return fmt.Sprintf("production-%s", appName)
+}
+
+// returns the name of the module of the task definition that runs the migrations of the given service
+func getMigrationsModuleName(serviceRole string) string {
+	return fmt.Sprintf("%s_migrations", serviceRole)
+}
+
+// generates a task definition that runs the migrations of the given service with the image
+// and environment of the service. It is not part of an ECS service but run once per deploy
+func generateMigrationsModule(serviceRole string, deployConfig deploy.Config) (string, error) {
+	serviceConfig := deployConfig.AppContext.ServiceContexts[serviceRole].Config
+	command, err := json.Marshal(serviceConfig.Migrations.GetCommand(types.MigrationActionMigrate))
+	if err != nil {
+		return "", err
+	}
+	varsMap := map[string]string{
+		"appName":             deployConfig.AppContext.Config.Name,
+		"serviceRole":         serviceRole,
+		"command":             string(command),
+		"cpu":                 serviceConfig.Remote.CPU,
+		"memory":              serviceConfig.Remote.Memory,
+		"terraformCommitHash": TerraformModulesRef,
+	}
+	result, err := RenderTemplates("migrations_task.tf", varsMap)
+	if err != nil {
+		return "", err
+	}
+	// the modules of services that shift traffic only declare the images of their colors
+	if serviceConfig.Remote.Deploy.ShiftsTraffic() {
+		result = fmt.Sprintf("variable \"%s_docker_image\" {}\n\n%s", serviceRole, result)
+	}
+	return result, nil
+}
+
+// GetMigrationsTargets returns the targets of the terraform apply that runs before the migrations:
+// the migrations task definitions and their policies plus everything else except the services, so that the
+// databases exist and the services are only updated once the migrations succeeded
+func GetMigrationsTargets(deployConfig deploy.Config) ([]string, error) {
+	terraformFile, err := ReadTerraformFile(deployConfig)
+	if err != nil {
+		return nil, err
+	}
+	hclFile, err := hcl.GetHCLFileFromTerraform(string(terraformFile))
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, moduleName := range hclFile.GetModuleNames() {
+		if _, ok := deployConfig.AppContext.ServiceContexts[moduleName]; ok {
+			continue
+		}
+		result = append(result, fmt.Sprintf("module.%s", moduleName))
+	}
+	for resourceType, resources := range hclFile.Resource {
+		// scaling targets and policies reference the ECS services of the service modules
+		if strings.HasPrefix(resourceType, "aws_appautoscaling_") {
+			continue
+		}
+		for resourceName, resource := range resources {
+			// task role policies of the services reference the service modules
+			if resourceType == "aws_iam_role_policy" && !isMigrationsTaskRolePolicy(resource) {
+				continue
+			}
+			result = append(result, fmt.Sprintf("%s.%s", resourceType, resourceName))
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// returns whether the given task role policy is attached to the task role of migrations
+func isMigrationsTaskRolePolicy(policy hcl.Resource) bool {
+	role, ok := policy["role"].(string)
+	return ok && strings.HasPrefix(role, "${module.") && strings.HasSuffix(role, "_migrations.task_role_name}")
+}
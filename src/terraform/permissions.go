@@ -0,0 +1,72 @@
+package terraform
+
+import (
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+	"github.com/Originate/exosphere/src/types/deploy"
+)
+
+// generates a policy attached to the task role of the given service which grants
+// the permissions declared in its service.yml. Presets are expanded into statements
+// for their resource in the region and account of the application
+func generatePermissionsPolicy(serviceRole string, deployConfig deploy.Config) (string, error) {
+	permissions := deployConfig.AppContext.ServiceContexts[serviceRole].Config.Remote.Permissions
+	managedResourceNames := getManagedResourceNames(deployConfig.AppContext)
+	statements := []iamPolicyStatement{}
+	for _, permission := range permissions {
+		statement := permission.GetStatement(deployConfig.AwsConfig.Region, deployConfig.AwsConfig.AccountID, managedResourceNames)
+		var condition map[string]map[string][]string
+		if len(statement.Condition) > 0 {
+			condition = map[string]map[string][]string{}
+			for operator, values := range statement.Condition {
+				condition[operator] = map[string][]string{}
+				for key, value := range values {
+					condition[operator][key] = value
+				}
+			}
+		}
+		statements = append(statements, iamPolicyStatement{
+			Effect:    statement.GetEffect(),
+			Action:    statement.Action,
+			Resource:  statement.Resource,
+			Condition: condition,
+		})
+	}
+	return generateTaskRolePolicy(deployConfig.AppContext, serviceRole, "permissions", statements)
+}
+
+// returns the real names of the buckets, tables, queues and topics declared by the remote dependencies
+// of the application and its services by their names, grouped by the AWS service of their presets.
+// These resources are named after the application, so presets for them refer to them by the declared name
+func getManagedResourceNames(appContext *context.AppContext) map[string]map[string]string {
+	result := map[string]map[string]string{"dynamodb": {}, "s3": {}, "sns": {}, "sqs": {}}
+	dependencies := append([]types.RemoteDependency{}, appContext.Config.Remote.Dependencies...)
+	for _, serviceContext := range appContext.ServiceContexts {
+		dependencies = append(dependencies, serviceContext.Config.Remote.Dependencies...)
+	}
+	appName := appContext.Config.Name
+	for _, dependency := range dependencies {
+		// dependencies with a definition of their own are not managed by exo
+		if _, ok := appContext.DependencyDefinitions[dependency.Name]; ok {
+			continue
+		}
+		switch dependency.Name {
+		case "dynamodb":
+			for _, table := range dependency.Config.Dynamodb.Tables {
+				result["dynamodb"][table.Name] = table.GetTableName(appName)
+			}
+		case "s3":
+			for _, bucket := range dependency.Config.S3.Buckets {
+				result["s3"][bucket.Name] = bucket.GetBucketName(appName)
+			}
+		case "queue":
+			for _, queue := range dependency.Config.Queue.Queues {
+				result["sqs"][queue.Name] = queue.GetQueueName(appName)
+			}
+			for _, topic := range dependency.Config.Queue.Topics {
+				result["sns"][topic.Name] = topic.GetTopicName(appName)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,186 @@
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// the actions services are allowed to perform on the queues they declare
+var sqsQueueActions = []string{
+	"sqs:ChangeMessageVisibility",
+	"sqs:DeleteMessage",
+	"sqs:GetQueueAttributes",
+	"sqs:GetQueueUrl",
+	"sqs:ReceiveMessage",
+	"sqs:SendMessage",
+}
+
+// generates the queues, topics and subscriptions of all queue dependencies and a policy for each service
+// that allows it to use the queues and topics declared in application.yml and its own service.yml
+func generateQueueModules(deployConfig deploy.Config) (string, error) {
+	appContext := deployConfig.AppContext
+	if _, ok := appContext.DependencyDefinitions["queue"]; ok {
+		return "", nil
+	}
+	queues := map[string]types.Queue{}
+	topics := map[string]types.QueueTopic{}
+	addDependency := func(dependency types.RemoteDependency) {
+		for _, queue := range dependency.Config.Queue.Queues {
+			queues[queue.Name] = queue
+		}
+		for _, topic := range dependency.Config.Queue.Topics {
+			topics[topic.Name] = topic
+		}
+	}
+	for _, dependency := range appContext.Config.Remote.Dependencies {
+		if dependency.Name == "queue" {
+			addDependency(dependency)
+		}
+	}
+	serviceQueueNames := map[string][]string{}
+	serviceTopicNames := map[string][]string{}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		for _, dependency := range getServiceRemoteDependencies(appContext, serviceRole, "queue") {
+			addDependency(dependency)
+			for _, queue := range dependency.Config.Queue.Queues {
+				if !util.DoesStringArrayContain(serviceQueueNames[serviceRole], queue.Name) {
+					serviceQueueNames[serviceRole] = append(serviceQueueNames[serviceRole], queue.Name)
+				}
+			}
+			for _, topic := range dependency.Config.Queue.Topics {
+				if !util.DoesStringArrayContain(serviceTopicNames[serviceRole], topic.Name) {
+					serviceTopicNames[serviceRole] = append(serviceTopicNames[serviceRole], topic.Name)
+				}
+			}
+		}
+	}
+	result := []string{}
+	queueNames := []string{}
+	for queueName := range queues {
+		queueNames = append(queueNames, queueName)
+	}
+	sort.Strings(queueNames)
+	for _, queueName := range queueNames {
+		queue, err := generateSqsQueue(queues[queueName], appContext.Config.Name)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, queue)
+	}
+	topicNames := []string{}
+	for topicName := range topics {
+		topicNames = append(topicNames, topicName)
+	}
+	sort.Strings(topicNames)
+	subscribingTopicNames := map[string][]string{}
+	for _, topicName := range topicNames {
+		topic, err := generateSnsTopic(topics[topicName], appContext.Config.Name)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, topic)
+		for _, queueName := range topics[topicName].Subscriptions {
+			subscribingTopicNames[queueName] = append(subscribingTopicNames[queueName], topicName)
+		}
+	}
+	for _, queueName := range queueNames {
+		if len(subscribingTopicNames[queueName]) == 0 {
+			continue
+		}
+		queuePolicy, err := generateSqsQueuePolicy(queueName, subscribingTopicNames[queueName])
+		if err != nil {
+			return "", err
+		}
+		result = append(result, queuePolicy)
+	}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		statements := []iamPolicyStatement{}
+		if len(serviceQueueNames[serviceRole]) > 0 {
+			sort.Strings(serviceQueueNames[serviceRole])
+			queueArns := []string{}
+			for _, queueName := range serviceQueueNames[serviceRole] {
+				queueArns = append(queueArns, fmt.Sprintf("${aws_sqs_queue.%s.arn}", util.ToSnake(queueName)))
+			}
+			statements = append(statements, iamPolicyStatement{Effect: "Allow", Action: sqsQueueActions, Resource: queueArns})
+		}
+		if len(serviceTopicNames[serviceRole]) > 0 {
+			sort.Strings(serviceTopicNames[serviceRole])
+			topicArns := []string{}
+			for _, topicName := range serviceTopicNames[serviceRole] {
+				topicArns = append(topicArns, fmt.Sprintf("${aws_sns_topic.%s.arn}", util.ToSnake(topicName)))
+			}
+			statements = append(statements, iamPolicyStatement{Effect: "Allow", Action: []string{"sns:Publish"}, Resource: topicArns})
+		}
+		if len(statements) == 0 {
+			continue
+		}
+		policy, err := generateTaskRolePolicy(appContext, serviceRole, "queue", statements)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, policy)
+	}
+	return strings.Join(result, "\n"), nil
+}
+
+func generateSqsQueue(queue types.Queue, appName string) (string, error) {
+	context := map[string]interface{}{
+		"queueName":    queue.GetQueueName(appName),
+		"resourceName": util.ToSnake(queue.Name),
+	}
+	if queue.VisibilityTimeout != "" {
+		context["visibilityTimeout"] = queue.VisibilityTimeout
+	}
+	if queue.MessageRetentionPeriod != "" {
+		context["messageRetentionPeriod"] = queue.MessageRetentionPeriod
+	}
+	if queue.DeadLetter.Queue != "" {
+		context["deadLetterQueue"] = util.ToSnake(queue.DeadLetter.Queue)
+		context["maxReceiveCount"] = queue.DeadLetter.GetMaxReceiveCount()
+	}
+	return renderTemplateWithSections("sqs_queue.tf", context)
+}
+
+func generateSnsTopic(topic types.QueueTopic, appName string) (string, error) {
+	subscriptions := []map[string]string{}
+	for _, queueName := range topic.Subscriptions {
+		subscriptions = append(subscriptions, map[string]string{"queueResourceName": util.ToSnake(queueName)})
+	}
+	return renderTemplateWithSections("sns_topic.tf", map[string]interface{}{
+		"resourceName":  util.ToSnake(topic.Name),
+		"subscriptions": subscriptions,
+		"topicName":     topic.GetTopicName(appName),
+	})
+}
+
+// generates the policy that allows the given topics to deliver messages to the given queue
+func generateSqsQueuePolicy(queueName string, topicNames []string) (string, error) {
+	topicArns := []string{}
+	for _, topicName := range topicNames {
+		topicArns = append(topicArns, fmt.Sprintf("${aws_sns_topic.%s.arn}", util.ToSnake(topicName)))
+	}
+	queueArn := fmt.Sprintf("${aws_sqs_queue.%s.arn}", util.ToSnake(queueName))
+	policy, err := renderIamPolicy([]iamPolicyStatement{
+		{
+			Effect:    "Allow",
+			Principal: "*",
+			Action:    []string{"sqs:SendMessage"},
+			Resource:  []string{queueArn},
+			Condition: map[string]map[string][]string{
+				"ArnEquals": {"aws:SourceArn": topicArns},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return RenderTemplates("sqs_queue_policy.tf", map[string]string{
+		"policy":       policy,
+		"resourceName": util.ToSnake(queueName),
+	})
+}
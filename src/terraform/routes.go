@@ -0,0 +1,50 @@
+package terraform
+
+import (
+	"strconv"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// generates the ALB the public services with routes share, if there are any. Its listener forwards
+// the requests to the services by the host and path of their routes, which point its DNS records at it
+func generateSharedAlbModule(deployConfig deploy.Config) (string, error) {
+	appURL := deployConfig.AppContext.Config.Remote.URL
+	dnsNames := []string{}
+	for _, serviceRole := range deployConfig.AppContext.Config.GetSortedServiceRoles() {
+		for _, route := range deployConfig.AppContext.ServiceContexts[serviceRole].Config.Remote.Routes {
+			host := route.GetHost(appURL)
+			if !util.DoesStringArrayContain(dnsNames, host) {
+				dnsNames = append(dnsNames, host)
+			}
+		}
+	}
+	if len(dnsNames) == 0 {
+		return "", nil
+	}
+	varsMap := map[string]string{
+		"appName":             deployConfig.AppContext.Config.Name,
+		"dnsNames":            toHCLList(dnsNames),
+		"sslCertificateArn":   deployConfig.AwsConfig.SslCertificateArn,
+		"terraformCommitHash": TerraformModulesRef,
+	}
+	return RenderTemplates("shared_alb.tf", varsMap)
+}
+
+// adds the host patterns, path patterns and priorities of the listener rules of the given routes
+// to the variables of the template of a service
+func addRouteVars(varsMap map[string]string, routes []types.ServiceRoute, appURL string) {
+	hosts := []string{}
+	pathPatterns := []string{}
+	priorities := []string{}
+	for _, route := range routes {
+		hosts = append(hosts, route.GetHost(appURL))
+		pathPatterns = append(pathPatterns, route.GetPathPattern())
+		priorities = append(priorities, strconv.Itoa(route.Priority))
+	}
+	varsMap["routeHosts"] = toHCLList(hosts)
+	varsMap["routePathPatterns"] = toHCLList(pathPatterns)
+	varsMap["routePriorities"] = toHCLList(priorities)
+}
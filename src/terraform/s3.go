@@ -0,0 +1,117 @@
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// generates the buckets of all s3 dependencies and a policy for each service
+// that allows it to access the buckets declared in application.yml and its own service.yml
+func generateS3Modules(deployConfig deploy.Config) (string, error) {
+	appContext := deployConfig.AppContext
+	if _, ok := appContext.DependencyDefinitions["s3"]; ok {
+		return "", nil
+	}
+	buckets := map[string]types.S3Bucket{}
+	for _, dependency := range appContext.Config.Remote.Dependencies {
+		if dependency.Name == "s3" {
+			for _, bucket := range dependency.Config.S3.Buckets {
+				buckets[bucket.Name] = bucket
+			}
+		}
+	}
+	serviceBucketNames := map[string][]string{}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		for _, dependency := range getServiceRemoteDependencies(appContext, serviceRole, "s3") {
+			for _, bucket := range dependency.Config.S3.Buckets {
+				buckets[bucket.Name] = bucket
+				if !util.DoesStringArrayContain(serviceBucketNames[serviceRole], bucket.Name) {
+					serviceBucketNames[serviceRole] = append(serviceBucketNames[serviceRole], bucket.Name)
+				}
+			}
+		}
+	}
+	bucketNames := []string{}
+	for bucketName := range buckets {
+		bucketNames = append(bucketNames, bucketName)
+	}
+	sort.Strings(bucketNames)
+	result := []string{}
+	for _, bucketName := range bucketNames {
+		bucket, err := generateS3Bucket(buckets[bucketName], appContext.Config.Name)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, bucket)
+	}
+	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
+		if len(serviceBucketNames[serviceRole]) == 0 {
+			continue
+		}
+		sort.Strings(serviceBucketNames[serviceRole])
+		bucketArns := []string{}
+		objectArns := []string{}
+		for _, bucketName := range serviceBucketNames[serviceRole] {
+			bucketArn := fmt.Sprintf("${aws_s3_bucket.%s.arn}", getS3BucketResourceName(bucketName))
+			bucketArns = append(bucketArns, bucketArn)
+			objectArns = append(objectArns, bucketArn+"/*")
+		}
+		policy, err := generateTaskRolePolicy(appContext, serviceRole, "s3", []iamPolicyStatement{
+			{Effect: "Allow", Action: []string{"s3:ListBucket"}, Resource: bucketArns},
+			{Effect: "Allow", Action: []string{"s3:DeleteObject", "s3:GetObject", "s3:PutObject"}, Resource: objectArns},
+		})
+		if err != nil {
+			return "", err
+		}
+		result = append(result, policy)
+	}
+	return strings.Join(result, "\n"), nil
+}
+
+func generateS3Bucket(bucket types.S3Bucket, appName string) (string, error) {
+	corsRules := []map[string]string{}
+	for _, rule := range bucket.Cors {
+		ruleVars := map[string]string{
+			"allowedMethods": toHCLList(rule.AllowedMethods),
+			"allowedOrigins": toHCLList(rule.AllowedOrigins),
+		}
+		if len(rule.AllowedHeaders) > 0 {
+			ruleVars["allowedHeaders"] = toHCLList(rule.AllowedHeaders)
+		}
+		if rule.MaxAgeSeconds != "" {
+			ruleVars["maxAgeSeconds"] = rule.MaxAgeSeconds
+		}
+		corsRules = append(corsRules, ruleVars)
+	}
+	lifecycleRules := []map[string]string{}
+	for i, lifecycle := range bucket.Lifecycle {
+		lifecycleRules = append(lifecycleRules, map[string]string{
+			"expirationDays": lifecycle.ExpirationDays,
+			"id":             fmt.Sprintf("%s-expiration-%d", bucket.Name, i),
+			"prefix":         lifecycle.Prefix,
+		})
+	}
+	return renderTemplateWithSections("s3_bucket.tf", map[string]interface{}{
+		"bucketName":     bucket.GetBucketName(appName),
+		"corsRules":      corsRules,
+		"lifecycleRules": lifecycleRules,
+		"resourceName":   getS3BucketResourceName(bucket.Name),
+	})
+}
+
+func getS3BucketResourceName(bucketName string) string {
+	return util.ToSnake(bucketName)
+}
+
+func toHCLList(values []string) string {
+	quotedValues := []string{}
+	for _, value := range values {
+		quotedValues = append(quotedValues, fmt.Sprintf("%q", value))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(quotedValues, ", "))
+}
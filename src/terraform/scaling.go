@@ -0,0 +1,55 @@
+package terraform
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/deploy"
+	"github.com/Originate/exosphere/src/util"
+)
+
+// the predefined metrics of Application Auto Scaling the scaling policies track
+var predefinedScalingMetrics = map[string]string{
+	types.ScalingMetricCPU:      "ECSServiceAverageCPUUtilization",
+	types.ScalingMetricMemory:   "ECSServiceAverageMemoryUtilization",
+	types.ScalingMetricRequests: "ALBRequestCountPerTarget",
+}
+
+// generates the scalable target of the ECS service of the given service, which keeps its number of tasks
+// between the configured bounds, and a target tracking policy for each of its scaling policies. Queue depth policies track the number of visible messages
+// in a queue declared by a queue dependency the service uses
+func generateScalingResources(serviceRole string, deployConfig deploy.Config) (string, error) {
+	scalingConfig := deployConfig.AppContext.ServiceContexts[serviceRole].Config.Remote.Scaling
+	queueNames := []string{}
+	for _, dependency := range getServiceRemoteDependencies(deployConfig.AppContext, serviceRole, "queue") {
+		for _, queue := range dependency.Config.Queue.Queues {
+			queueNames = append(queueNames, queue.Name)
+		}
+	}
+	policies := []map[string]interface{}{}
+	for _, policy := range scalingConfig.Policies {
+		if policy.Metric == types.ScalingMetricQueueDepth && !util.DoesStringArrayContain(queueNames, policy.Queue) {
+			return "", fmt.Errorf("the queue-depth scaling policy of %s tracks the queue '%s', which no queue dependency of the service declares", serviceRole, policy.Queue)
+		}
+		policyVars := map[string]interface{}{
+			"name":         fmt.Sprintf("%s-%s", serviceRole, policy.GetName()),
+			"resourceName": fmt.Sprintf("%s_%s", serviceRole, util.ToSnake(policy.GetName())),
+			"targetValue":  strconv.Itoa(policy.Target),
+		}
+		if policy.Metric == types.ScalingMetricQueueDepth {
+			policyVars["queueResourceName"] = util.ToSnake(policy.Queue)
+		} else {
+			policyVars["predefinedMetricType"] = predefinedScalingMetrics[policy.Metric]
+			policyVars["albResourceLabel"] = policy.Metric == types.ScalingMetricRequests
+		}
+		policies = append(policies, policyVars)
+	}
+	return renderTemplateWithSections("scaling.tf", map[string]interface{}{
+		"clusterName": GetClusterName(deployConfig.AppContext.Config.Name),
+		"maxCapacity": strconv.Itoa(scalingConfig.GetMax()),
+		"minCapacity": strconv.Itoa(scalingConfig.GetMin()),
+		"policies":    policies,
+		"serviceRole": serviceRole,
+	})
+}
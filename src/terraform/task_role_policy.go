@@ -0,0 +1,77 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/src/types/context"
+)
+
+type iamPolicyDocument struct {
+	Version   string
+	Statement []iamPolicyStatement
+}
+
+type iamPolicyStatement struct {
+	Effect    string
+	Principal string `json:",omitempty"`
+	Action    []string
+	Resource  []string
+	Condition map[string]map[string][]string `json:",omitempty"`
+}
+
+func renderIamPolicy(statements []iamPolicyStatement) (string, error) {
+	policy, err := json.MarshalIndent(iamPolicyDocument{
+		Version:   "2012-10-17",
+		Statement: statements,
+	}, "", "  ")
+	return string(policy), err
+}
+
+// generates a policy attached to the task role of the given service
+// which allows the actions of the given statements only.
+// The migrations of the service run with the same policy, since they usually access the same resources
+func generateTaskRolePolicy(appContext *context.AppContext, serviceRole, name string, statements []iamPolicyStatement) (string, error) {
+	policy, err := renderIamPolicy(statements)
+	if err != nil {
+		return "", err
+	}
+	result, err := renderTaskRolePolicy(serviceRole, fmt.Sprintf("%s_%s", serviceRole, name), fmt.Sprintf("%s-%s", serviceRole, name), policy)
+	if err != nil {
+		return "", err
+	}
+	if serviceContext, ok := appContext.ServiceContexts[serviceRole]; ok && serviceContext.Config.Migrations.IsConfigured() {
+		migrationsPolicy, err := renderTaskRolePolicy(getMigrationsModuleName(serviceRole), fmt.Sprintf("%s_migrations_%s", serviceRole, name), fmt.Sprintf("%s-migrations-%s", serviceRole, name), policy)
+		if err != nil {
+			return "", err
+		}
+		result = fmt.Sprintf("%s\n%s", result, migrationsPolicy)
+	}
+	return result, nil
+}
+
+func renderTaskRolePolicy(moduleName, resourceName, policyName, policy string) (string, error) {
+	return RenderTemplates("task_role_policy.tf", map[string]string{
+		"moduleName":   moduleName,
+		"policy":       policy,
+		"policyName":   policyName,
+		"resourceName": resourceName,
+	})
+}
+
+// returns the remote dependencies with the given name a service uses,
+// i.e. the ones declared in application.yml and in its own service.yml
+func getServiceRemoteDependencies(appContext *context.AppContext, serviceRole, name string) []types.RemoteDependency {
+	result := []types.RemoteDependency{}
+	dependencies := appContext.Config.Remote.Dependencies
+	if serviceContext, ok := appContext.ServiceContexts[serviceRole]; ok {
+		dependencies = append(dependencies, serviceContext.Config.Remote.Dependencies...)
+	}
+	for _, dependency := range dependencies {
+		if dependency.Name == name {
+			result = append(result, dependency)
+		}
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LogStreamPrefix is the prefix of the CloudWatch log streams of the tasks of the services
+// not deployed with the blue-green or canary strategy. Those prefix their log streams with the color
+const LogStreamPrefix = "ecs"
+
+// GetTaskDefinitionFamily returns the family of the task definition of the given service
+func GetTaskDefinitionFamily(serviceRole string) string {
+	return fmt.Sprintf("production-%s", serviceRole)
+}
+
+// GetContainerName returns the name of the container in the task definitions of the given service
+func GetContainerName(serviceRole string) string {
+	return fmt.Sprintf("production-%s", serviceRole)
+}
+
+// GetLogGroupName returns the name of the CloudWatch log group the tasks of the given service log to
+func GetLogGroupName(serviceRole string) string {
+	return fmt.Sprintf("services/production/%s", GetContainerName(serviceRole))
+}
+
+// GetLogStreamName returns the name of the CloudWatch log stream of the given task of the given service,
+// which the awslogs driver names after the prefix, the container and the ID of the task
+func GetLogStreamName(logStreamPrefix, serviceRole, taskArn string) string {
+	taskID := taskArn[strings.LastIndex(taskArn, "/")+1:]
+	return fmt.Sprintf("%s/%s/%s", logStreamPrefix, GetContainerName(serviceRole), taskID)
+}
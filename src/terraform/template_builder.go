@@ -24,6 +24,16 @@ func RenderTemplates(templateName string, varsMap map[string]string) (string, er
 	return mustache.Render(template, varsMap), nil
 }
 
+// renders a Terraform template whose context contains lists and optional values,
+// which the template iterates over and checks with sections
+func renderTemplateWithSections(templateName string, context map[string]interface{}) (string, error) {
+	template, err := getTemplate(templateName)
+	if err != nil {
+		return "", err
+	}
+	return mustache.Render(template, context), nil
+}
+
 // WriteTerraformFile writes the main Terraform file to the given path
 func WriteTerraformFile(data string, terraformDir string) error {
 	err := util.MakeDirectory(terraformDir)
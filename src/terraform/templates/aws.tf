@@ -30,6 +30,9 @@ module "aws" {
 
   name              = "{{appName}}"
   env               = "production"
+  ecs_instance_type = "{{instanceType}}"
+  ecs_max_size      = {{clusterMaxSize}}
+  ecs_min_size      = {{clusterMinSize}}
   external_dns_name = "{{{url}}}"
   key_name          = "${var.key_name}"
 }
@@ -0,0 +1,12 @@
+{{#secrets}}
+variable "{{name}}" {}
+
+{{/secrets}}{{#modules}}
+{{#leadingBlankLine}}
+
+{{/leadingBlankLine}}module "{{name}}" {
+  source = {{{source}}}
+{{#variables}}
+  {{name}} = {{{value}}}
+{{/variables}}}
+{{/modules}}
\ No newline at end of file
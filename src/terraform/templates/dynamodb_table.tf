@@ -0,0 +1,29 @@
+resource "aws_dynamodb_table" "{{resourceName}}" {
+  name           = "{{tableName}}"
+  hash_key       = "{{hashKey}}"
+{{#rangeKey}}
+  range_key      = "{{rangeKey}}"
+{{/rangeKey}}  read_capacity  = {{readCapacity}}
+  write_capacity = {{writeCapacity}}
+{{#attributes}}
+
+  attribute {
+    name = "{{name}}"
+    type = "{{type}}"
+  }
+{{/attributes}}{{#indexes}}
+
+  global_secondary_index {
+    name            = "{{name}}"
+    hash_key        = "{{hashKey}}"
+{{#indexRangeKey}}
+    range_key       = "{{indexRangeKey}}"
+{{/indexRangeKey}}    projection_type = "{{projectionType}}"
+    read_capacity   = {{readCapacity}}
+    write_capacity  = {{writeCapacity}}
+  }
+{{/indexes}}
+  tags {
+    env = "production"
+  }
+}
@@ -0,0 +1,13 @@
+module "{{serviceRole}}_migrations" {
+  source = "github.com/Originate/exosphere.git//terraform//aws//ecs-task-definition?ref={{terraformCommitHash}}"
+
+  app_name              = "{{appName}}"
+  command               = {{{command}}}
+  cpu                   = "{{cpu}}"
+  docker_image          = "${var.{{serviceRole}}_docker_image}"
+  env                   = "production"
+  environment_variables = "${var.{{serviceRole}}_env_vars}"
+  memory_reservation    = "{{memory}}"
+  name                  = "production-{{serviceRole}}-migrations"
+  region                = "${module.aws.region}"
+}
@@ -0,0 +1,47 @@
+module "nats_cluster" {
+  source = "github.com/Originate/exosphere.git//terraform//aws//dependencies//nats//nats-cluster?ref={{terraformCommitHash}}"
+
+  app_name                = "{{appName}}"
+  availability_zones      = "${module.aws.availability_zones}"
+  cluster_size            = "{{clusterSize}}"
+  env                     = "production"
+  internal_hosted_zone_id = "${module.aws.internal_zone_id}"
+  instance_type           = "t2.micro"
+  key_name                = "${var.key_name}"
+  name                    = "nats"
+  region                  = "${module.aws.region}"
+
+  bastion_security_group = ["${module.aws.bastion_security_group}"]
+
+  ecs_cluster_security_groups = ["${module.aws.ecs_cluster_security_group}"]
+
+  subnet_ids = "${module.aws.private_subnet_ids}"
+  vpc_id     = "${module.aws.vpc_id}"
+}
+
+variable "nats_env_vars" {
+  default = ""
+}
+
+variable "nats_docker_image" {}
+
+variable "{{authTokenVariable}}" {
+  default = ""
+}
+
+module "nats_service" {
+  source = "github.com/Originate/exosphere.git//terraform//aws//dependencies//nats//nats-service?ref={{terraformCommitHash}}"
+
+  app_name              = "{{appName}}"
+  auth_token            = "${var.{{authTokenVariable}}}"
+  cluster_id            = "${module.nats_cluster.cluster_id}"
+  cluster_size          = "{{clusterSize}}"
+  cpu_units             = "128"
+  dns_name              = "${module.nats_cluster.nats_address}"
+  docker_image          = "${var.nats_docker_image}"
+  env                   = "production"
+  environment_variables = "${var.nats_env_vars}"
+  memory_reservation    = "128"
+  name                  = "nats"
+  region                = "${module.aws.region}"
+}
@@ -11,10 +11,11 @@ module "{{serviceRole}}" {
 
   alb_security_group    = "${module.aws.external_alb_security_group}"
   alb_subnet_ids        = ["${module.aws.public_subnet_ids}"]
+  app_name              = "{{appName}}"
   cluster_id            = "${module.aws.ecs_cluster_id}"
   container_port        = "{{publicPort}}"
   cpu                   = "{{cpu}}"
-  desired_count         = 1
+  desired_count         = {{desiredCount}}
   docker_image          = "${var.{{serviceRole}}_docker_image}"
   ecs_role_arn          = "${module.aws.ecs_service_iam_role_arn}"
   env                   = "production"
@@ -0,0 +1,30 @@
+variable "{{serviceRole}}_env_vars" {
+  default = "[]"
+}
+
+variable "{{serviceRole}}_docker_image" {}
+
+module "{{serviceRole}}" {
+  source = "github.com/Originate/exosphere.git//terraform//aws//public-service-routed?ref={{terraformCommitHash}}"
+
+  name = "{{serviceRole}}"
+
+  alb_arn_suffix        = "${module.shared_alb.arn_suffix}"
+  app_name              = "{{appName}}"
+  cluster_id            = "${module.aws.ecs_cluster_id}"
+  container_port        = "{{publicPort}}"
+  cpu                   = "{{cpu}}"
+  desired_count         = {{desiredCount}}
+  docker_image          = "${var.{{serviceRole}}_docker_image}"
+  ecs_role_arn          = "${module.aws.ecs_service_iam_role_arn}"
+  env                   = "production"
+  environment_variables = "${var.{{serviceRole}}_env_vars}"
+  health_check_endpoint = "{{{healthCheck}}}"
+  listener_arn          = "${module.shared_alb.listener_arn}"
+  memory_reservation    = "{{memory}}"
+  region                = "${module.aws.region}"
+  route_hosts           = {{{routeHosts}}}
+  route_path_patterns   = {{{routePathPatterns}}}
+  route_priorities      = {{{routePriorities}}}
+  vpc_id                = "${module.aws.vpc_id}"
+}
@@ -0,0 +1,16 @@
+module "{{name}}_elasticache" {
+  source = "github.com/Originate/exosphere.git//terraform//aws//dependencies//elasticache?ref={{terraformCommitHash}}"
+
+  app_name                = "{{appName}}"
+  bastion_security_group  = "${module.aws.bastion_security_group}"
+  ecs_security_group      = "${module.aws.ecs_cluster_security_group}"
+  engine_version          = "{{engineVersion}}"
+  env                     = "production"
+  internal_hosted_zone_id = "${module.aws.internal_zone_id}"
+  name                    = "{{name}}"
+  node_type               = "{{nodeType}}"
+  num_cache_clusters      = "{{numCacheClusters}}"
+  replication_group_id    = "{{replicationGroupID}}"
+  subnet_ids              = ["${module.aws.private_subnet_ids}"]
+  vpc_id                  = "${module.aws.vpc_id}"
+}
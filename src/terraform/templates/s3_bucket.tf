@@ -0,0 +1,29 @@
+resource "aws_s3_bucket" "{{resourceName}}" {
+  bucket = "{{bucketName}}"
+{{#corsRules}}
+
+  cors_rule {
+    allowed_origins = {{{allowedOrigins}}}
+    allowed_methods = {{{allowedMethods}}}
+{{#allowedHeaders}}
+    allowed_headers = {{{allowedHeaders}}}
+{{/allowedHeaders}}{{#maxAgeSeconds}}
+    max_age_seconds = {{maxAgeSeconds}}
+{{/maxAgeSeconds}}  }
+{{/corsRules}}{{#lifecycleRules}}
+
+  lifecycle_rule {
+    id      = "{{id}}"
+    prefix  = "{{prefix}}"
+    enabled = true
+
+    expiration {
+      days = {{expirationDays}}
+    }
+  }
+{{/lifecycleRules}}
+  tags {
+    Name        = "{{bucketName}}"
+    Environment = "production"
+  }
+}
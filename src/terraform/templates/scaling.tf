@@ -0,0 +1,40 @@
+resource "aws_appautoscaling_target" "{{serviceRole}}" {
+  max_capacity       = {{maxCapacity}}
+  min_capacity       = {{minCapacity}}
+  resource_id        = "service/{{clusterName}}/${module.{{serviceRole}}.service_name}"
+  role_arn           = "${module.aws.ecs_autoscale_iam_role_arn}"
+  scalable_dimension = "ecs:service:DesiredCount"
+  service_namespace  = "ecs"
+}
+{{#policies}}
+
+resource "aws_appautoscaling_policy" "{{resourceName}}" {
+  name               = "{{name}}"
+  policy_type        = "TargetTrackingScaling"
+  resource_id        = "${aws_appautoscaling_target.{{serviceRole}}.resource_id}"
+  scalable_dimension = "${aws_appautoscaling_target.{{serviceRole}}.scalable_dimension}"
+  service_namespace  = "${aws_appautoscaling_target.{{serviceRole}}.service_namespace}"
+
+  target_tracking_scaling_policy_configuration {
+    target_value = {{targetValue}}
+
+{{#queueResourceName}}
+    customized_metric_specification {
+      metric_name = "ApproximateNumberOfMessagesVisible"
+      namespace   = "AWS/SQS"
+      statistic   = "Average"
+
+      dimensions {
+        name  = "QueueName"
+        value = "${aws_sqs_queue.{{queueResourceName}}.name}"
+      }
+    }
+{{/queueResourceName}}{{#predefinedMetricType}}
+    predefined_metric_specification {
+      predefined_metric_type = "{{predefinedMetricType}}"
+{{#albResourceLabel}}
+      resource_label         = "${module.{{serviceRole}}.alb_resource_label}"
+{{/albResourceLabel}}    }
+{{/predefinedMetricType}}  }
+}
+{{/policies}}
\ No newline at end of file
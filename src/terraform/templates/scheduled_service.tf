@@ -0,0 +1,21 @@
+variable "{{serviceRole}}_env_vars" {
+  default = "[]"
+}
+
+variable "{{serviceRole}}_docker_image" {}
+
+module "{{serviceRole}}" {
+  source = "github.com/Originate/exosphere.git//terraform//aws//scheduled-service?ref={{terraformCommitHash}}"
+
+  name = "{{serviceRole}}"
+
+  app_name              = "{{appName}}"
+  cluster_id            = "${module.aws.ecs_cluster_id}"
+  cpu                   = "{{cpu}}"
+  docker_image          = "${var.{{serviceRole}}_docker_image}"
+  env                   = "production"
+  environment_variables = "${var.{{serviceRole}}_env_vars}"
+  memory_reservation    = "{{memory}}"
+  region                = "${module.aws.region}"
+  schedule_expression   = "{{{scheduleExpression}}}"
+}
@@ -0,0 +1,14 @@
+module "shared_alb" {
+  source = "github.com/Originate/exosphere.git//terraform//aws//shared-alb?ref={{terraformCommitHash}}"
+
+  name = "{{appName}}-routes"
+
+  alb_security_group  = "${module.aws.external_alb_security_group}"
+  alb_subnet_ids      = ["${module.aws.public_subnet_ids}"]
+  env                 = "production"
+  external_dns_names  = {{{dnsNames}}}
+  external_zone_id    = "${module.aws.external_zone_id}"
+  log_bucket          = "${module.aws.log_bucket_id}"
+  ssl_certificate_arn = "{{{sslCertificateArn}}}"
+  vpc_id              = "${module.aws.vpc_id}"
+}
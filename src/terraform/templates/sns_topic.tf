@@ -0,0 +1,11 @@
+resource "aws_sns_topic" "{{resourceName}}" {
+  name = "{{topicName}}"
+}
+{{#subscriptions}}
+
+resource "aws_sns_topic_subscription" "{{resourceName}}_{{queueResourceName}}" {
+  topic_arn = "${aws_sns_topic.{{resourceName}}.arn}"
+  protocol  = "sqs"
+  endpoint  = "${aws_sqs_queue.{{queueResourceName}}.arn}"
+}
+{{/subscriptions}}
\ No newline at end of file
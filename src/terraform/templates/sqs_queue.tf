@@ -0,0 +1,14 @@
+resource "aws_sqs_queue" "{{resourceName}}" {
+  name = "{{queueName}}"
+{{#visibilityTimeout}}
+  visibility_timeout_seconds = {{visibilityTimeout}}
+{{/visibilityTimeout}}{{#messageRetentionPeriod}}
+  message_retention_seconds = {{messageRetentionPeriod}}
+{{/messageRetentionPeriod}}{{#deadLetterQueue}}
+  redrive_policy = "{\"deadLetterTargetArn\":\"${aws_sqs_queue.{{deadLetterQueue}}.arn}\",\"maxReceiveCount\":{{maxReceiveCount}}}"
+{{/deadLetterQueue}}
+  tags {
+    Name        = "{{queueName}}"
+    Environment = "production"
+  }
+}
@@ -0,0 +1,7 @@
+resource "aws_sqs_queue_policy" "{{resourceName}}" {
+  queue_url = "${aws_sqs_queue.{{resourceName}}.id}"
+
+  policy = <<EOF
+{{{policy}}}
+EOF
+}
@@ -0,0 +1,8 @@
+resource "aws_iam_role_policy" "{{resourceName}}" {
+  name = "{{policyName}}"
+  role = "${module.{{moduleName}}.task_role_name}"
+
+  policy = <<EOF
+{{{policy}}}
+EOF
+}
@@ -9,9 +9,10 @@ module "{{serviceRole}}" {
 
   name = "{{serviceRole}}"
 
+  app_name              = "{{appName}}"
   cluster_id            = "${module.aws.ecs_cluster_id}"
   cpu                   = "{{cpu}}"
-  desired_count         = 1
+  desired_count         = {{desiredCount}}
   docker_image          = "${var.{{serviceRole}}_docker_image}"
   env                   = "production"
   environment_variables = "${var.{{serviceRole}}_env_vars}"
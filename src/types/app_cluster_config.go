@@ -0,0 +1,117 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// the CPU units and memory in MiB of the EC2 instance types commonly used in ECS clusters
+var instanceTypeResources = map[string]struct{ cpu, memory int }{
+	"t2.nano":    {1024, 512},
+	"t2.micro":   {1024, 1024},
+	"t2.small":   {1024, 2048},
+	"t2.medium":  {2048, 4096},
+	"t2.large":   {2048, 8192},
+	"t2.xlarge":  {4096, 16384},
+	"t2.2xlarge": {8192, 32768},
+	"m4.large":   {2048, 8192},
+	"m4.xlarge":  {4096, 16384},
+	"m4.2xlarge": {8192, 32768},
+	"m4.4xlarge": {16384, 65536},
+	"c4.large":   {2048, 3840},
+	"c4.xlarge":  {4096, 7680},
+	"c4.2xlarge": {8192, 15360},
+	"c4.4xlarge": {16384, 30720},
+	"r4.large":   {2048, 15616},
+	"r4.xlarge":  {4096, 31232},
+	"r4.2xlarge": {8192, 62464},
+}
+
+// AppClusterConfig represents the ECS cluster running the services
+// as provided under remote.cluster in application.yml.
+// MinSize is a pointer since a cluster without a minimum of instances is valid
+type AppClusterConfig struct {
+	InstanceType string `yaml:"instance-type,omitempty"`
+	MinSize      *int   `yaml:"min-size,omitempty"`
+	MaxSize      int    `yaml:"max-size,omitempty"`
+}
+
+// GetInstanceType returns the EC2 instance type of the cluster, defaulting to t2.micro
+func (c AppClusterConfig) GetInstanceType() string {
+	if c.InstanceType == "" {
+		return "t2.micro"
+	}
+	return c.InstanceType
+}
+
+// GetMinSize returns the minimum number of instances, defaulting to 3
+func (c AppClusterConfig) GetMinSize() int {
+	if c.MinSize == nil {
+		return 3
+	}
+	return *c.MinSize
+}
+
+// GetMaxSize returns the maximum number of instances, defaulting to 100
+func (c AppClusterConfig) GetMaxSize() int {
+	if c.MaxSize == 0 {
+		return 100
+	}
+	return c.MaxSize
+}
+
+// ValidateFields validates that the cluster config contains valid fields
+func (c AppClusterConfig) ValidateFields() error {
+	if c.GetMinSize() < 0 || c.MaxSize < 0 {
+		return errors.New("the sizes in 'remote.cluster' must be positive")
+	}
+	if c.GetMaxSize() < c.GetMinSize() {
+		return fmt.Errorf("'remote.cluster.max-size' (%d) must not be less than 'remote.cluster.min-size' (%d)", c.GetMaxSize(), c.GetMinSize())
+	}
+	return nil
+}
+
+// ValidateCapacity validates that the tasks of the given services fit on the instances of the cluster,
+// and that the cluster can run the maximum number of tasks of all services at once.
+// Services deployed with the blue-green or canary strategy run twice while a deploy shifts traffic.
+// It can only validate instance types it knows the resources of
+func (c AppClusterConfig) ValidateCapacity(serviceRemoteConfigs map[string]ServiceRemoteConfig) error {
+	resources, ok := instanceTypeResources[c.GetInstanceType()]
+	if !ok {
+		return nil
+	}
+	serviceRoles := []string{}
+	for serviceRole := range serviceRemoteConfigs {
+		serviceRoles = append(serviceRoles, serviceRole)
+	}
+	sort.Strings(serviceRoles)
+	totalCPU, totalMemory := 0, 0
+	for _, serviceRole := range serviceRoles {
+		remoteConfig := serviceRemoteConfigs[serviceRole]
+		cpu, err := strconv.Atoi(remoteConfig.CPU)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' in field 'remote.cpu' of %s. Must be a number of CPU units", remoteConfig.CPU, serviceRole)
+		}
+		memory, err := strconv.Atoi(remoteConfig.Memory)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' in field 'remote.memory' of %s. Must be a number of MiB", remoteConfig.Memory, serviceRole)
+		}
+		if cpu > resources.cpu || memory > resources.memory {
+			return fmt.Errorf("a task of %s needs %d CPU units and %d MiB of memory, which do not fit on a %s instance (%d CPU units, %d MiB)", serviceRole, cpu, memory, c.GetInstanceType(), resources.cpu, resources.memory)
+		}
+		taskCount := remoteConfig.Scaling.GetMax()
+		if remoteConfig.Deploy.ShiftsTraffic() {
+			taskCount *= 2
+		}
+		totalCPU += taskCount * cpu
+		totalMemory += taskCount * memory
+	}
+	maxCPU, maxMemory := c.GetMaxSize()*resources.cpu, c.GetMaxSize()*resources.memory
+	if totalCPU > maxCPU || totalMemory > maxMemory {
+		return fmt.Errorf("the services need up to %d CPU units and %d MiB of memory, more than the %d %s instances of the cluster provide (%d CPU units, %d MiB). Lower 'remote.scaling.max' of the services or raise 'remote.cluster.max-size' in application.yml", totalCPU, totalMemory, c.GetMaxSize(), c.GetInstanceType(), maxCPU, maxMemory)
+	}
+	return nil
+}
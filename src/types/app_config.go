@@ -21,6 +21,7 @@ type AppConfig struct {
 	Remote      AppRemoteConfig `yaml:",omitempty"`
 	Services    map[string]ServiceSource
 	Templates   map[string]string `yaml:",omitempty"`
+	Lint        LintConfig        `yaml:",omitempty"`
 }
 
 // NewAppConfig reads application.yml and returns the appConfig object
@@ -87,5 +88,13 @@ func (a AppConfig) validateAppConfig() error {
 			err = fmt.Errorf("The service key 'services.%s' in application.yml is invalid. Only alphanumeric character(s) separated by a single hyphen are allowed. Must match regex: /^[a-zA-Z0-9]+(-[a-zA-Z0-9]+)*$/", serviceRole)
 		}
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	for _, dependency := range a.Local.Dependencies {
+		if err := dependency.ValidateFields(); err != nil {
+			return err
+		}
+	}
+	return a.Local.E2E.ValidateFields()
 }
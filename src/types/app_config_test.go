@@ -5,6 +5,7 @@ import (
 	"github.com/Originate/exosphere/test/helpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v2"
 )
 
 var _ = Describe("AppConfig", func() {
@@ -161,4 +162,68 @@ var _ = Describe("AppConfig", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	var _ = Describe("cluster size", func() {
+		It("should default to 3 to 100 instances", func() {
+			cluster := types.AppClusterConfig{}
+			Expect(cluster.GetMinSize()).To(Equal(3))
+			Expect(cluster.GetMaxSize()).To(Equal(100))
+		})
+
+		It("should accept a cluster without a minimum of instances", func() {
+			var cluster types.AppClusterConfig
+			Expect(yaml.Unmarshal([]byte("min-size: 0\nmax-size: 4"), &cluster)).To(Succeed())
+			Expect(cluster.GetMinSize()).To(Equal(0))
+			Expect(cluster.ValidateFields()).To(Succeed())
+		})
+
+		It("should throw an error for negative sizes", func() {
+			minSize := -1
+			err := types.AppClusterConfig{MinSize: &minSize}.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the sizes in 'remote.cluster' must be positive"))
+		})
+	})
+
+	var _ = Describe("cluster capacity", func() {
+		cluster := types.AppClusterConfig{InstanceType: "t2.small", MaxSize: 2}
+
+		It("should accept services that fit on the cluster at their maximum", func() {
+			err := cluster.ValidateCapacity(map[string]types.ServiceRemoteConfig{
+				"web":    {CPU: "256", Memory: "512", Scaling: types.ServiceScalingConfig{Max: 4}},
+				"worker": {CPU: "128", Memory: "128"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should throw an error if a task does not fit on an instance", func() {
+			err := cluster.ValidateCapacity(map[string]types.ServiceRemoteConfig{
+				"web": {CPU: "256", Memory: "4096"},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("a task of web needs 256 CPU units and 4096 MiB of memory, which do not fit on a t2.small instance"))
+		})
+
+		It("should throw an error if the services do not fit on the cluster at their maximum", func() {
+			err := cluster.ValidateCapacity(map[string]types.ServiceRemoteConfig{
+				"web": {CPU: "512", Memory: "512", Scaling: types.ServiceScalingConfig{Max: 5}},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the services need up to 2560 CPU units and 2560 MiB of memory, more than the 2 t2.small instances of the cluster provide"))
+		})
+
+		It("should reserve room for both colors of services shifting traffic", func() {
+			err := cluster.ValidateCapacity(map[string]types.ServiceRemoteConfig{
+				"web": {CPU: "512", Memory: "512", Scaling: types.ServiceScalingConfig{Max: 3}, Deploy: types.ServiceDeployConfig{Strategy: "blue-green"}},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not validate instance types it does not know", func() {
+			err := types.AppClusterConfig{InstanceType: "x1.32xlarge"}.ValidateCapacity(map[string]types.ServiceRemoteConfig{
+				"web": {CPU: "999999", Memory: "999999"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
 })
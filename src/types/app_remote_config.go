@@ -8,10 +8,11 @@ import (
 // AppRemoteConfig represents production specific configuration for an application
 type AppRemoteConfig struct {
 	Dependencies      []RemoteDependency
-	URL               string `yaml:",omitempty"`
-	Region            string `yaml:",omitempty"`
-	AccountID         string `yaml:"account-id,omitempty"`
-	SslCertificateArn string `yaml:"ssl-certificate-arn,omitempty"`
+	URL               string           `yaml:",omitempty"`
+	Region            string           `yaml:",omitempty"`
+	AccountID         string           `yaml:"account-id,omitempty"`
+	SslCertificateArn string           `yaml:"ssl-certificate-arn,omitempty"`
+	Cluster           AppClusterConfig `yaml:",omitempty"`
 }
 
 // ValidateFields validates that the production section contiains the required fields
@@ -23,5 +24,5 @@ func (p AppRemoteConfig) ValidateFields() error {
 			return fmt.Errorf("application.yml missing required field 'production.%s'", field)
 		}
 	}
-	return nil
+	return p.Cluster.ValidateFields()
 }
@@ -24,6 +24,7 @@ const (
 	BuildModeEnvironmentTest = iota
 	BuildModeEnvironmentDevelopment
 	BuildModeEnvironmentProduction
+	BuildModeEnvironmentE2E
 )
 
 // LocalDevelopmentComposeFileName is the docker-compose file name for local development runs
@@ -35,6 +36,9 @@ const LocalProductionComposeFileName = "run_production.yml"
 // LocalTestComposeFileName is the docker-compose file name for local test runs
 const LocalTestComposeFileName = "test.yml"
 
+// LocalE2EComposeFileName is the docker-compose file name for local end-to-end test runs
+const LocalE2EComposeFileName = "e2e.yml"
+
 // GetDockerComposeFileName returns the proper docker-compose file name for the build environment
 func (b BuildMode) GetDockerComposeFileName() string {
 	switch b.Environment {
@@ -44,6 +48,8 @@ func (b BuildMode) GetDockerComposeFileName() string {
 		return LocalProductionComposeFileName
 	case BuildModeEnvironmentTest:
 		return LocalTestComposeFileName
+	case BuildModeEnvironmentE2E:
+		return LocalE2EComposeFileName
 	default:
 		panic("docker-compose filename does not exist for given build mode")
 	}
@@ -51,5 +57,5 @@ func (b BuildMode) GetDockerComposeFileName() string {
 
 // GetComposeFileNames returns a list of docker-compose file names for local run processes
 func GetComposeFileNames() []string {
-	return []string{LocalTestComposeFileName, LocalDevelopmentComposeFileName, LocalProductionComposeFileName}
+	return []string{LocalTestComposeFileName, LocalDevelopmentComposeFileName, LocalProductionComposeFileName, LocalE2EComposeFileName}
 }
@@ -3,15 +3,17 @@ package context
 import (
 	"fmt"
 	"path"
+	"strings"
 
 	"github.com/Originate/exosphere/src/types"
 )
 
 // AppContext represents the exosphere application the user is running
 type AppContext struct {
-	Location        string
-	Config          types.AppConfig
-	ServiceContexts map[string]*ServiceContext
+	Location              string
+	Config                types.AppConfig
+	ServiceContexts       map[string]*ServiceContext
+	DependencyDefinitions map[string]types.DependencyDefinition
 }
 
 // NewAppContext returns an AppContext with all the service contexts loaded
@@ -20,7 +22,16 @@ func NewAppContext(location string, config types.AppConfig) (*AppContext, error)
 		Location: location,
 		Config:   config,
 	}
-	return appContext, appContext.initializeServiceContexts()
+	var err error
+	appContext.DependencyDefinitions, err = types.GetDependencyDefinitions(location)
+	if err != nil {
+		return appContext, err
+	}
+	err = appContext.initializeServiceContexts()
+	if err != nil {
+		return appContext, err
+	}
+	return appContext, appContext.validateMessageSchemas()
 }
 
 // GetServiceContextByLocation returns the service context for the given location
@@ -33,6 +44,28 @@ func (a *AppContext) GetServiceContextByLocation(location string) *ServiceContex
 	return nil
 }
 
+// GetServiceRolesWithMigrations returns the sorted roles of the services that declare database migrations
+func (a *AppContext) GetServiceRolesWithMigrations() []string {
+	result := []string{}
+	for _, serviceRole := range a.Config.GetSortedServiceRoles() {
+		if a.ServiceContexts[serviceRole].Config.Migrations.IsConfigured() {
+			result = append(result, serviceRole)
+		}
+	}
+	return result
+}
+
+// GetScheduledServiceRoles returns the sorted roles of the services that run on a schedule
+func (a *AppContext) GetScheduledServiceRoles() []string {
+	result := []string{}
+	for _, serviceRole := range a.Config.GetSortedServiceRoles() {
+		if a.ServiceContexts[serviceRole].Config.Type == types.ServiceTypeScheduled {
+			result = append(result, serviceRole)
+		}
+	}
+	return result
+}
+
 func (a *AppContext) getServiceContext(serviceRole string, serviceSource types.ServiceSource) (*ServiceContext, error) {
 	var serviceConfig types.ServiceConfig
 	var err error
@@ -68,3 +101,33 @@ func (a *AppContext) initializeServiceContexts() error {
 	}
 	return nil
 }
+
+// validates that the schema of each sent message is compatible with the schemas of its receivers.
+// Senders and receivers are matched by the public names of their messages
+func (a *AppContext) validateMessageSchemas() error {
+	serviceRoles := a.Config.GetSortedServiceRoles()
+	for _, senderRole := range serviceRoles {
+		senderMessages := a.ServiceContexts[senderRole].Config.ServiceMessages
+		for _, messageName := range senderMessages.Sends {
+			senderSchema, ok := senderMessages.Schemas[messageName]
+			if !ok {
+				continue
+			}
+			publicMessageName := a.Config.Services[senderRole].GetPublicMessageName(messageName)
+			for _, receiverRole := range serviceRoles {
+				receiverMessages := a.ServiceContexts[receiverRole].Config.ServiceMessages
+				for _, receivedMessageName := range receiverMessages.Receives {
+					receiverSchema, ok := receiverMessages.Schemas[receivedMessageName]
+					if !ok || a.Config.Services[receiverRole].GetPublicMessageName(receivedMessageName) != publicMessageName {
+						continue
+					}
+					incompatibilities := senderSchema.GetIncompatibilities(receiverSchema)
+					if len(incompatibilities) > 0 {
+						return fmt.Errorf("The schema of message '%s' sent by '%s' is incompatible with the schema expected by '%s': %s", publicMessageName, senderRole, receiverRole, strings.Join(incompatibilities, ", "))
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
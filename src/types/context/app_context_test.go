@@ -1,6 +1,10 @@
 package context_test
 
 import (
+	"io/ioutil"
+	"path"
+	"strings"
+
 	"github.com/Originate/exosphere/src/types"
 	"github.com/Originate/exosphere/src/types/context"
 	"github.com/Originate/exosphere/test/helpers"
@@ -75,4 +79,54 @@ var _ = Describe("AppContext", func() {
 		})
 
 	})
+	var _ = Describe("message schemas", func() {
+		It("should load the schema files of the services", func() {
+			appDir := helpers.GetTestApplicationDir("message-schemas")
+			appContext, err := context.GetAppContext(appDir)
+			Expect(err).ToNot(HaveOccurred())
+			schema := appContext.ServiceContexts["web"].Config.ServiceMessages.Schemas["users.create"]
+			Expect(schema.File).To(Equal("schemas/users.create.json"))
+			Expect(schema.Schema["required"]).To(Equal([]interface{}{"name", "email"}))
+		})
+
+		It("should throw an error if sender and receiver schemas are incompatible", func() {
+			appDir, err := ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+			err = helpers.CheckoutApp(appDir, "message-schemas")
+			Expect(err).NotTo(HaveOccurred())
+			serviceYmlPath := path.Join(appDir, "users", "service.yml")
+			serviceYml, err := ioutil.ReadFile(serviceYmlPath)
+			Expect(err).NotTo(HaveOccurred())
+			serviceYml = []byte(strings.Replace(string(serviceYml), "- name", "- age", 1))
+			err = ioutil.WriteFile(serviceYmlPath, serviceYml, 0644)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = context.GetAppContext(appDir)
+			Expect(err).To(HaveOccurred())
+			expectedErrorString := "The schema of message 'users.create' sent by 'web' is incompatible with the schema expected by 'users': 'age' is required by the receiver but not by the sender"
+			Expect(err.Error()).To(ContainSubstring(expectedErrorString))
+		})
+
+		It("should match senders and receivers by the public names of their messages", func() {
+			appDir, err := ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+			err = helpers.CheckoutApp(appDir, "message-schemas")
+			Expect(err).NotTo(HaveOccurred())
+			appYmlPath := path.Join(appDir, "application.yml")
+			appYml, err := ioutil.ReadFile(appYmlPath)
+			Expect(err).NotTo(HaveOccurred())
+			appYml = []byte(strings.Replace(string(appYml), "location: ./users", "location: ./users\n    message-translation:\n      - public: users\n        internal: mongo", 1))
+			err = ioutil.WriteFile(appYmlPath, appYml, 0644)
+			Expect(err).NotTo(HaveOccurred())
+			serviceYmlPath := path.Join(appDir, "users", "service.yml")
+			serviceYml, err := ioutil.ReadFile(serviceYmlPath)
+			Expect(err).NotTo(HaveOccurred())
+			serviceYml = []byte(strings.Replace(strings.Replace(string(serviceYml), "users.", "mongo.", -1), "- name", "- age", 1))
+			err = ioutil.WriteFile(serviceYmlPath, serviceYml, 0644)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = context.GetAppContext(appDir)
+			Expect(err).To(HaveOccurred())
+			expectedErrorString := "The schema of message 'users.create' sent by 'web' is incompatible with the schema expected by 'users': 'age' is required by the receiver but not by the sender"
+			Expect(err.Error()).To(ContainSubstring(expectedErrorString))
+		})
+	})
 })
@@ -0,0 +1,160 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the fields of a cron expression with their ranges and the names they accept
+var cronFields = []struct {
+	name     string
+	min, max int
+	names    []string
+}{
+	{"minute", 0, 59, nil},
+	{"hour", 0, 23, nil},
+	{"day of month", 1, 31, nil},
+	{"month", 1, 12, []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{"day of week", 0, 7, []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// how far Next looks ahead for a matching time, long enough to cover February 29
+const cronScheduleLookahead = 5 * 366 * 24 * time.Hour
+
+// CronSchedule is a cron expression with the fields minute, hour, day of month, month and day of week.
+// The times it matches are in UTC
+type CronSchedule struct {
+	expression string
+	fields     [5]map[int]bool
+}
+
+// ParseCronSchedule parses the given cron expression. It does not allow to restrict both
+// the day of the month and the day of the week, because CloudWatch Events does not support it
+func ParseCronSchedule(expression string) (CronSchedule, error) {
+	result := CronSchedule{expression: expression}
+	parts := strings.Fields(expression)
+	if len(parts) != len(cronFields) {
+		return result, fmt.Errorf("invalid cron expression '%s'. Must have 5 fields: minute, hour, day of month, month and day of week", expression)
+	}
+	for i, part := range parts {
+		values, err := parseCronField(part, i)
+		if err != nil {
+			return result, fmt.Errorf("invalid cron expression '%s': %s", expression, err)
+		}
+		result.fields[i] = values
+	}
+	if result.fields[4][7] {
+		result.fields[4][0] = true
+	}
+	if parts[2] != "*" && parts[4] != "*" {
+		return result, fmt.Errorf("invalid cron expression '%s': the day of month and the day of week cannot both be restricted", expression)
+	}
+	return result, nil
+}
+
+// Next returns the first time after the given time the schedule matches,
+// or the zero time if it does not match within the next years
+func (c CronSchedule) Next(after time.Time) time.Time {
+	current := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	end := current.Add(cronScheduleLookahead)
+	for current.Before(end) {
+		if !c.fields[3][int(current.Month())] {
+			current = time.Date(current.Year(), current.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.fields[2][current.Day()] || !c.fields[4][int(current.Weekday())] {
+			current = time.Date(current.Year(), current.Month(), current.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.fields[1][current.Hour()] {
+			current = current.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !c.fields[0][current.Minute()] {
+			current = current.Add(time.Minute)
+			continue
+		}
+		return current
+	}
+	return time.Time{}
+}
+
+// GetCloudWatchExpression returns the schedule expression of a CloudWatch Events rule
+// matching the same times. CloudWatch Events numbers the days of the week from 1 (Sunday) to 7,
+// requires a '?' in either the day of month or the day of week and has an additional year field,
+// so the days of the week are listed explicitly
+func (c CronSchedule) GetCloudWatchExpression() string {
+	parts := strings.Fields(c.expression)
+	if parts[4] == "*" {
+		parts[4] = "?"
+	} else {
+		parts[2] = "?"
+		days := []string{}
+		for day := 0; day < 7; day++ {
+			if c.fields[4][day] {
+				days = append(days, strconv.Itoa(day+1))
+			}
+		}
+		parts[4] = strings.Join(days, ",")
+	}
+	return fmt.Sprintf("cron(%s *)", strings.Join(parts, " "))
+}
+
+// returns the values the given part of a cron expression matches for the field with the given index
+func parseCronField(part string, index int) (map[int]bool, error) {
+	field := cronFields[index]
+	result := map[int]bool{}
+	for _, item := range strings.Split(part, ",") {
+		rangePart, step := item, 1
+		if slashIndex := strings.Index(item, "/"); slashIndex != -1 {
+			rangePart = item[:slashIndex]
+			var err error
+			step, err = strconv.Atoi(item[slashIndex+1:])
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step '%s' in the %s field", item[slashIndex+1:], field.name)
+			}
+		}
+		start, end := field.min, field.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			start, err = parseCronValue(bounds[0], index)
+			if err != nil {
+				return nil, err
+			}
+			end = start
+			if len(bounds) == 2 {
+				end, err = parseCronValue(bounds[1], index)
+				if err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				end = field.max
+			}
+			if end < start {
+				return nil, fmt.Errorf("invalid range '%s' in the %s field", rangePart, field.name)
+			}
+		}
+		for value := start; value <= end; value += step {
+			result[value] = true
+		}
+	}
+	return result, nil
+}
+
+// parses a single number or name of the field with the given index
+func parseCronValue(value string, index int) (int, error) {
+	field := cronFields[index]
+	for i, name := range field.names {
+		if strings.ToUpper(value) == name {
+			return i + field.min, nil
+		}
+	}
+	result, err := strconv.Atoi(value)
+	if err != nil || result < field.min || result > field.max {
+		return 0, fmt.Errorf("invalid value '%s' in the %s field. Must be between %d and %d", value, field.name, field.min, field.max)
+	}
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package types_test
+
+import (
+	"time"
+
+	"github.com/Originate/exosphere/src/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CronSchedule", func() {
+	// a Wednesday
+	now := time.Date(2017, time.November, 15, 10, 20, 30, 0, time.UTC)
+
+	parse := func(expression string) types.CronSchedule {
+		schedule, err := types.ParseCronSchedule(expression)
+		Expect(err).NotTo(HaveOccurred())
+		return schedule
+	}
+
+	Describe("parsing", func() {
+		It("throws an error if the expression does not have 5 fields", func() {
+			_, err := types.ParseCronSchedule("0 3 * *")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Must have 5 fields"))
+		})
+
+		It("throws an error if a value is out of range", func() {
+			_, err := types.ParseCronSchedule("60 * * * *")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid value '60' in the minute field. Must be between 0 and 59"))
+		})
+
+		It("throws an error if a range is reversed", func() {
+			_, err := types.ParseCronSchedule("* 5-3 * * *")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid range '5-3' in the hour field"))
+		})
+
+		It("throws an error if a step is invalid", func() {
+			_, err := types.ParseCronSchedule("*/0 * * * *")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid step '0' in the minute field"))
+		})
+
+		It("throws an error if both the day of month and the day of week are restricted", func() {
+			_, err := types.ParseCronSchedule("0 3 1 * MON")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the day of month and the day of week cannot both be restricted"))
+		})
+	})
+
+	Describe("Next", func() {
+		It("returns the next minute for every minute", func() {
+			Expect(parse("* * * * *").Next(now)).To(Equal(time.Date(2017, time.November, 15, 10, 21, 0, 0, time.UTC)))
+		})
+
+		It("supports steps", func() {
+			Expect(parse("*/15 * * * *").Next(now)).To(Equal(time.Date(2017, time.November, 15, 10, 30, 0, 0, time.UTC)))
+		})
+
+		It("moves to the next day if the time has passed today", func() {
+			Expect(parse("0 3 * * *").Next(now)).To(Equal(time.Date(2017, time.November, 16, 3, 0, 0, 0, time.UTC)))
+		})
+
+		It("supports names of days of the week", func() {
+			Expect(parse("30 2 * * MON-FRI").Next(time.Date(2017, time.November, 17, 12, 0, 0, 0, time.UTC))).To(Equal(time.Date(2017, time.November, 20, 2, 30, 0, 0, time.UTC)))
+		})
+
+		It("treats 7 as Sunday", func() {
+			Expect(parse("0 0 * * 7").Next(now)).To(Equal(time.Date(2017, time.November, 19, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("supports days of the month and months", func() {
+			Expect(parse("0 0 1 JAN,JUL *").Next(now)).To(Equal(time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("skips months without the day", func() {
+			Expect(parse("0 0 31 * *").Next(now)).To(Equal(time.Date(2017, time.December, 31, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("returns the zero time if the schedule never matches", func() {
+			Expect(parse("0 0 30 FEB *").Next(now).IsZero()).To(BeTrue())
+		})
+	})
+
+	Describe("GetCloudWatchExpression", func() {
+		It("uses '?' for the day of week if it is not restricted", func() {
+			Expect(parse("0 3 1 * *").GetCloudWatchExpression()).To(Equal("cron(0 3 1 * ? *)"))
+		})
+
+		It("uses '?' for the day of month and numbers the days of the week from 1", func() {
+			Expect(parse("30 2 * * MON-FRI").GetCloudWatchExpression()).To(Equal("cron(30 2 ? * 2,3,4,5,6 *)"))
+			Expect(parse("0 0 * * 0,7").GetCloudWatchExpression()).To(Equal("cron(0 0 ? * 1 *)"))
+		})
+
+		It("keeps steps and ranges", func() {
+			Expect(parse("*/10 8-18 * * *").GetCloudWatchExpression()).To(Equal("cron(*/10 8-18 * * ? *)"))
+		})
+	})
+})
@@ -0,0 +1,175 @@
+package types
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Originate/exosphere/src/util"
+	"github.com/hoisie/mustache"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DependencyDefinitionsDir is the directory (relative to the application) that holds
+// the user-defined dependency types
+const DependencyDefinitionsDir = ".exosphere/dependencies"
+
+// DependencyDefinition represents a user-defined dependency type
+// as provided in .exosphere/dependencies/<name>.yml
+type DependencyDefinition struct {
+	Local  LocalDependencyDefinition  `yaml:",omitempty"`
+	Remote RemoteDependencyDefinition `yaml:",omitempty"`
+}
+
+// LocalDependencyDefinition describes how to run a user-defined dependency locally
+type LocalDependencyDefinition struct {
+	Image                 string            `yaml:",omitempty"`
+	Command               string            `yaml:",omitempty"`
+	Ports                 []string          `yaml:",omitempty"`
+	Persist               []string          `yaml:",omitempty"`
+	DependencyEnvironment map[string]string `yaml:"dependency-environment,omitempty"`
+	ServiceEnvironment    map[string]string `yaml:"service-environment,omitempty"`
+	Healthcheck           Healthcheck       `yaml:",omitempty"`
+}
+
+// RemoteDependencyDefinition describes how to provision a user-defined dependency remotely
+type RemoteDependencyDefinition struct {
+	Image              string                            `yaml:",omitempty"`
+	Module             TerraformModuleMapping            `yaml:",omitempty"`
+	Modules            map[string]TerraformModuleMapping `yaml:",omitempty"`
+	Secrets            []string                          `yaml:",omitempty"`
+	ServiceEnvironment map[string]string                 `yaml:"service-environment,omitempty"`
+}
+
+// TerraformModuleMapping is a Terraform module together with the values of its variables
+type TerraformModuleMapping struct {
+	Source    string            `yaml:",omitempty"`
+	Variables map[string]string `yaml:",omitempty"`
+}
+
+// Healthcheck is a command that succeeds while a dependency accepts connections.
+// It becomes the docker healthcheck of the container, services don't wait for it
+type Healthcheck struct {
+	Command  string `yaml:",omitempty"`
+	Interval string `yaml:",omitempty"`
+	Timeout  string `yaml:",omitempty"`
+	Retries  int    `yaml:",omitempty"`
+}
+
+// GetDependencyDefinitions reads the user-defined dependency types of the application at the given location
+func GetDependencyDefinitions(appDir string) (map[string]DependencyDefinition, error) {
+	result := map[string]DependencyDefinition{}
+	definitionsDir := path.Join(appDir, DependencyDefinitionsDir)
+	dirExists, err := util.DoesDirectoryExist(definitionsDir)
+	if err != nil || !dirExists {
+		return result, err
+	}
+	fileInfos, err := ioutil.ReadDir(definitionsDir)
+	if err != nil {
+		return result, err
+	}
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() || path.Ext(fileInfo.Name()) != ".yml" {
+			continue
+		}
+		name := strings.TrimSuffix(fileInfo.Name(), ".yml")
+		yamlFile, err := ioutil.ReadFile(path.Join(definitionsDir, fileInfo.Name()))
+		if err != nil {
+			return result, err
+		}
+		var definition DependencyDefinition
+		if err = yaml.Unmarshal(yamlFile, &definition); err != nil {
+			return result, errors.Wrap(err, fmt.Sprintf("Failed to unmarshal the definition of dependency '%s'", name))
+		}
+		if err = definition.ValidateFields(); err != nil {
+			return result, errors.Wrap(err, fmt.Sprintf("The definition of dependency '%s' has issues", name))
+		}
+		result[name] = definition
+	}
+	return result, nil
+}
+
+// HasRemoteModule returns whether or not the dependency provisions infrastructure when deployed
+func (d DependencyDefinition) HasRemoteModule() bool {
+	return d.Remote.Module.Source != "" || len(d.Remote.Modules) > 0
+}
+
+// GetRemoteModules returns the Terraform modules of the dependency with the given name by module name.
+// The module is named like the dependency, the additional modules '<dependency>_<key>'
+func (d DependencyDefinition) GetRemoteModules(dependencyName string) map[string]TerraformModuleMapping {
+	result := map[string]TerraformModuleMapping{}
+	if d.Remote.Module.Source != "" {
+		result[dependencyName] = d.Remote.Module
+	}
+	for key, module := range d.Remote.Modules {
+		result[fmt.Sprintf("%s_%s", dependencyName, key)] = module
+	}
+	return result
+}
+
+// ValidateFields validates that a dependency definition contains all required fields
+func (d DependencyDefinition) ValidateFields() error {
+	if d.Local.Image == "" {
+		return errors.New("missing required field 'local.image'")
+	}
+	if len(d.Remote.Module.Variables) > 0 && d.Remote.Module.Source == "" {
+		return errors.New("missing required field 'remote.module.source'")
+	}
+	for _, key := range getSortedModuleKeys(d.Remote.Modules) {
+		if d.Remote.Modules[key].Source == "" {
+			return fmt.Errorf("missing required field 'remote.modules.%s.source'", key)
+		}
+	}
+	return d.validateTemplates()
+}
+
+// validates that all templates of the definition can be parsed,
+// so that rendering them when the dependency is used cannot fail
+func (d DependencyDefinition) validateTemplates() error {
+	templates := map[string]string{
+		"local.image":               d.Local.Image,
+		"local.command":             d.Local.Command,
+		"local.healthcheck.command": d.Local.Healthcheck.Command,
+		"remote.image":              d.Remote.Image,
+	}
+	for key, template := range d.Local.DependencyEnvironment {
+		templates["local.dependency-environment."+key] = template
+	}
+	for key, template := range d.Local.ServiceEnvironment {
+		templates["local.service-environment."+key] = template
+	}
+	for key, template := range d.Remote.Module.Variables {
+		templates["remote.module.variables."+key] = template
+	}
+	for moduleKey, module := range d.Remote.Modules {
+		for key, template := range module.Variables {
+			templates[fmt.Sprintf("remote.modules.%s.variables.%s", moduleKey, key)] = template
+		}
+	}
+	for key, template := range d.Remote.ServiceEnvironment {
+		templates["remote.service-environment."+key] = template
+	}
+	fields := []string{}
+	for field := range templates {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if _, err := mustache.ParseString(templates[field]); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid template in field '%s'", field))
+		}
+	}
+	return nil
+}
+
+func getSortedModuleKeys(modules map[string]TerraformModuleMapping) []string {
+	result := []string{}
+	for key := range modules {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
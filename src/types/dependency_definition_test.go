@@ -0,0 +1,76 @@
+package types_test
+
+import (
+	"github.com/Originate/exosphere/src/types"
+	"github.com/Originate/exosphere/test/helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DependencyDefinition", func() {
+	var _ = Describe("GetDependencyDefinitions", func() {
+		It("reads the definitions in .exosphere/dependencies", func() {
+			appDir := helpers.GetTestApplicationDir("dependency-definitions")
+			definitions, err := types.GetDependencyDefinitions(appDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(definitions).To(HaveKey("memcached"))
+			definition := definitions["memcached"]
+			Expect(definition.Local.Image).To(Equal("memcached:{{version}}"))
+			Expect(definition.Local.Healthcheck).To(Equal(types.Healthcheck{
+				Command:  "echo stats | nc localhost 11211",
+				Interval: "5s",
+				Timeout:  "3s",
+				Retries:  5,
+			}))
+			Expect(definition.Remote.Module.Source).To(Equal("github.com/example/terraform-modules//memcached"))
+			Expect(definition.Remote.Secrets).To(Equal([]string{"MEMCACHED_TOKEN"}))
+		})
+
+		It("returns no definitions if the application has none", func() {
+			appDir := helpers.GetTestApplicationDir("rds")
+			definitions, err := types.GetDependencyDefinitions(appDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(definitions).To(BeEmpty())
+		})
+	})
+
+	var _ = Describe("ValidateFields", func() {
+		It("requires a local image", func() {
+			err := types.DependencyDefinition{}.ValidateFields()
+			Expect(err).To(MatchError("missing required field 'local.image'"))
+		})
+
+		It("requires a module source when module variables are given", func() {
+			definition := types.DependencyDefinition{
+				Local: types.LocalDependencyDefinition{Image: "memcached:1.5.3"},
+				Remote: types.RemoteDependencyDefinition{
+					Module: types.TerraformModuleMapping{Variables: map[string]string{"name": "cache"}},
+				},
+			}
+			Expect(definition.ValidateFields()).To(MatchError("missing required field 'remote.module.source'"))
+		})
+
+		It("requires a source for each additional module", func() {
+			definition := types.DependencyDefinition{
+				Local: types.LocalDependencyDefinition{Image: "nats:1.0.4"},
+				Remote: types.RemoteDependencyDefinition{
+					Module:  types.TerraformModuleMapping{Source: "github.com/example/terraform-modules//nats-service"},
+					Modules: map[string]types.TerraformModuleMapping{"cluster": {Variables: map[string]string{"name": "nats"}}},
+				},
+			}
+			Expect(definition.ValidateFields()).To(MatchError("missing required field 'remote.modules.cluster.source'"))
+		})
+
+		It("requires templates that can be parsed", func() {
+			definition := types.DependencyDefinition{
+				Local: types.LocalDependencyDefinition{
+					Image:              "memcached:{{version}}",
+					ServiceEnvironment: map[string]string{"MEMCACHED_URL": "{{containerName:11211"},
+				},
+			}
+			err := definition.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid template in field 'local.service-environment.MEMCACHED_URL'"))
+		})
+	})
+})
@@ -0,0 +1,47 @@
+package deploy
+
+import (
+	"sort"
+	"time"
+)
+
+// ManifestIDFormat is the time format of the IDs of deploy manifests, which sort chronologically
+const ManifestIDFormat = "20060102-150405"
+
+// Manifest records what a successful deploy applied, so that it can be rolled back to
+type Manifest struct {
+	ID                string            `json:"id"`
+	DeployedAt        time.Time         `json:"deployedAt"`
+	Images            map[string]string `json:"images"`
+	Dependencies      map[string]string `json:"dependencies"`
+	TerraformVarsHash string            `json:"terraformVarsHash"`
+	RollbackOf        string            `json:"rollbackOf,omitempty"`
+}
+
+// NewManifest returns a manifest for a deploy at the given time
+func NewManifest(deployedAt time.Time, images, dependencies map[string]string, terraformVarsHash string) Manifest {
+	return Manifest{
+		ID:                deployedAt.UTC().Format(ManifestIDFormat),
+		DeployedAt:        deployedAt.UTC(),
+		Images:            images,
+		Dependencies:      dependencies,
+		TerraformVarsHash: terraformVarsHash,
+	}
+}
+
+// GetSortedImageNames returns the sorted names of the services and dependencies the manifest has images for
+func (m Manifest) GetSortedImageNames() []string {
+	result := []string{}
+	for name := range m.Images {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// SortManifests sorts the given manifests from the newest to the oldest deploy
+func SortManifests(manifests []Manifest) {
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].ID > manifests[j].ID
+	})
+}
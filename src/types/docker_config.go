@@ -3,14 +3,24 @@ package types
 // DockerConfig represents the configuration of a service/dependency as provided in
 // docker-compose.yml
 type DockerConfig struct {
-	Image         string            `yaml:",omitempty"`
-	Build         map[string]string `yaml:",omitempty"`
-	Command       string            `yaml:",omitempty"`
-	ContainerName string            `yaml:"container_name,omitempty"`
-	Ports         []string          `yaml:",omitempty"`
-	Volumes       []string          `yaml:",omitempty"`
-	Links         []string          `yaml:",omitempty"`
-	Environment   map[string]string `yaml:",omitempty"`
-	DependsOn     []string          `yaml:"depends_on,omitempty"`
-	Restart       string            `yaml:",omitempty"`
+	Image         string             `yaml:",omitempty"`
+	Build         map[string]string  `yaml:",omitempty"`
+	Command       string             `yaml:",omitempty"`
+	Entrypoint    []string           `yaml:",omitempty"`
+	ContainerName string             `yaml:"container_name,omitempty"`
+	Ports         []string           `yaml:",omitempty"`
+	Volumes       []string           `yaml:",omitempty"`
+	Links         []string           `yaml:",omitempty"`
+	Environment   map[string]string  `yaml:",omitempty"`
+	DependsOn     []string           `yaml:"depends_on,omitempty"`
+	Restart       string             `yaml:",omitempty"`
+	Healthcheck   *DockerHealthcheck `yaml:",omitempty"`
+}
+
+// DockerHealthcheck represents the healthcheck of a service/dependency as provided in docker-compose.yml
+type DockerHealthcheck struct {
+	Test     []string `yaml:",omitempty"`
+	Interval string   `yaml:",omitempty"`
+	Timeout  string   `yaml:",omitempty"`
+	Retries  int      `yaml:",omitempty"`
 }
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Originate/exosphere/src/util"
+	"github.com/pkg/errors"
+)
+
+// DynamoDBConfig holds configuration fields for a dynamodb dependency
+type DynamoDBConfig struct {
+	Tables []DynamoDBTable `yaml:",omitempty"`
+}
+
+// DynamoDBTable represents a table of a dynamodb dependency
+type DynamoDBTable struct {
+	Name                   string                   `yaml:",omitempty"`
+	HashKey                string                   `yaml:"hash-key,omitempty"`
+	RangeKey               string                   `yaml:"range-key,omitempty"`
+	Attributes             map[string]string        `yaml:",omitempty"`
+	GlobalSecondaryIndexes []DynamoDBSecondaryIndex `yaml:"global-secondary-indexes,omitempty"`
+	ReadCapacity           string                   `yaml:"read-capacity,omitempty"`
+	WriteCapacity          string                   `yaml:"write-capacity,omitempty"`
+}
+
+// DynamoDBSecondaryIndex represents a global secondary index of a dynamodb table
+type DynamoDBSecondaryIndex struct {
+	Name           string `yaml:",omitempty"`
+	HashKey        string `yaml:"hash-key,omitempty"`
+	RangeKey       string `yaml:"range-key,omitempty"`
+	ProjectionType string `yaml:"projection-type,omitempty"`
+	ReadCapacity   string `yaml:"read-capacity,omitempty"`
+	WriteCapacity  string `yaml:"write-capacity,omitempty"`
+}
+
+var dynamoDBAttributeTypes = []string{"S", "N", "B"}
+
+var dynamoDBProjectionTypes = []string{"ALL", "KEYS_ONLY"}
+
+// GetTableName returns the name of the table in DynamoDB, which is prefixed with the application name
+// so that multiple applications can share an AWS account
+func (d DynamoDBTable) GetTableName(appName string) string {
+	return fmt.Sprintf("%s-%s", appName, d.Name)
+}
+
+// GetEnvVarName returns the name of the env var holding the table name, e.g. DYNAMODB_TABLE_USER_SESSIONS
+func (d DynamoDBTable) GetEnvVarName() string {
+	return "DYNAMODB_TABLE_" + strings.ToUpper(util.ToSnake(d.Name))
+}
+
+// GetReadCapacity returns the provisioned read capacity units, defaulting to 5
+func (d DynamoDBTable) GetReadCapacity() string {
+	if d.ReadCapacity == "" {
+		return "5"
+	}
+	return d.ReadCapacity
+}
+
+// GetWriteCapacity returns the provisioned write capacity units, defaulting to 5
+func (d DynamoDBTable) GetWriteCapacity() string {
+	if d.WriteCapacity == "" {
+		return "5"
+	}
+	return d.WriteCapacity
+}
+
+// GetProjectionType returns the projection type of the index, defaulting to ALL
+func (d DynamoDBSecondaryIndex) GetProjectionType() string {
+	if d.ProjectionType == "" {
+		return "ALL"
+	}
+	return d.ProjectionType
+}
+
+// GetReadCapacity returns the provisioned read capacity units, defaulting to 5
+func (d DynamoDBSecondaryIndex) GetReadCapacity() string {
+	if d.ReadCapacity == "" {
+		return "5"
+	}
+	return d.ReadCapacity
+}
+
+// GetWriteCapacity returns the provisioned write capacity units, defaulting to 5
+func (d DynamoDBSecondaryIndex) GetWriteCapacity() string {
+	if d.WriteCapacity == "" {
+		return "5"
+	}
+	return d.WriteCapacity
+}
+
+// ValidateFields validates that a dynamodb config contains valid fields
+func (d DynamoDBConfig) ValidateFields() error {
+	if len(d.Tables) == 0 {
+		return errors.New("'dynamodb.tables' must declare at least one table")
+	}
+	tableNames := []string{}
+	for _, table := range d.Tables {
+		if util.DoesStringArrayContain(tableNames, table.Name) {
+			return fmt.Errorf("table '%s' is declared more than once", table.Name)
+		}
+		tableNames = append(tableNames, table.Name)
+		err := table.ValidateFields()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("table '%s' has issues", table.Name))
+		}
+	}
+	return nil
+}
+
+// ValidateFields validates that a dynamodb table contains valid fields
+func (d DynamoDBTable) ValidateFields() error {
+	nameRegex := regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9_-]*$")
+	if !nameRegex.MatchString(d.Name) {
+		return errors.New("only alphanumeric characters, underscores and hyphens allowed in 'name', starting with a letter")
+	}
+	for attribute, attributeType := range d.Attributes {
+		if !util.DoesStringArrayContain(dynamoDBAttributeTypes, attributeType) {
+			return fmt.Errorf("invalid type '%s' for attribute '%s'. Must be one of: %s", attributeType, attribute, strings.Join(dynamoDBAttributeTypes, ", "))
+		}
+	}
+	if d.HashKey == "" {
+		return errors.New("missing field 'hash-key'")
+	}
+	keys := []string{d.HashKey, d.RangeKey}
+	for _, index := range d.GlobalSecondaryIndexes {
+		if index.Name == "" || index.HashKey == "" {
+			return errors.New("global secondary indexes must have a 'name' and a 'hash-key'")
+		}
+		if !util.DoesStringArrayContain(dynamoDBProjectionTypes, index.GetProjectionType()) {
+			return fmt.Errorf("invalid projection type '%s' for index '%s'. Must be one of: %s", index.ProjectionType, index.Name, strings.Join(dynamoDBProjectionTypes, ", "))
+		}
+		keys = append(keys, index.HashKey, index.RangeKey)
+	}
+	for _, key := range keys {
+		if key != "" && d.Attributes[key] == "" {
+			return fmt.Errorf("key '%s' must be declared in 'attributes'", key)
+		}
+	}
+	for attribute := range d.Attributes {
+		if !util.DoesStringArrayContain(keys, attribute) {
+			return fmt.Errorf("attribute '%s' is not used as a key. Only key attributes can be declared", attribute)
+		}
+	}
+	for _, capacity := range []string{d.GetReadCapacity(), d.GetWriteCapacity()} {
+		if value, err := strconv.Atoi(capacity); err != nil || value < 1 {
+			return fmt.Errorf("invalid capacity '%s'. Must be a positive integer", capacity)
+		}
+	}
+	return nil
+}
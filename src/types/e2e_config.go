@@ -0,0 +1,26 @@
+package types
+
+import "fmt"
+
+// E2EConfig represents the configuration of the end-to-end tests for an application
+type E2EConfig struct {
+	Location    string `yaml:",omitempty"`
+	DockerImage string `yaml:"docker-image,omitempty"`
+	Command     string `yaml:",omitempty"`
+}
+
+// IsConfigured returns whether or not end-to-end tests are configured
+func (e E2EConfig) IsConfigured() bool {
+	return e.Location != "" || e.DockerImage != ""
+}
+
+// ValidateFields validates that the e2e section contains valid fields
+func (e E2EConfig) ValidateFields() error {
+	if e.Location != "" && e.DockerImage != "" {
+		return fmt.Errorf("application.yml field 'local.e2e' can only have one of 'location' or 'docker-image'")
+	}
+	if e.DockerImage != "" && e.Command == "" {
+		return fmt.Errorf("application.yml missing required field 'local.e2e.command'")
+	}
+	return nil
+}
@@ -14,14 +14,15 @@ type ServiceEndpoint struct {
 	ContainerPort string
 	HostPort      string
 	BuildMode     types.BuildMode
+	AppURL        string
 }
 
-func newServiceEndpoint(serviceRole string, serviceConfig types.ServiceConfig, portReservation *PortReservation, buildMode types.BuildMode) *ServiceEndpoint {
+func newServiceEndpoint(serviceRole string, serviceConfig types.ServiceConfig, portReservation *PortReservation, buildMode types.BuildMode, appURL string) *ServiceEndpoint {
 	containerPort := ""
 	hostPort := ""
 	if buildMode.Type == types.BuildModeTypeLocal {
 		switch buildMode.Environment {
-		case types.BuildModeEnvironmentDevelopment:
+		case types.BuildModeEnvironmentDevelopment, types.BuildModeEnvironmentE2E:
 			containerPort = serviceConfig.Development.Port
 		case types.BuildModeEnvironmentProduction:
 			containerPort = serviceConfig.Production.Port
@@ -36,6 +37,7 @@ func newServiceEndpoint(serviceRole string, serviceConfig types.ServiceConfig, p
 		ContainerPort: containerPort,
 		HostPort:      hostPort,
 		BuildMode:     buildMode,
+		AppURL:        appURL,
 	}
 }
 
@@ -57,18 +59,41 @@ func (s *ServiceEndpoint) GetEndpointMappings() map[string]string {
 	}
 }
 
+// GetInternalEndpointMappings returns a map from env var name to env var value of a service endpoint
+// as reachable from within the docker network
+func (s *ServiceEndpoint) GetInternalEndpointMappings() map[string]string {
+	if s.ServiceConfig.Type != "public" || s.ContainerPort == "" {
+		return map[string]string{}
+	}
+	externalKey := fmt.Sprintf("%s_EXTERNAL_ORIGIN", toConstantCase(s.ServiceRole))
+	return map[string]string{externalKey: fmt.Sprintf("http://%s:%s", s.ServiceRole, s.ContainerPort)}
+}
+
 func (s *ServiceEndpoint) getExternalOrigin() map[string]string {
 	externalKey := fmt.Sprintf("%s_EXTERNAL_ORIGIN", toConstantCase(s.ServiceRole))
 	if s.BuildMode.Type == types.BuildModeTypeLocal {
 		if s.HostPort != "" {
 			return map[string]string{externalKey: fmt.Sprintf("http://localhost:%s", s.HostPort)}
 		}
+	} else if len(s.ServiceConfig.Remote.Routes) > 0 {
+		return map[string]string{externalKey: s.getRouteOrigin()}
 	} else {
 		return map[string]string{externalKey: fmt.Sprintf("https://%s", s.ServiceConfig.Remote.URL)}
 	}
 	return map[string]string{}
 }
 
+// returns the origin of the first route of the service with a host that is not a wildcard,
+// which includes the path of the route. Defaults to the URL of the application
+func (s *ServiceEndpoint) getRouteOrigin() string {
+	for _, route := range s.ServiceConfig.Remote.Routes {
+		if !strings.HasPrefix(route.Host, "*.") {
+			return route.GetOrigin(s.AppURL)
+		}
+	}
+	return fmt.Sprintf("https://%s", s.AppURL)
+}
+
 // converts valid serviceRole strings to constant case
 // see validateAppConfig() in types/app_config.go for valid serviceRole regex
 func toConstantCase(serviceRole string) string {
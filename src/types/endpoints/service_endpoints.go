@@ -15,7 +15,7 @@ func NewServiceEndpoints(appContext *context.AppContext, buildMode types.BuildMo
 	serviceEndpoints := map[string]*ServiceEndpoint{}
 	for _, serviceRole := range appContext.Config.GetSortedServiceRoles() {
 		serviceConfig := appContext.ServiceContexts[serviceRole].Config
-		serviceEndpoints[serviceRole] = newServiceEndpoint(serviceRole, serviceConfig, portReservation, buildMode)
+		serviceEndpoints[serviceRole] = newServiceEndpoint(serviceRole, serviceConfig, portReservation, buildMode, appContext.Config.Remote.URL)
 	}
 	return serviceEndpoints
 }
@@ -32,6 +32,15 @@ func (s ServiceEndpoints) GetServiceEndpointEnvVars(serviceRole string) map[stri
 	return endpointEnvVars
 }
 
+// GetE2EEndpointEnvVars creates all the endpoint env vars for the end-to-end tests container
+func (s ServiceEndpoints) GetE2EEndpointEnvVars() map[string]string {
+	endpointEnvVars := map[string]string{}
+	for _, serviceEndpoint := range s {
+		util.Merge(endpointEnvVars, serviceEndpoint.GetInternalEndpointMappings())
+	}
+	return endpointEnvVars
+}
+
 // GetServicePortMappings gets the port mapping for a particular service
 func (s ServiceEndpoints) GetServicePortMappings(serviceRole string) []string {
 	return s[serviceRole].GetPortMappings()
@@ -44,4 +44,46 @@ var _ = Describe("ServiceEndpoints", func() {
 		mapping := serviceEndpoints.GetServicePortMappings("web")
 		Expect(mapping[0]).To(Equal("3000:80"))
 	})
+
+	It("compiles the proper end-to-end test endpoints", func() {
+		buildMode := types.BuildMode{
+			Type:        types.BuildModeTypeLocal,
+			Environment: types.BuildModeEnvironmentE2E,
+		}
+		serviceEndpoints := endpoints.NewServiceEndpoints(appContext, buildMode)
+		envVars := serviceEndpoints.GetE2EEndpointEnvVars()
+		Expect(envVars).To(Equal(map[string]string{
+			"WEB_EXTERNAL_ORIGIN": "http://web:4000",
+		}))
+	})
+
+	It("compiles the deploy endpoints of public services with and without routes", func() {
+		appContext := &context.AppContext{
+			Config: types.AppConfig{
+				Remote: types.AppRemoteConfig{URL: "example.com"},
+				Services: map[string]types.ServiceSource{
+					"admin": {}, "api": {}, "users": {}, "web": {},
+				},
+			},
+			ServiceContexts: map[string]*context.ServiceContext{
+				"admin": {Config: types.ServiceConfig{Type: types.ServiceTypePublic, Remote: types.ServiceRemoteConfig{
+					Routes: []types.ServiceRoute{{Host: "*.example.com", Priority: 1}},
+				}}},
+				"api": {Config: types.ServiceConfig{Type: types.ServiceTypePublic, Remote: types.ServiceRemoteConfig{
+					Routes: []types.ServiceRoute{{Path: "/api/", Priority: 2}, {Host: "api.example.com", Priority: 3}},
+				}}},
+				"users": {Config: types.ServiceConfig{Type: types.ServiceTypeWorker}},
+				"web": {Config: types.ServiceConfig{Type: types.ServiceTypePublic, Remote: types.ServiceRemoteConfig{
+					URL: "web.example.com",
+				}}},
+			},
+		}
+		serviceEndpoints := endpoints.NewServiceEndpoints(appContext, types.BuildMode{Type: types.BuildModeTypeDeploy})
+		envVars := serviceEndpoints.GetServiceEndpointEnvVars("users")
+		Expect(envVars).To(Equal(map[string]string{
+			"ADMIN_EXTERNAL_ORIGIN": "https://example.com",
+			"API_EXTERNAL_ORIGIN":   "https://example.com/api",
+			"WEB_EXTERNAL_ORIGIN":   "https://web.example.com",
+		}))
+	})
 })
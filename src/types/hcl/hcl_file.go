@@ -8,6 +8,7 @@ type File struct {
 	Provider  map[string]Provider
 	Variable  map[string]Variable
 	Module    map[string]Module
+	Resource  map[string]map[string]Resource
 }
 
 // GetVariableNames returns the list of variable names defined in the file
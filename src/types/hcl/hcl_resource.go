@@ -0,0 +1,4 @@
+package hcl
+
+// Resource represents a terraform resource block in a File
+type Resource map[string]interface{}
@@ -0,0 +1,6 @@
+package types
+
+// LintConfig represents the configuration of 'exo lint' for an application
+type LintConfig struct {
+	Disable []string `yaml:",omitempty"`
+}
@@ -3,4 +3,5 @@ package types
 // LocalConfig represents development specific configuration for an application
 type LocalConfig struct {
 	Dependencies []LocalDependency
+	E2E          E2EConfig `yaml:"e2e,omitempty"`
 }
@@ -1,8 +1,74 @@
 package types
 
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
 // LocalDependency represents a development dependency
 type LocalDependency struct {
 	Config  LocalDependencyConfig `yaml:",omitempty"`
 	Name    string
 	Version string
 }
+
+// the directories in which the official images of these dependencies
+// run the scripts they find when they initialize an empty database
+var localDependencyInitPaths = map[string]string{
+	"postgres": "/docker-entrypoint-initdb.d",
+	"mysql":    "/docker-entrypoint-initdb.d",
+	"mariadb":  "/docker-entrypoint-initdb.d",
+	"mongo":    "/docker-entrypoint-initdb.d",
+}
+
+// GetInitPath returns the directory in the image the init files are mounted into,
+// which is 'config.init-path' or the init hook of the official image
+func (l LocalDependency) GetInitPath() string {
+	if l.Config.InitPath != "" {
+		return l.Config.InitPath
+	}
+	return localDependencyInitPaths[l.Name]
+}
+
+// ValidateFields validates that a local dependency contains valid fields
+func (l LocalDependency) ValidateFields() error {
+	var err error
+	switch l.Name {
+	case "nats":
+		err = l.Config.Nats.ValidateFields()
+	case "dynamodb":
+		err = l.Config.Dynamodb.ValidateFields()
+	case "s3":
+		err = l.Config.S3.ValidateFields()
+	case "queue":
+		err = l.Config.Queue.ValidateFields()
+	}
+	if err == nil {
+		err = l.validateInitFields()
+	}
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("local dependency %s:%s has issues", l.Name, l.Version))
+	}
+	return nil
+}
+
+func (l LocalDependency) validateInitFields() error {
+	if len(l.Config.Init) == 0 {
+		return nil
+	}
+	if l.GetInitPath() == "" {
+		return fmt.Errorf("'config.init-path' is required for '%s' since its init hook is unknown", l.Name)
+	}
+	if !path.IsAbs(l.GetInitPath()) {
+		return fmt.Errorf("'config.init-path' must be an absolute path, got '%s'", l.Config.InitPath)
+	}
+	for _, initPath := range l.Config.Init {
+		if path.IsAbs(initPath) || strings.HasPrefix(path.Clean(initPath), "..") {
+			return fmt.Errorf("'config.init' must contain paths inside the application, got '%s'", initPath)
+		}
+	}
+	return nil
+}
@@ -6,4 +6,13 @@ type LocalDependencyConfig struct {
 	Persist               []string          `yaml:",omitempty"`
 	DependencyEnvironment map[string]string `yaml:"dependency-environment,omitempty"`
 	ServiceEnvironment    map[string]string `yaml:"service-environment,omitempty"`
+	Init                  []string          `yaml:",omitempty"`
+	InitPath              string            `yaml:"init-path,omitempty"`
+	Seed                  []string          `yaml:",omitempty"`
+	Nats                  NatsConfig        `yaml:",omitempty"`
+	Redis                 RedisConfig       `yaml:",omitempty"`
+	Dynamodb              DynamoDBConfig    `yaml:",omitempty"`
+	S3                    S3Config          `yaml:",omitempty"`
+	Queue                 QueueConfig       `yaml:",omitempty"`
+	Variables             map[string]string `yaml:",omitempty"`
 }
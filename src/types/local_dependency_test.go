@@ -0,0 +1,31 @@
+package types_test
+
+import (
+	"github.com/Originate/exosphere/src/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalDependency", func() {
+	Describe("init files", func() {
+		It("uses the init hook of the official image by default", func() {
+			dependency := types.LocalDependency{Name: "postgres", Config: types.LocalDependencyConfig{Init: []string{"db/schema.sql"}}}
+			Expect(dependency.GetInitPath()).To(Equal("/docker-entrypoint-initdb.d"))
+			Expect(dependency.ValidateFields()).To(Succeed())
+		})
+
+		It("requires an init path for images with an unknown init hook", func() {
+			dependency := types.LocalDependency{Name: "cassandra", Version: "3.11", Config: types.LocalDependencyConfig{Init: []string{"db/schema.cql"}}}
+			err := dependency.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("local dependency cassandra:3.11 has issues: 'config.init-path' is required for 'cassandra' since its init hook is unknown"))
+		})
+
+		It("does not allow init files outside of the application", func() {
+			dependency := types.LocalDependency{Name: "mongo", Version: "3.4.0", Config: types.LocalDependencyConfig{Init: []string{"../schema.js"}}}
+			err := dependency.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'config.init' must contain paths inside the application, got '../schema.js'"))
+		})
+	})
+})
@@ -0,0 +1,14 @@
+package types
+
+// MessageContractReport represents the result of verifying that a service
+// only sends and receives the messages it declares in service.yml
+type MessageContractReport struct {
+	Role              string   `json:"role"`
+	UndeclaredSends   []string `json:"undeclaredSends"`
+	UnhandledReceives []string `json:"unhandledReceives"`
+}
+
+// IsValid returns whether or not the service adheres to its message contract
+func (m MessageContractReport) IsValid() bool {
+	return len(m.UndeclaredSends) == 0 && len(m.UnhandledReceives) == 0
+}
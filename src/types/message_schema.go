@@ -0,0 +1,223 @@
+package types
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Originate/exosphere/src/util"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// MessageSchema is the JSON Schema of a message payload, given either inline in service.yml
+// or as the path (relative to the service directory) to a JSON file
+type MessageSchema struct {
+	File   string
+	Schema map[string]interface{}
+}
+
+var jsonSchemaTypes = []string{"array", "boolean", "integer", "null", "number", "object", "string"}
+
+// UnmarshalYAML unmarshals either a file path or an inline schema
+func (m *MessageSchema) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var file string
+	if err := unmarshal(&file); err == nil {
+		m.File = file
+		return nil
+	}
+	var schema map[interface{}]interface{}
+	if err := unmarshal(&schema); err != nil {
+		return err
+	}
+	m.Schema = normalizeYAML(schema).(map[string]interface{})
+	return nil
+}
+
+// MarshalYAML marshals the schema the same way it was given
+func (m MessageSchema) MarshalYAML() (interface{}, error) {
+	if m.File != "" {
+		return m.File, nil
+	}
+	return m.Schema, nil
+}
+
+// Load reads the schema file of the message (if any) relative to the given service location
+func (m *MessageSchema) Load(serviceLocation string) error {
+	if m.File == "" {
+		return nil
+	}
+	fileContent, err := ioutil.ReadFile(path.Join(serviceLocation, m.File))
+	if err != nil {
+		return err
+	}
+	var schema map[interface{}]interface{}
+	err = yaml.Unmarshal(fileContent, &schema)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Failed to unmarshal schema file '%s'", m.File))
+	}
+	m.Schema = normalizeYAML(schema).(map[string]interface{})
+	return nil
+}
+
+// Validate returns an error if the schema is not a valid JSON Schema
+func (m MessageSchema) Validate() error {
+	if m.Schema == nil {
+		return nil
+	}
+	return validateSchema(m.Schema, "")
+}
+
+// GetIncompatibilities returns the reasons why payloads matching this (sender) schema
+// might not match the given receiver schema
+func (m MessageSchema) GetIncompatibilities(receiver MessageSchema) []string {
+	if m.Schema == nil || receiver.Schema == nil {
+		return []string{}
+	}
+	return getSchemaIncompatibilities(m.Schema, receiver.Schema, "")
+}
+
+func validateSchema(schema map[string]interface{}, schemaPath string) error {
+	if schemaType, ok := schema["type"]; ok {
+		schemaTypes, isList := schemaType.([]interface{})
+		if !isList {
+			schemaTypes = []interface{}{schemaType}
+		}
+		for _, value := range schemaTypes {
+			typeName, isString := value.(string)
+			if !isString || !util.DoesStringArrayContain(jsonSchemaTypes, typeName) {
+				return fmt.Errorf("'%stype' must be one of: %s", schemaPath, strings.Join(jsonSchemaTypes, ", "))
+			}
+		}
+	}
+	if properties, ok := schema["properties"]; ok {
+		propertiesMap, isMap := properties.(map[string]interface{})
+		if !isMap {
+			return fmt.Errorf("'%sproperties' must be a map", schemaPath)
+		}
+		for _, name := range getSortedKeys(propertiesMap) {
+			propertySchema, isMap := propertiesMap[name].(map[string]interface{})
+			if !isMap {
+				return fmt.Errorf("'%sproperties.%s' must be a schema", schemaPath, name)
+			}
+			if err := validateSchema(propertySchema, fmt.Sprintf("%sproperties.%s.", schemaPath, name)); err != nil {
+				return err
+			}
+		}
+	}
+	if required, ok := schema["required"]; ok {
+		requiredList, isList := required.([]interface{})
+		if !isList {
+			return fmt.Errorf("'%srequired' must be a list of property names", schemaPath)
+		}
+		for _, value := range requiredList {
+			if _, isString := value.(string); !isString {
+				return fmt.Errorf("'%srequired' must be a list of property names", schemaPath)
+			}
+		}
+	}
+	if items, ok := schema["items"]; ok {
+		itemsSchema, isMap := items.(map[string]interface{})
+		if !isMap {
+			return fmt.Errorf("'%sitems' must be a schema", schemaPath)
+		}
+		return validateSchema(itemsSchema, schemaPath+"items.")
+	}
+	return nil
+}
+
+func getSchemaIncompatibilities(sender, receiver map[string]interface{}, schemaPath string) []string {
+	result := []string{}
+	senderType, senderHasType := sender["type"]
+	receiverType, receiverHasType := receiver["type"]
+	if senderHasType && receiverHasType && !isTypeCompatible(senderType, receiverType) {
+		result = append(result, fmt.Sprintf("'%stype' is '%v' for the sender but '%v' for the receiver", schemaPath, senderType, receiverType))
+	}
+	senderRequired := getStringList(sender["required"])
+	for _, name := range getStringList(receiver["required"]) {
+		if !util.DoesStringArrayContain(senderRequired, name) {
+			result = append(result, fmt.Sprintf("'%s%s' is required by the receiver but not by the sender", schemaPath, name))
+		}
+	}
+	senderProperties, _ := sender["properties"].(map[string]interface{})
+	receiverProperties, _ := receiver["properties"].(map[string]interface{})
+	for _, name := range getSortedKeys(receiverProperties) {
+		senderProperty, senderOk := senderProperties[name].(map[string]interface{})
+		receiverProperty, receiverOk := receiverProperties[name].(map[string]interface{})
+		if senderOk && receiverOk {
+			result = append(result, getSchemaIncompatibilities(senderProperty, receiverProperty, schemaPath+name+".")...)
+		}
+	}
+	senderItems, senderOk := sender["items"].(map[string]interface{})
+	receiverItems, receiverOk := receiver["items"].(map[string]interface{})
+	if senderOk && receiverOk {
+		result = append(result, getSchemaIncompatibilities(senderItems, receiverItems, schemaPath+"items.")...)
+	}
+	return result
+}
+
+// returns whether every type the sender allows is accepted by the receiver,
+// integers being accepted as numbers
+func isTypeCompatible(senderType, receiverType interface{}) bool {
+	receiverTypes := getTypeList(receiverType)
+	for _, typeName := range getTypeList(senderType) {
+		if util.DoesStringArrayContain(receiverTypes, typeName) {
+			continue
+		}
+		if typeName == "integer" && util.DoesStringArrayContain(receiverTypes, "number") {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// returns the types of a schema, which are given either as a single type or a list of types
+func getTypeList(value interface{}) []string {
+	if typeName, ok := value.(string); ok {
+		return []string{typeName}
+	}
+	return getStringList(value)
+}
+
+func getStringList(value interface{}) []string {
+	result := []string{}
+	list, _ := value.([]interface{})
+	for _, item := range list {
+		if str, ok := item.(string); ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
+
+func getSortedKeys(m map[string]interface{}) []string {
+	result := []string{}
+	for key := range m {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// converts the maps unmarshaled by yaml to maps with string keys so they can be marshaled to JSON
+func normalizeYAML(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[interface{}]interface{}:
+		result := map[string]interface{}{}
+		for key, val := range typedValue {
+			result[fmt.Sprint(key)] = normalizeYAML(val)
+		}
+		return result
+	case []interface{}:
+		result := []interface{}{}
+		for _, val := range typedValue {
+			result = append(result, normalizeYAML(val))
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,104 @@
+package types_test
+
+import (
+	"github.com/Originate/exosphere/src/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var _ = Describe("MessageSchema", func() {
+	var _ = Describe("UnmarshalYAML", func() {
+		It("reads file paths", func() {
+			var schema types.MessageSchema
+			err := yaml.Unmarshal([]byte("schemas/users.create.json"), &schema)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schema.File).To(Equal("schemas/users.create.json"))
+		})
+
+		It("reads inline schemas", func() {
+			var schema types.MessageSchema
+			err := yaml.Unmarshal([]byte("type: object\nrequired: [name]"), &schema)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schema.Schema).To(Equal(map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"name"},
+			}))
+		})
+	})
+
+	var _ = Describe("Validate", func() {
+		It("throws an error for an invalid type", func() {
+			schema := types.MessageSchema{Schema: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{"type": "text"},
+				},
+			}}
+			err := schema.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'properties.name.type' must be one of"))
+		})
+
+		It("does not throw an error for a valid schema", func() {
+			schema := types.MessageSchema{Schema: map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"name"},
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{"type": "string"},
+					"tags": map[string]interface{}{
+						"type":  "array",
+						"items": map[string]interface{}{"type": "string"},
+					},
+				},
+			}}
+			Expect(schema.Validate()).NotTo(HaveOccurred())
+		})
+	})
+
+	var _ = Describe("GetIncompatibilities", func() {
+		receiver := types.MessageSchema{Schema: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"name"},
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{"type": "string"},
+			},
+		}}
+
+		It("returns nothing for compatible schemas", func() {
+			sender := types.MessageSchema{Schema: map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"name", "email"},
+				"properties": map[string]interface{}{
+					"name":  map[string]interface{}{"type": "string"},
+					"email": map[string]interface{}{"type": "string"},
+				},
+			}}
+			Expect(sender.GetIncompatibilities(receiver)).To(BeEmpty())
+		})
+
+		It("accepts integers as numbers", func() {
+			sender := types.MessageSchema{Schema: map[string]interface{}{
+				"type": []interface{}{"integer", "null"},
+			}}
+			Expect(sender.GetIncompatibilities(types.MessageSchema{Schema: map[string]interface{}{
+				"type": []interface{}{"number", "null"},
+			}})).To(BeEmpty())
+			Expect(receiver.GetIncompatibilities(types.MessageSchema{Schema: map[string]interface{}{
+				"type": "integer",
+			}})).To(Equal([]string{"'type' is 'object' for the sender but 'integer' for the receiver"}))
+		})
+
+		It("returns missing required fields and mismatching types", func() {
+			sender := types.MessageSchema{Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{"type": "integer"},
+				},
+			}}
+			Expect(sender.GetIncompatibilities(receiver)).To(Equal([]string{
+				"'name' is required by the receiver but not by the sender",
+				"'name.type' is 'integer' for the sender but 'string' for the receiver",
+			}))
+		})
+	})
+})
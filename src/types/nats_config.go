@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// NatsConfig holds configuration fields for a nats dependency
+type NatsConfig struct {
+	ClusterSize         string `yaml:"cluster-size,omitempty"`
+	AuthTokenSecretName string `yaml:"auth-token-secret-name,omitempty"`
+}
+
+// GetClusterSize returns the number of NATS servers to run, defaulting to 1
+func (n NatsConfig) GetClusterSize() string {
+	if n.ClusterSize == "" {
+		return "1"
+	}
+	return n.ClusterSize
+}
+
+// ValidateFields validates that a nats config contains valid fields
+func (n NatsConfig) ValidateFields() error {
+	if n.ClusterSize != "" {
+		clusterSize, err := strconv.Atoi(n.ClusterSize)
+		if err != nil || clusterSize < 1 {
+			return fmt.Errorf("invalid value '%s' for 'nats.cluster-size'. Must be a positive integer", n.ClusterSize)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Originate/exosphere/src/util"
+	"github.com/pkg/errors"
+)
+
+// QueueConfig holds configuration fields for a queue dependency
+type QueueConfig struct {
+	Queues []Queue      `yaml:",omitempty"`
+	Topics []QueueTopic `yaml:",omitempty"`
+}
+
+// Queue represents an SQS queue of a queue dependency
+type Queue struct {
+	Name                   string          `yaml:",omitempty"`
+	VisibilityTimeout      string          `yaml:"visibility-timeout,omitempty"`
+	MessageRetentionPeriod string          `yaml:"message-retention-period,omitempty"`
+	DeadLetter             QueueDeadLetter `yaml:"dead-letter,omitempty"`
+}
+
+// QueueDeadLetter represents the dead-letter settings of a queue: messages that are received
+// more than MaxReceiveCount times are moved to the queue with the given name
+type QueueDeadLetter struct {
+	Queue           string `yaml:",omitempty"`
+	MaxReceiveCount string `yaml:"max-receive-count,omitempty"`
+}
+
+// QueueTopic represents an SNS topic of a queue dependency,
+// which delivers the messages published to it to the subscribed queues
+type QueueTopic struct {
+	Name          string   `yaml:",omitempty"`
+	Subscriptions []string `yaml:",omitempty"`
+}
+
+// GetQueueName returns the name of the queue in SQS, which is prefixed with the application name
+func (q Queue) GetQueueName(appName string) string {
+	return fmt.Sprintf("%s-%s", appName, q.Name)
+}
+
+// GetURLEnvVarName returns the name of the env var holding the queue URL, e.g. QUEUE_EMAIL_JOBS_URL
+func (q Queue) GetURLEnvVarName() string {
+	return fmt.Sprintf("QUEUE_%s_URL", strings.ToUpper(util.ToSnake(q.Name)))
+}
+
+// GetMaxReceiveCount returns how often a message is received before it is moved
+// to the dead-letter queue, defaulting to 5
+func (q QueueDeadLetter) GetMaxReceiveCount() string {
+	if q.MaxReceiveCount == "" {
+		return "5"
+	}
+	return q.MaxReceiveCount
+}
+
+// GetTopicName returns the name of the topic in SNS, which is prefixed with the application name
+func (q QueueTopic) GetTopicName(appName string) string {
+	return fmt.Sprintf("%s-%s", appName, q.Name)
+}
+
+// GetArnEnvVarName returns the name of the env var holding the topic ARN, e.g. TOPIC_USER_EVENTS_ARN
+func (q QueueTopic) GetArnEnvVarName() string {
+	return fmt.Sprintf("TOPIC_%s_ARN", strings.ToUpper(util.ToSnake(q.Name)))
+}
+
+// ValidateFields validates that a queue config contains valid fields
+func (q QueueConfig) ValidateFields() error {
+	if len(q.Queues) == 0 && len(q.Topics) == 0 {
+		return errors.New("'queue' must declare at least one queue or topic")
+	}
+	nameRegex := regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9_-]*$")
+	queueNames := []string{}
+	for _, queue := range q.Queues {
+		if !nameRegex.MatchString(queue.Name) {
+			return fmt.Errorf("invalid queue name '%s'. Only alphanumeric characters, underscores and hyphens allowed, starting with a letter", queue.Name)
+		}
+		if util.DoesStringArrayContain(queueNames, queue.Name) {
+			return fmt.Errorf("queue '%s' is declared more than once", queue.Name)
+		}
+		queueNames = append(queueNames, queue.Name)
+	}
+	for _, queue := range q.Queues {
+		for field, value := range map[string]string{"visibility-timeout": queue.VisibilityTimeout, "message-retention-period": queue.MessageRetentionPeriod} {
+			if value == "" {
+				continue
+			}
+			if seconds, err := strconv.Atoi(value); err != nil || seconds < 0 {
+				return fmt.Errorf("invalid value '%s' for '%s' of queue '%s'. Must be a number of seconds", value, field, queue.Name)
+			}
+		}
+		if queue.DeadLetter.Queue != "" {
+			if queue.DeadLetter.Queue == queue.Name || !util.DoesStringArrayContain(queueNames, queue.DeadLetter.Queue) {
+				return fmt.Errorf("the dead-letter queue '%s' of queue '%s' must be another declared queue", queue.DeadLetter.Queue, queue.Name)
+			}
+			if count, err := strconv.Atoi(queue.DeadLetter.GetMaxReceiveCount()); err != nil || count < 1 {
+				return fmt.Errorf("invalid value '%s' for 'dead-letter.max-receive-count' of queue '%s'. Must be a positive integer", queue.DeadLetter.MaxReceiveCount, queue.Name)
+			}
+		}
+	}
+	topicNames := []string{}
+	for _, topic := range q.Topics {
+		if !nameRegex.MatchString(topic.Name) {
+			return fmt.Errorf("invalid topic name '%s'. Only alphanumeric characters, underscores and hyphens allowed, starting with a letter", topic.Name)
+		}
+		if util.DoesStringArrayContain(topicNames, topic.Name) {
+			return fmt.Errorf("topic '%s' is declared more than once", topic.Name)
+		}
+		topicNames = append(topicNames, topic.Name)
+		for _, subscription := range topic.Subscriptions {
+			if !util.DoesStringArrayContain(queueNames, subscription) {
+				return fmt.Errorf("topic '%s' subscribes unknown queue '%s'", topic.Name, subscription)
+			}
+		}
+	}
+	return nil
+}
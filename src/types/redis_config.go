@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RedisConfig holds configuration fields for a redis dependency
+type RedisConfig struct {
+	Name               string                  `yaml:",omitempty"`
+	NodeType           string                  `yaml:"node-type,omitempty"`
+	NumCacheClusters   string                  `yaml:"num-cache-clusters,omitempty"`
+	ServiceEnvVarNames RedisServiceEnvVarNames `yaml:"service-env-var-names,omitempty"`
+}
+
+// RedisServiceEnvVarNames are the names of redis related env vars injected into a service
+type RedisServiceEnvVarNames struct {
+	URL string `yaml:",omitempty"`
+}
+
+// GetName returns the name of the replication group, defaulting to "redis"
+func (r RedisConfig) GetName() string {
+	if r.Name == "" {
+		return "redis"
+	}
+	return r.Name
+}
+
+// GetNodeType returns the ElastiCache node type, defaulting to the smallest one
+func (r RedisConfig) GetNodeType() string {
+	if r.NodeType == "" {
+		return "cache.t2.micro"
+	}
+	return r.NodeType
+}
+
+// GetNumCacheClusters returns the number of nodes in the replication group, defaulting to 1
+func (r RedisConfig) GetNumCacheClusters() string {
+	if r.NumCacheClusters == "" {
+		return "1"
+	}
+	return r.NumCacheClusters
+}
+
+// GetURLEnvVarName returns the name of the env var holding the redis URL, defaulting to REDIS_URL
+func (r RedisConfig) GetURLEnvVarName() string {
+	if r.ServiceEnvVarNames.URL == "" {
+		return "REDIS_URL"
+	}
+	return r.ServiceEnvVarNames.URL
+}
+
+// ValidateFields validates that a redis config contains valid fields
+func (r RedisConfig) ValidateFields() error {
+	nameRegex := regexp.MustCompile("^[a-z][a-z0-9-]*$")
+	if !nameRegex.MatchString(r.GetName()) {
+		return errors.New("only lowercase alphanumeric characters and hyphens allowed in 'redis.name', starting with a letter")
+	}
+	numCacheClusters, err := strconv.Atoi(r.GetNumCacheClusters())
+	if err != nil || numCacheClusters < 1 || numCacheClusters > 6 {
+		return fmt.Errorf("invalid value '%s' for 'redis.num-cache-clusters'. Must be an integer between 1 and 6", r.NumCacheClusters)
+	}
+	if numCacheClusters > 1 && (strings.HasPrefix(r.GetNodeType(), "cache.t1.") || strings.HasPrefix(r.GetNodeType(), "cache.t2.")) {
+		return fmt.Errorf("'redis.num-cache-clusters' greater than 1 enables automatic failover, which is not supported by the node type '%s'. Please set 'redis.node-type' to a larger node type", r.GetNodeType())
+	}
+	return nil
+}
@@ -32,5 +32,21 @@ func (p *RemoteDependency) ValidateFields() error {
 			return errors.Wrap(err, fmt.Sprintf("production dependency %s:%s has issues", p.Name, p.Version))
 		}
 	}
+	var err error
+	switch p.Name {
+	case "nats":
+		err = p.Config.Nats.ValidateFields()
+	case "redis":
+		err = p.Config.Redis.ValidateFields()
+	case "dynamodb":
+		err = p.Config.Dynamodb.ValidateFields()
+	case "s3":
+		err = p.Config.S3.ValidateFields()
+	case "queue":
+		err = p.Config.Queue.ValidateFields()
+	}
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("production dependency %s:%s has issues", p.Name, p.Version))
+	}
 	return nil
 }
@@ -2,5 +2,11 @@ package types
 
 // RemoteDependencyConfig represents the configuration of a development dependency
 type RemoteDependencyConfig struct {
-	Rds RdsConfig `yaml:",omitempty"`
+	Rds       RdsConfig         `yaml:",omitempty"`
+	Nats      NatsConfig        `yaml:",omitempty"`
+	Redis     RedisConfig       `yaml:",omitempty"`
+	Dynamodb  DynamoDBConfig    `yaml:",omitempty"`
+	S3        S3Config          `yaml:",omitempty"`
+	Queue     QueueConfig       `yaml:",omitempty"`
+	Variables map[string]string `yaml:",omitempty"`
 }
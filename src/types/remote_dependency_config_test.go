@@ -60,6 +60,134 @@ var _ = Describe("RemoteDependency", func() {
 			Expect(err.Error()).To(ContainSubstring(expectedErrorString))
 		})
 
+		It("throws an error if the nats cluster size is not a positive integer", func() {
+			natsConfig := types.RemoteDependency{
+				Name:    "nats",
+				Version: "0.9.6",
+				Config: types.RemoteDependencyConfig{
+					Nats: types.NatsConfig{ClusterSize: "0"},
+				},
+			}
+			err := natsConfig.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("production dependency nats:0.9.6 has issues: invalid value '0' for 'nats.cluster-size'. Must be a positive integer"))
+		})
+
+		It("throws an error if the number of redis cache clusters is invalid", func() {
+			redisConfig := types.RemoteDependency{
+				Name:    "redis",
+				Version: "3.2.10",
+				Config: types.RemoteDependencyConfig{
+					Redis: types.RedisConfig{NumCacheClusters: "7"},
+				},
+			}
+			err := redisConfig.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("production dependency redis:3.2.10 has issues: invalid value '7' for 'redis.num-cache-clusters'. Must be an integer between 1 and 6"))
+		})
+
+		It("throws an error if automatic failover is enabled for nodes that don't support it", func() {
+			redisConfig := types.RemoteDependency{
+				Name:    "redis",
+				Version: "3.2.10",
+				Config: types.RemoteDependencyConfig{
+					Redis: types.RedisConfig{NumCacheClusters: "2"},
+				},
+			}
+			err := redisConfig.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("production dependency redis:3.2.10 has issues: 'redis.num-cache-clusters' greater than 1 enables automatic failover, which is not supported by the node type 'cache.t2.micro'. Please set 'redis.node-type' to a larger node type"))
+		})
+
+		It("throws an error if a dynamodb key is not declared as an attribute", func() {
+			dynamodbConfig := types.RemoteDependency{
+				Name: "dynamodb",
+				Config: types.RemoteDependencyConfig{
+					Dynamodb: types.DynamoDBConfig{
+						Tables: []types.DynamoDBTable{
+							{Name: "users", HashKey: "id", Attributes: map[string]string{"email": "S"}},
+						},
+					},
+				},
+			}
+			err := dynamodbConfig.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("production dependency dynamodb: has issues: table 'users' has issues: key 'id' must be declared in 'attributes'"))
+		})
+
+		It("throws an error if a dynamodb attribute has an invalid type", func() {
+			dynamodbConfig := types.RemoteDependency{
+				Name: "dynamodb",
+				Config: types.RemoteDependencyConfig{
+					Dynamodb: types.DynamoDBConfig{
+						Tables: []types.DynamoDBTable{
+							{Name: "users", HashKey: "id", Attributes: map[string]string{"id": "string"}},
+						},
+					},
+				},
+			}
+			err := dynamodbConfig.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("production dependency dynamodb: has issues: table 'users' has issues: invalid type 'string' for attribute 'id'. Must be one of: S, N, B"))
+		})
+
+		It("throws an error if an s3 bucket name is invalid", func() {
+			s3Config := types.RemoteDependency{
+				Name: "s3",
+				Config: types.RemoteDependencyConfig{
+					S3: types.S3Config{Buckets: []types.S3Bucket{{Name: "User_Uploads"}}},
+				},
+			}
+			err := s3Config.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("production dependency s3: has issues: bucket 'User_Uploads' has issues: only lowercase alphanumeric characters and hyphens allowed in 'name'"))
+		})
+
+		It("throws an error if an s3 lifecycle rule has no expiration", func() {
+			s3Config := types.RemoteDependency{
+				Name: "s3",
+				Config: types.RemoteDependencyConfig{
+					S3: types.S3Config{
+						Buckets: []types.S3Bucket{
+							{Name: "uploads", Lifecycle: []types.S3Lifecycle{{Prefix: "tmp/"}}},
+						},
+					},
+				},
+			}
+			err := s3Config.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("production dependency s3: has issues: bucket 'uploads' has issues: invalid value '' for 'expiration-days'. Must be a positive integer"))
+		})
+
+		It("throws an error if a topic subscribes a queue that is not declared", func() {
+			queueConfig := types.RemoteDependency{
+				Name: "queue",
+				Config: types.RemoteDependencyConfig{
+					Queue: types.QueueConfig{
+						Queues: []types.Queue{{Name: "email-jobs"}},
+						Topics: []types.QueueTopic{{Name: "user-events", Subscriptions: []string{"sms-jobs"}}},
+					},
+				},
+			}
+			err := queueConfig.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("production dependency queue: has issues: topic 'user-events' subscribes unknown queue 'sms-jobs'"))
+		})
+
+		It("throws an error if the dead-letter queue of a queue is not declared", func() {
+			queueConfig := types.RemoteDependency{
+				Name: "queue",
+				Config: types.RemoteDependencyConfig{
+					Queue: types.QueueConfig{
+						Queues: []types.Queue{{Name: "email-jobs", DeadLetter: types.QueueDeadLetter{Queue: "failed"}}},
+					},
+				},
+			}
+			err := queueConfig.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("production dependency queue: has issues: the dead-letter queue 'failed' of queue 'email-jobs' must be another declared queue"))
+		})
+
 		It("does not throw an error if production fields are valid", func() {
 			goodConfig := types.RemoteDependency{
 				Name:    "postgres",
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Originate/exosphere/src/util"
+	"github.com/pkg/errors"
+)
+
+// S3Config holds configuration fields for an s3 dependency
+type S3Config struct {
+	Buckets []S3Bucket `yaml:",omitempty"`
+}
+
+// S3Bucket represents a bucket of an s3 dependency
+type S3Bucket struct {
+	Name      string        `yaml:",omitempty"`
+	Cors      []S3CorsRule  `yaml:",omitempty"`
+	Lifecycle []S3Lifecycle `yaml:",omitempty"`
+}
+
+// S3CorsRule represents a CORS rule of a bucket
+type S3CorsRule struct {
+	AllowedOrigins []string `yaml:"allowed-origins,omitempty"`
+	AllowedMethods []string `yaml:"allowed-methods,omitempty"`
+	AllowedHeaders []string `yaml:"allowed-headers,omitempty"`
+	MaxAgeSeconds  string   `yaml:"max-age-seconds,omitempty"`
+}
+
+// S3Lifecycle represents a lifecycle rule of a bucket, expiring the objects under the given prefix
+type S3Lifecycle struct {
+	Prefix         string `yaml:",omitempty"`
+	ExpirationDays string `yaml:"expiration-days,omitempty"`
+}
+
+var s3CorsMethods = []string{"GET", "PUT", "POST", "DELETE", "HEAD"}
+
+// GetBucketName returns the name of the bucket in S3, which is prefixed with the application name
+// since bucket names are globally unique
+func (s S3Bucket) GetBucketName(appName string) string {
+	return fmt.Sprintf("%s-%s", appName, s.Name)
+}
+
+// GetEnvVarName returns the name of the env var holding the bucket name, e.g. S3_BUCKET_USER_UPLOADS
+func (s S3Bucket) GetEnvVarName() string {
+	return "S3_BUCKET_" + strings.ToUpper(util.ToSnake(s.Name))
+}
+
+// ValidateFields validates that an s3 config contains valid fields
+func (s S3Config) ValidateFields() error {
+	if len(s.Buckets) == 0 {
+		return errors.New("'s3.buckets' must declare at least one bucket")
+	}
+	bucketNames := []string{}
+	for _, bucket := range s.Buckets {
+		if util.DoesStringArrayContain(bucketNames, bucket.Name) {
+			return fmt.Errorf("bucket '%s' is declared more than once", bucket.Name)
+		}
+		bucketNames = append(bucketNames, bucket.Name)
+		err := bucket.ValidateFields()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("bucket '%s' has issues", bucket.Name))
+		}
+	}
+	return nil
+}
+
+// ValidateFields validates that an s3 bucket contains valid fields
+func (s S3Bucket) ValidateFields() error {
+	nameRegex := regexp.MustCompile("^[a-z0-9][a-z0-9-]*$")
+	if !nameRegex.MatchString(s.Name) {
+		return errors.New("only lowercase alphanumeric characters and hyphens allowed in 'name'")
+	}
+	for _, rule := range s.Cors {
+		if len(rule.AllowedOrigins) == 0 || len(rule.AllowedMethods) == 0 {
+			return errors.New("CORS rules must have 'allowed-origins' and 'allowed-methods'")
+		}
+		for _, method := range rule.AllowedMethods {
+			if !util.DoesStringArrayContain(s3CorsMethods, method) {
+				return fmt.Errorf("invalid CORS method '%s'. Must be one of: %s", method, strings.Join(s3CorsMethods, ", "))
+			}
+		}
+		if rule.MaxAgeSeconds != "" {
+			if value, err := strconv.Atoi(rule.MaxAgeSeconds); err != nil || value < 0 {
+				return fmt.Errorf("invalid value '%s' for 'max-age-seconds'. Must be a non-negative integer", rule.MaxAgeSeconds)
+			}
+		}
+	}
+	for _, lifecycle := range s.Lifecycle {
+		if value, err := strconv.Atoi(lifecycle.ExpirationDays); err != nil || value < 1 {
+			return fmt.Errorf("invalid value '%s' for 'expiration-days'. Must be a positive integer", lifecycle.ExpirationDays)
+		}
+	}
+	return nil
+}
@@ -17,12 +17,16 @@ const ServiceTypePublic = "public"
 // ServiceTypeWorker is the value for the type field of a worker service
 const ServiceTypeWorker = "worker"
 
+// ServiceTypeScheduled is the value for the type field of a service that runs on a schedule
+const ServiceTypeScheduled = "scheduled"
+
 // ServiceConfig represents the configuration of a service as provided in
 // service.yml
 type ServiceConfig struct {
 	Type            string `yaml:",omitempty"`
 	Description     string `yaml:",omitempty"`
 	Author          string `yaml:",omitempty"`
+	Schedule        string `yaml:",omitempty"`
 	ServiceMessages `yaml:"messages,omitempty"`
 	Docker          DockerConfig             `yaml:",omitempty"`
 	Environment     EnvVars                  `yaml:",omitempty"`
@@ -30,6 +34,7 @@ type ServiceConfig struct {
 	Local           LocalConfig              `yaml:",omitempty"`
 	Production      ServiceProductionConfig  `yaml:",omitempty"`
 	Remote          ServiceRemoteConfig
+	Migrations      ServiceMigrationsConfig `yaml:",omitempty"`
 }
 
 // NewServiceConfig returns a validated ServiceConfig object given the app directory path
@@ -43,6 +48,12 @@ func NewServiceConfig(serviceLocation string) (ServiceConfig, error) {
 	if err = yaml.Unmarshal(yamlFile, &serviceConfig); err != nil {
 		return serviceConfig, errors.Wrap(err, fmt.Sprintf("Failed to unmarshal service.yml for the internal service '%s'", path.Base(serviceLocation)))
 	}
+	if err = serviceConfig.ServiceMessages.LoadSchemas(serviceLocation); err != nil {
+		return serviceConfig, errors.Wrap(err, fmt.Sprintf("Failed to load message schemas for the internal service '%s'", path.Base(serviceLocation)))
+	}
+	if err = serviceConfig.Migrations.ValidateFields(serviceLocation); err != nil {
+		return serviceConfig, errors.Wrap(err, fmt.Sprintf("Invalid migrations for the internal service '%s'", path.Base(serviceLocation)))
+	}
 	return serviceConfig, serviceConfig.ValidateServiceConfig()
 }
 
@@ -65,11 +76,22 @@ func (s ServiceConfig) GetEnvVars(environment string) (map[string]string, []stri
 
 // ValidateServiceConfig validates a ServiceConfig object
 func (s ServiceConfig) ValidateServiceConfig() error {
-	validTypes := []string{ServiceTypePublic, ServiceTypeWorker}
+	validTypes := []string{ServiceTypePublic, ServiceTypeWorker, ServiceTypeScheduled}
 	if !util.DoesStringArrayContain(validTypes, s.Type) {
 		return fmt.Errorf("Invalid value '%s' in service.yml field 'type'. Must be one of: %s", s.Type, strings.Join(validTypes, ", "))
 	}
-	return nil
+	if err := s.validateSchedule(); err != nil {
+		return err
+	}
+	if err := s.ServiceMessages.ValidateSchemas(); err != nil {
+		return err
+	}
+	for _, dependency := range s.Local.Dependencies {
+		if err := dependency.ValidateFields(); err != nil {
+			return err
+		}
+	}
+	return s.Development.Test.ValidateFields()
 }
 
 // ValidateDeployFields validates a serviceConfig for deployment
@@ -80,3 +102,22 @@ func (s ServiceConfig) ValidateDeployFields(serviceLocation, protectionLevel str
 	}
 	return s.Remote.ValidateRemoteFields(serviceLocation, protectionLevel)
 }
+
+// GetCronSchedule returns the parsed schedule of a scheduled service
+func (s ServiceConfig) GetCronSchedule() (CronSchedule, error) {
+	return ParseCronSchedule(s.Schedule)
+}
+
+func (s ServiceConfig) validateSchedule() error {
+	if s.Type != ServiceTypeScheduled {
+		if s.Schedule != "" {
+			return fmt.Errorf("service.yml field 'schedule' is only allowed for services of type '%s'", ServiceTypeScheduled)
+		}
+		return nil
+	}
+	if s.Schedule == "" {
+		return fmt.Errorf("service.yml missing required field 'schedule' for services of type '%s'", ServiceTypeScheduled)
+	}
+	_, err := s.GetCronSchedule()
+	return errors.Wrap(err, "Invalid value in service.yml field 'schedule'")
+}
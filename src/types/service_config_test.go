@@ -1,6 +1,11 @@
 package types_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
 	"github.com/Originate/exosphere/src/types"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -23,6 +28,79 @@ var _ = Describe("ServiceConfig", func() {
 			err := rightType.ValidateServiceConfig()
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("throws an error if a message schema is for an undeclared message", func() {
+			serviceConfig := types.ServiceConfig{
+				Type: "public",
+				ServiceMessages: types.ServiceMessages{
+					Sends: []string{"users.create"},
+					Schemas: map[string]types.MessageSchema{
+						"users.delete": {Schema: map[string]interface{}{"type": "object"}},
+					},
+				},
+			}
+			err := serviceConfig.ValidateServiceConfig()
+			Expect(err).To(HaveOccurred())
+			expectedErrorString := "service.yml field 'messages.schemas' contains a schema for 'users.delete' which is neither sent nor received"
+			Expect(err.Error()).To(ContainSubstring(expectedErrorString))
+		})
+
+		It("throws an error if the test timeout is invalid", func() {
+			serviceConfig := types.ServiceConfig{
+				Type: "public",
+				Development: types.ServiceDevelopmentConfig{
+					Test: types.ServiceTestConfig{Timeout: "forever"},
+				},
+			}
+			err := serviceConfig.ValidateServiceConfig()
+			Expect(err).To(HaveOccurred())
+			expectedErrorString := "Invalid value 'forever' in service.yml field 'development.test.timeout'"
+			Expect(err.Error()).To(ContainSubstring(expectedErrorString))
+		})
+
+		It("throws an error if the test retries are negative", func() {
+			serviceConfig := types.ServiceConfig{
+				Type: "public",
+				Development: types.ServiceDevelopmentConfig{
+					Test: types.ServiceTestConfig{Retries: -1},
+				},
+			}
+			err := serviceConfig.ValidateServiceConfig()
+			Expect(err).To(HaveOccurred())
+			expectedErrorString := "Invalid value '-1' in service.yml field 'development.test.retries'"
+			Expect(err.Error()).To(ContainSubstring(expectedErrorString))
+		})
+	})
+
+	Describe("schedule", func() {
+		It("requires a schedule for scheduled services", func() {
+			err := types.ServiceConfig{Type: "scheduled"}.ValidateServiceConfig()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("service.yml missing required field 'schedule' for services of type 'scheduled'"))
+		})
+
+		It("throws an error if the schedule is invalid", func() {
+			err := types.ServiceConfig{Type: "scheduled", Schedule: "0 25 * * *"}.ValidateServiceConfig()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Invalid value in service.yml field 'schedule': invalid cron expression '0 25 * * *': invalid value '25' in the hour field. Must be between 0 and 23"))
+		})
+
+		It("throws an error if a service that is not scheduled declares a schedule", func() {
+			err := types.ServiceConfig{Type: "worker", Schedule: "0 3 * * *"}.ValidateServiceConfig()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("service.yml field 'schedule' is only allowed for services of type 'scheduled'"))
+		})
+
+		It("does not throw an error for a valid scheduled service", func() {
+			err := types.ServiceConfig{Type: "scheduled", Schedule: "30 2 * * MON-FRI"}.ValidateServiceConfig()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not support scaling scheduled services", func() {
+			err := types.ServiceScalingConfig{Max: 2}.ValidateFields("scheduled", types.ServiceDeployConfig{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'remote.scaling' is not supported for scheduled services"))
+		})
 	})
 
 	Describe("validates required production fields", func() {
@@ -94,4 +172,148 @@ var _ = Describe("ServiceConfig", func() {
 		})
 	})
 
+	Describe("migrations", func() {
+		var serviceLocation string
+
+		BeforeEach(func() {
+			var err error
+			serviceLocation, err = ioutil.TempDir("", "service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.Mkdir(path.Join(serviceLocation, "migrations"), 0777)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(serviceLocation)).To(Succeed())
+		})
+
+		It("does not throw an error if no migrations are declared", func() {
+			err := types.ServiceMigrationsConfig{}.ValidateFields(serviceLocation)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not throw an error if the migrations directory exists", func() {
+			err := types.ServiceMigrationsConfig{Command: "bin/migrations", Directory: "migrations"}.ValidateFields(serviceLocation)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("throws an error if the migrations directory does not exist", func() {
+			err := types.ServiceMigrationsConfig{Command: "bin/migrations", Directory: "db"}.ValidateFields(serviceLocation)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the migrations directory 'db' does not exist"))
+		})
+
+		It("throws an error if the migrations directory is outside of the service", func() {
+			err := types.ServiceMigrationsConfig{Command: "bin/migrations", Directory: "../migrations"}.ValidateFields(serviceLocation)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'migrations.directory' must be a path inside the service"))
+		})
+
+		It("throws an error if a directory is declared without a command", func() {
+			err := types.ServiceMigrationsConfig{Directory: "migrations"}.ValidateFields(serviceLocation)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'migrations.directory' requires 'migrations.command'"))
+		})
+
+		It("throws an error if the timeout is negative", func() {
+			err := types.ServiceMigrationsConfig{Command: "bin/migrations", Directory: "migrations", Timeout: -1}.ValidateFields(serviceLocation)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid value '-1' in field 'migrations.timeout'"))
+		})
+
+		It("waits 10 minutes for the migrations by default", func() {
+			Expect(types.ServiceMigrationsConfig{}.GetTimeout()).To(Equal(10 * time.Minute))
+			Expect(types.ServiceMigrationsConfig{Timeout: 1800}.GetTimeout()).To(Equal(30 * time.Minute))
+		})
+
+		It("appends the action to the command and passes the directory", func() {
+			migrations := types.ServiceMigrationsConfig{Command: "bin/migrations", Directory: "migrations"}
+			Expect(migrations.GetCommand(types.MigrationActionRollback)).To(Equal([]string{"env", "MIGRATIONS_DIR=migrations", "sh", "-c", "bin/migrations rollback"}))
+		})
+	})
+
+	Describe("scaling", func() {
+		It("runs one task by default", func() {
+			scalingConfig := types.ServiceScalingConfig{}
+			Expect(scalingConfig.GetMin()).To(Equal(1))
+			Expect(scalingConfig.GetMax()).To(Equal(1))
+			Expect(scalingConfig.GetDesired()).To(Equal(1))
+			Expect(scalingConfig.ValidateFields("worker", types.ServiceDeployConfig{})).To(Succeed())
+		})
+
+		It("throws an error if the desired count is out of bounds", func() {
+			err := types.ServiceScalingConfig{Min: 2, Max: 4, Desired: 5}.ValidateFields("public", types.ServiceDeployConfig{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'remote.scaling.desired' (5) must be between 'remote.scaling.min' (2) and 'remote.scaling.max' (4)"))
+		})
+
+		It("throws an error if policies cannot change the number of tasks", func() {
+			err := types.ServiceScalingConfig{Min: 2, Policies: []types.ServiceScalingPolicy{{Metric: "cpu", Target: 50}}}.ValidateFields("public", types.ServiceDeployConfig{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("require 'remote.scaling.max' to be greater than 'remote.scaling.min'"))
+		})
+
+		It("throws an error if a worker service scales on requests", func() {
+			err := types.ServiceScalingConfig{Max: 4, Policies: []types.ServiceScalingPolicy{{Metric: "requests", Target: 1000}}}.ValidateFields("worker", types.ServiceDeployConfig{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the requests scaling policy requires a public service"))
+		})
+
+		It("throws an error if a queue depth policy declares no queue", func() {
+			err := types.ServiceScalingConfig{Max: 4, Policies: []types.ServiceScalingPolicy{{Metric: "queue-depth", Target: 10}}}.ValidateFields("worker", types.ServiceDeployConfig{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the queue-depth scaling policy requires a 'queue'"))
+		})
+
+		It("throws an error if a percentage target is above 100", func() {
+			err := types.ServiceScalingConfig{Max: 4, Policies: []types.ServiceScalingPolicy{{Metric: "memory", Target: 120}}}.ValidateFields("worker", types.ServiceDeployConfig{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the target of the memory scaling policy is a percentage, got 120"))
+		})
+	})
+
+	Describe("deploy strategy", func() {
+		It("defaults to rolling deploys", func() {
+			deployConfig := types.ServiceDeployConfig{}
+			Expect(deployConfig.GetStrategy()).To(Equal(types.DeployStrategyRolling))
+			Expect(deployConfig.ShiftsTraffic()).To(BeFalse())
+			Expect(deployConfig.ValidateFields("worker")).To(Succeed())
+		})
+
+		It("shifts 10% of the traffic first for canary deploys by default", func() {
+			deployConfig := types.ServiceDeployConfig{Strategy: "canary"}
+			Expect(deployConfig.ShiftsTraffic()).To(BeTrue())
+			Expect(deployConfig.GetTrafficPercentages()).To(Equal([]int{10}))
+			Expect(deployConfig.ValidateFields("public")).To(Succeed())
+		})
+
+		It("shifts all traffic at once for blue-green deploys", func() {
+			deployConfig := types.ServiceDeployConfig{Strategy: "blue-green"}
+			Expect(deployConfig.GetTrafficPercentages()).To(BeEmpty())
+		})
+
+		It("throws an error if the strategy is unsupported", func() {
+			err := types.ServiceDeployConfig{Strategy: "big-bang"}.ValidateFields("public")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid value 'big-bang' in field 'remote.deploy.strategy'. Must be one of: rolling, blue-green, canary"))
+		})
+
+		It("throws an error if a worker service shifts traffic", func() {
+			err := types.ServiceDeployConfig{Strategy: "blue-green"}.ValidateFields("worker")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the blue-green strategy requires a public service"))
+		})
+
+		It("throws an error if the traffic percentages are not increasing", func() {
+			err := types.ServiceDeployConfig{Strategy: "canary", TrafficPercentages: []int{50, 10}}.ValidateFields("public")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid value '10' in field 'remote.deploy.traffic-percentages'"))
+		})
+
+		It("throws an error if rolling deploys declare traffic shifting settings", func() {
+			err := types.ServiceDeployConfig{BakeTime: 60}.ValidateFields("public")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("require the blue-green or canary strategy"))
+		})
+	})
+
 })
@@ -1,8 +1,24 @@
 package types
 
+import "strings"
+
 // ServiceSource represents the service info as provided in application.yml
 type ServiceSource struct {
 	Location            string               `yaml:",omitempty"`
 	DockerImage         string               `yaml:"docker-image,omitempty"`
 	MessageTranslations []MessageTranslation `yaml:"message-translation,omitempty"`
 }
+
+// GetPublicMessageName returns the name under which the rest of the application
+// sees the given message of this service, applying the message translations
+func (s ServiceSource) GetPublicMessageName(messageName string) string {
+	for _, translation := range s.MessageTranslations {
+		if messageName == translation.Internal {
+			return translation.Public
+		}
+		if strings.HasPrefix(messageName, translation.Internal+".") {
+			return translation.Public + strings.TrimPrefix(messageName, translation.Internal)
+		}
+	}
+	return messageName
+}
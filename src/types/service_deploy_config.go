@@ -0,0 +1,101 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Originate/exosphere/src/util"
+	"github.com/pkg/errors"
+)
+
+// Possible strategies to replace the running version of a service
+const (
+	DeployStrategyRolling   = "rolling"
+	DeployStrategyBlueGreen = "blue-green"
+	DeployStrategyCanary    = "canary"
+)
+
+// ServiceDeployConfig represents how a deploy replaces the running version of a service
+// as provided under remote.deploy in service.yml
+type ServiceDeployConfig struct {
+	Strategy           string                    `yaml:",omitempty"`
+	TrafficPercentages []int                     `yaml:"traffic-percentages,omitempty"`
+	BakeTime           int                       `yaml:"bake-time,omitempty"`
+	AutoRollback       ServiceAutoRollbackConfig `yaml:"auto-rollback,omitempty"`
+}
+
+// ServiceAutoRollbackConfig represents the conditions under which a deploy rolls back
+// to the previous version of a service while shifting traffic to the new one
+type ServiceAutoRollbackConfig struct {
+	Max5xxErrors       int  `yaml:"max-5xx-errors,omitempty"`
+	HealthCheckFailure bool `yaml:"health-check-failure,omitempty"`
+}
+
+// GetStrategy returns the deploy strategy, defaulting to rolling
+func (d ServiceDeployConfig) GetStrategy() string {
+	if d.Strategy == "" {
+		return DeployStrategyRolling
+	}
+	return d.Strategy
+}
+
+// ShiftsTraffic returns whether the new version of the service runs next to the running one
+// and receives traffic once it is healthy, as opposed to replacing the running tasks in place
+func (d ServiceDeployConfig) ShiftsTraffic() bool {
+	return d.GetStrategy() != DeployStrategyRolling
+}
+
+// GetTrafficPercentages returns the percentages of traffic the new version receives before all of it,
+// defaulting to 10% for canary deploys. Blue-green deploys shift all traffic at once
+func (d ServiceDeployConfig) GetTrafficPercentages() []int {
+	if d.GetStrategy() != DeployStrategyCanary {
+		return []int{}
+	}
+	if len(d.TrafficPercentages) == 0 {
+		return []int{10}
+	}
+	return d.TrafficPercentages
+}
+
+// GetBakeTime returns how long the new version is monitored after each traffic shift, defaulting to 5 minutes
+func (d ServiceDeployConfig) GetBakeTime() time.Duration {
+	if d.BakeTime == 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(d.BakeTime) * time.Second
+}
+
+// ValidateFields validates that the deploy config of a service of the given type contains valid fields
+func (d ServiceDeployConfig) ValidateFields(serviceType string) error {
+	validStrategies := []string{DeployStrategyRolling, DeployStrategyBlueGreen, DeployStrategyCanary}
+	if !util.DoesStringArrayContain(validStrategies, d.GetStrategy()) {
+		return fmt.Errorf("invalid value '%s' in field 'remote.deploy.strategy'. Must be one of: %s", d.Strategy, strings.Join(validStrategies, ", "))
+	}
+	if !d.ShiftsTraffic() {
+		if len(d.TrafficPercentages) > 0 || d.BakeTime != 0 || d.AutoRollback != (ServiceAutoRollbackConfig{}) {
+			return errors.New("'remote.deploy' settings other than 'strategy' require the blue-green or canary strategy")
+		}
+		return nil
+	}
+	if serviceType != ServiceTypePublic {
+		return fmt.Errorf("the %s strategy requires a public service", d.Strategy)
+	}
+	if len(d.TrafficPercentages) > 0 && d.GetStrategy() != DeployStrategyCanary {
+		return errors.New("'remote.deploy.traffic-percentages' requires the canary strategy")
+	}
+	previousPercentage := 0
+	for _, percentage := range d.TrafficPercentages {
+		if percentage <= previousPercentage || percentage >= 100 {
+			return fmt.Errorf("invalid value '%d' in field 'remote.deploy.traffic-percentages'. The percentages must be increasing and between 1 and 99", percentage)
+		}
+		previousPercentage = percentage
+	}
+	if d.BakeTime < 0 {
+		return fmt.Errorf("invalid value '%d' in field 'remote.deploy.bake-time'. Must be a number of seconds", d.BakeTime)
+	}
+	if d.AutoRollback.Max5xxErrors < 0 {
+		return fmt.Errorf("invalid value '%d' in field 'remote.deploy.auto-rollback.max-5xx-errors'", d.AutoRollback.Max5xxErrors)
+	}
+	return nil
+}
@@ -4,4 +4,5 @@ package types
 type ServiceDevelopmentConfig struct {
 	Scripts map[string]string `yaml:",omitempty"`
 	Port    string            `yaml:",omitempty"`
+	Test    ServiceTestConfig `yaml:",omitempty"`
 }
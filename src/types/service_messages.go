@@ -1,7 +1,51 @@
 package types
 
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Originate/exosphere/src/util"
+)
+
 // ServiceMessages represents the messages that the service sends and receives
 type ServiceMessages struct {
 	Receives []string
 	Sends    []string
+	Schemas  map[string]MessageSchema `yaml:",omitempty"`
+}
+
+// LoadSchemas reads the schema files of all messages relative to the given service location
+func (s ServiceMessages) LoadSchemas(serviceLocation string) error {
+	for messageName, schema := range s.Schemas {
+		err := schema.Load(serviceLocation)
+		if err != nil {
+			return err
+		}
+		s.Schemas[messageName] = schema
+	}
+	return nil
+}
+
+// ValidateSchemas validates that the schemas belong to declared messages and are valid JSON Schemas
+func (s ServiceMessages) ValidateSchemas() error {
+	messageNames := []string{}
+	for messageName := range s.Schemas {
+		messageNames = append(messageNames, messageName)
+	}
+	sort.Strings(messageNames)
+	for _, messageName := range messageNames {
+		if !s.IsDeclared(messageName) {
+			return fmt.Errorf("service.yml field 'messages.schemas' contains a schema for '%s' which is neither sent nor received", messageName)
+		}
+		err := s.Schemas[messageName].Validate()
+		if err != nil {
+			return fmt.Errorf("Invalid schema for message '%s' in service.yml: %s", messageName, err)
+		}
+	}
+	return nil
+}
+
+// IsDeclared returns whether or not the service sends or receives the given message
+func (s ServiceMessages) IsDeclared(messageName string) bool {
+	return util.DoesStringArrayContain(util.JoinStringSlices(s.Sends, s.Receives), messageName)
 }
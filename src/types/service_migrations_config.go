@@ -0,0 +1,75 @@
+package types
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Possible actions of the migrations command of a service
+const (
+	MigrationActionMigrate  = "migrate"
+	MigrationActionStatus   = "status"
+	MigrationActionRollback = "rollback"
+)
+
+// MigrationActions are the actions the migrations command of a service has to support
+var MigrationActions = []string{MigrationActionMigrate, MigrationActionStatus, MigrationActionRollback}
+
+// ServiceMigrationsConfig represents the database migrations of a service as provided in service.yml
+type ServiceMigrationsConfig struct {
+	Command   string `yaml:",omitempty"`
+	Directory string `yaml:",omitempty"`
+	Timeout   int    `yaml:",omitempty"`
+}
+
+// IsConfigured returns whether or not the service declares migrations
+func (m ServiceMigrationsConfig) IsConfigured() bool {
+	return m.Command != ""
+}
+
+// GetTimeout returns how long a deploy waits for the migrations to finish, defaulting to 10 minutes
+func (m ServiceMigrationsConfig) GetTimeout() time.Duration {
+	if m.Timeout == 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(m.Timeout) * time.Second
+}
+
+// GetCommand returns the command that performs the given action inside the service container.
+// The action is appended to the configured command and the migrations directory is passed
+// to it as MIGRATIONS_DIR
+func (m ServiceMigrationsConfig) GetCommand(action string) []string {
+	return []string{"env", fmt.Sprintf("MIGRATIONS_DIR=%s", m.Directory), "sh", "-c", fmt.Sprintf("%s %s", m.Command, action)}
+}
+
+// ValidateFields validates that the migrations config of the service at the given location contains valid fields
+func (m ServiceMigrationsConfig) ValidateFields(serviceLocation string) error {
+	if !m.IsConfigured() {
+		if m.Directory != "" {
+			return errors.New("'migrations.directory' requires 'migrations.command'")
+		}
+		if m.Timeout != 0 {
+			return errors.New("'migrations.timeout' requires 'migrations.command'")
+		}
+		return nil
+	}
+	if m.Timeout < 0 {
+		return fmt.Errorf("invalid value '%d' in field 'migrations.timeout'. Must be a positive number of seconds", m.Timeout)
+	}
+	if m.Directory == "" {
+		return errors.New("missing field 'migrations.directory'")
+	}
+	if path.IsAbs(m.Directory) || strings.HasPrefix(path.Clean(m.Directory), "..") {
+		return fmt.Errorf("'migrations.directory' must be a path inside the service, got '%s'", m.Directory)
+	}
+	fileInfo, err := os.Stat(path.Join(serviceLocation, m.Directory))
+	if err != nil || !fileInfo.IsDir() {
+		return fmt.Errorf("the migrations directory '%s' does not exist", m.Directory)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// the permission presets by their kind, with the actions they allow
+// and the formats of the ARNs of the resource they are given for
+var permissionPresets = map[string]struct {
+	actions         []string
+	resourceFormats []string
+}{
+	"dynamodb:read": {
+		[]string{"dynamodb:BatchGetItem", "dynamodb:DescribeTable", "dynamodb:GetItem", "dynamodb:Query", "dynamodb:Scan"},
+		[]string{"arn:aws:dynamodb:%[1]s:%[2]s:table/%[3]s", "arn:aws:dynamodb:%[1]s:%[2]s:table/%[3]s/index/*"},
+	},
+	"dynamodb:write": {
+		[]string{"dynamodb:BatchWriteItem", "dynamodb:DeleteItem", "dynamodb:PutItem", "dynamodb:UpdateItem"},
+		[]string{"arn:aws:dynamodb:%[1]s:%[2]s:table/%[3]s"},
+	},
+	"s3:read": {
+		[]string{"s3:GetObject", "s3:ListBucket"},
+		[]string{"arn:aws:s3:::%[3]s", "arn:aws:s3:::%[3]s/*"},
+	},
+	"s3:write": {
+		[]string{"s3:DeleteObject", "s3:PutObject"},
+		[]string{"arn:aws:s3:::%[3]s/*"},
+	},
+	"sns:publish": {
+		[]string{"sns:Publish"},
+		[]string{"arn:aws:sns:%[1]s:%[2]s:%[3]s"},
+	},
+	"sqs:receive": {
+		[]string{"sqs:ChangeMessageVisibility", "sqs:DeleteMessage", "sqs:GetQueueAttributes", "sqs:GetQueueUrl", "sqs:ReceiveMessage"},
+		[]string{"arn:aws:sqs:%[1]s:%[2]s:%[3]s"},
+	},
+	"sqs:send": {
+		[]string{"sqs:GetQueueAttributes", "sqs:GetQueueUrl", "sqs:SendMessage"},
+		[]string{"arn:aws:sqs:%[1]s:%[2]s:%[3]s"},
+	},
+}
+
+var iamActionRegex = regexp.MustCompile(`^[a-z0-9-]+:[A-Za-z0-9*]+$`)
+
+// StringList is a list of strings that can be given as a single string in YAML
+type StringList []string
+
+// UnmarshalYAML parses a single string or a list of strings
+func (s *StringList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err == nil {
+		*s = StringList{value}
+		return nil
+	}
+	var values []string
+	if err := unmarshal(&values); err != nil {
+		return err
+	}
+	*s = values
+	return nil
+}
+
+// ServicePermission represents access to AWS resources granted to the task role of a service
+// as provided under remote.permissions in service.yml. It is either an IAM statement
+// or a named preset like s3:read:<bucket>
+type ServicePermission struct {
+	Preset    string                           `yaml:"-"`
+	Effect    string                           `yaml:",omitempty"`
+	Action    StringList                       `yaml:",omitempty"`
+	Resource  StringList                       `yaml:",omitempty"`
+	Condition map[string]map[string]StringList `yaml:",omitempty"`
+}
+
+// UnmarshalYAML parses a preset given as a string or an IAM statement
+func (p *ServicePermission) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var preset string
+	if err := unmarshal(&preset); err == nil {
+		*p = ServicePermission{Preset: preset}
+		return nil
+	}
+	type statement ServicePermission
+	var result statement
+	if err := unmarshal(&result); err != nil {
+		return err
+	}
+	*p = ServicePermission(result)
+	return nil
+}
+
+// MarshalYAML returns the preset or the IAM statement of the permission
+func (p ServicePermission) MarshalYAML() (interface{}, error) {
+	if p.Preset != "" {
+		return p.Preset, nil
+	}
+	type statement ServicePermission
+	return statement(p), nil
+}
+
+// GetEffect returns the effect of the statement, defaulting to Allow
+func (p ServicePermission) GetEffect() string {
+	if p.Effect == "" {
+		return "Allow"
+	}
+	return p.Effect
+}
+
+// GetStatement returns the IAM statement of the permission. Presets are expanded
+// into statements for the resource they name in the given region and account.
+// Names found in the given managed resource names, by the AWS service of the preset,
+// are replaced with the real name of the resource
+func (p ServicePermission) GetStatement(region, accountID string, managedResourceNames map[string]map[string]string) ServicePermission {
+	if p.Preset == "" {
+		return p
+	}
+	kind, resourceName := splitPermissionPreset(p.Preset)
+	if realName, ok := managedResourceNames[strings.Split(kind, ":")[0]][resourceName]; ok {
+		resourceName = realName
+	}
+	preset := permissionPresets[kind]
+	result := ServicePermission{Effect: "Allow", Action: preset.actions}
+	for _, resourceFormat := range preset.resourceFormats {
+		result.Resource = append(result.Resource, fmt.Sprintf(resourceFormat, region, accountID, resourceName))
+	}
+	return result
+}
+
+// ValidateFields validates that the permission is a known preset or a valid IAM statement
+func (p ServicePermission) ValidateFields() error {
+	if p.Preset != "" {
+		kind, resourceName := splitPermissionPreset(p.Preset)
+		if _, ok := permissionPresets[kind]; !ok || resourceName == "" {
+			return fmt.Errorf("invalid permission preset '%s'. Must be one of: %s, followed by ':<name>'", p.Preset, strings.Join(getPermissionPresetKinds(), ", "))
+		}
+		return nil
+	}
+	if p.GetEffect() != "Allow" && p.GetEffect() != "Deny" {
+		return fmt.Errorf("invalid value '%s' in field 'effect' of a permission. Must be one of: Allow, Deny", p.Effect)
+	}
+	if len(p.Action) == 0 {
+		return errors.New("a permission requires at least one 'action'")
+	}
+	for _, action := range p.Action {
+		if action != "*" && !iamActionRegex.MatchString(action) {
+			return fmt.Errorf("invalid action '%s' in a permission. Must have the form <service>:<action>, for example s3:GetObject", action)
+		}
+	}
+	if len(p.Resource) == 0 {
+		return errors.New("a permission requires at least one 'resource'")
+	}
+	for _, resource := range p.Resource {
+		if resource != "*" && !strings.HasPrefix(resource, "arn:") {
+			return fmt.Errorf("invalid resource '%s' in a permission. Must be an ARN or '*'", resource)
+		}
+	}
+	return nil
+}
+
+// splits the given preset into its kind, like s3:read, and the name of its resource
+func splitPermissionPreset(preset string) (string, string) {
+	parts := strings.SplitN(preset, ":", 3)
+	if len(parts) < 3 {
+		return preset, ""
+	}
+	return parts[0] + ":" + parts[1], parts[2]
+}
+
+func getPermissionPresetKinds() []string {
+	result := []string{}
+	for kind := range permissionPresets {
+		result = append(result, kind)
+	}
+	sort.Strings(result)
+	return result
+}
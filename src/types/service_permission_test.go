@@ -0,0 +1,105 @@
+package types_test
+
+import (
+	"github.com/Originate/exosphere/src/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var _ = Describe("ServicePermission", func() {
+	It("parses presets and IAM statements", func() {
+		var remoteConfig types.ServiceRemoteConfig
+		err := yaml.Unmarshal([]byte(`
+permissions:
+  - s3:read:exports
+  - action: sqs:SendMessage
+    resource:
+      - arn:aws:sqs:us-west-2:12345678:jobs
+`), &remoteConfig)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remoteConfig.Permissions).To(Equal([]types.ServicePermission{
+			{Preset: "s3:read:exports"},
+			{Action: types.StringList{"sqs:SendMessage"}, Resource: types.StringList{"arn:aws:sqs:us-west-2:12345678:jobs"}},
+		}))
+	})
+
+	It("expands presets into statements for the resource in the given region and account", func() {
+		statement := types.ServicePermission{Preset: "sqs:send:jobs"}.GetStatement("us-west-2", "12345678", nil)
+		Expect(statement).To(Equal(types.ServicePermission{
+			Effect:   "Allow",
+			Action:   types.StringList{"sqs:GetQueueAttributes", "sqs:GetQueueUrl", "sqs:SendMessage"},
+			Resource: types.StringList{"arn:aws:sqs:us-west-2:12345678:jobs"},
+		}))
+		statement = types.ServicePermission{Preset: "dynamodb:read:users"}.GetStatement("us-west-2", "12345678", nil)
+		Expect(statement.Resource).To(Equal(types.StringList{
+			"arn:aws:dynamodb:us-west-2:12345678:table/users",
+			"arn:aws:dynamodb:us-west-2:12345678:table/users/index/*",
+		}))
+	})
+
+	It("expands presets for managed resources into statements for their real names", func() {
+		managedResourceNames := map[string]map[string]string{
+			"s3":  {"exports": "example-app-exports"},
+			"sqs": {"exports": "example-app-exports-jobs"},
+		}
+		statement := types.ServicePermission{Preset: "s3:read:exports"}.GetStatement("us-west-2", "12345678", managedResourceNames)
+		Expect(statement.Resource).To(Equal(types.StringList{"arn:aws:s3:::example-app-exports", "arn:aws:s3:::example-app-exports/*"}))
+		statement = types.ServicePermission{Preset: "s3:read:partner-exports"}.GetStatement("us-west-2", "12345678", managedResourceNames)
+		Expect(statement.Resource).To(Equal(types.StringList{"arn:aws:s3:::partner-exports", "arn:aws:s3:::partner-exports/*"}))
+	})
+
+	Describe("validation", func() {
+		It("accepts known presets and valid statements", func() {
+			Expect(types.ServicePermission{Preset: "s3:write:uploads"}.ValidateFields()).To(Succeed())
+			Expect(types.ServicePermission{Effect: "Deny", Action: types.StringList{"s3:*"}, Resource: types.StringList{"*"}}.ValidateFields()).To(Succeed())
+		})
+
+		It("throws an error for unknown presets", func() {
+			err := types.ServicePermission{Preset: "s3:admin:uploads"}.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid permission preset 's3:admin:uploads'. Must be one of: dynamodb:read, dynamodb:write, s3:read, s3:write, sns:publish, sqs:receive, sqs:send, followed by ':<name>'"))
+		})
+
+		It("throws an error for presets without a resource", func() {
+			err := types.ServicePermission{Preset: "s3:read"}.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid permission preset 's3:read'"))
+		})
+
+		It("throws an error for an invalid effect", func() {
+			err := types.ServicePermission{Effect: "Permit", Action: types.StringList{"s3:GetObject"}, Resource: types.StringList{"*"}}.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid value 'Permit' in field 'effect' of a permission. Must be one of: Allow, Deny"))
+		})
+
+		It("throws an error for invalid actions", func() {
+			err := types.ServicePermission{Action: types.StringList{"GetObject"}, Resource: types.StringList{"*"}}.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid action 'GetObject' in a permission. Must have the form <service>:<action>"))
+		})
+
+		It("throws an error for statements without resources", func() {
+			err := types.ServicePermission{Action: types.StringList{"s3:GetObject"}}.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("a permission requires at least one 'resource'"))
+		})
+
+		It("throws an error for resources that are not ARNs", func() {
+			err := types.ServicePermission{Action: types.StringList{"s3:GetObject"}, Resource: types.StringList{"my-bucket"}}.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid resource 'my-bucket' in a permission. Must be an ARN or '*'"))
+		})
+
+		It("validates the permissions with the remote fields of a service", func() {
+			remoteConfig := types.ServiceRemoteConfig{
+				CPU:         "128",
+				Memory:      "128",
+				Permissions: []types.ServicePermission{{Preset: "ec2:run:instances"}},
+			}
+			err := remoteConfig.ValidateRemoteFields("reports", "worker")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("reports/service.yml field 'remote.permissions': invalid permission preset 'ec2:run:instances'"))
+		})
+	})
+})
@@ -3,15 +3,21 @@ package types
 import (
 	"fmt"
 	"reflect"
+
+	"github.com/pkg/errors"
 )
 
 // ServiceRemoteConfig represents production specific configuration for an application
 type ServiceRemoteConfig struct {
 	Dependencies []RemoteDependency
-	URL          string `yaml:"url,omitempty"`
-	CPU          string `yaml:"cpu,omitempty"`
-	Memory       string `yaml:"memory,omitempty"`
-	HealthCheck  string `yaml:"health-check,omitempty"`
+	URL          string               `yaml:"url,omitempty"`
+	CPU          string               `yaml:"cpu,omitempty"`
+	Memory       string               `yaml:"memory,omitempty"`
+	HealthCheck  string               `yaml:"health-check,omitempty"`
+	Deploy       ServiceDeployConfig  `yaml:",omitempty"`
+	Scaling      ServiceScalingConfig `yaml:",omitempty"`
+	Permissions  []ServicePermission  `yaml:",omitempty"`
+	Routes       []ServiceRoute       `yaml:",omitempty"`
 }
 
 // ValidateRemoteFields validates that service.yml contiains the required fields
@@ -22,8 +28,13 @@ func (r ServiceRemoteConfig) ValidateRemoteFields(serviceLocation, protectionLev
 	requiredFields := []string{}
 	switch protectionLevel {
 	case ServiceTypePublic:
-		requiredFields = requiredPublicFields
-	case ServiceTypeWorker:
+		// public services with routes are reached through the shared ALB of the application instead of a URL
+		for _, field := range requiredPublicFields {
+			if field != "URL" || len(r.Routes) == 0 {
+				requiredFields = append(requiredFields, field)
+			}
+		}
+	case ServiceTypeWorker, ServiceTypeScheduled:
 		requiredFields = requiredWorkerFields
 	}
 	for _, field := range requiredFields {
@@ -32,5 +43,19 @@ func (r ServiceRemoteConfig) ValidateRemoteFields(serviceLocation, protectionLev
 			return fmt.Errorf("%s/service.yml missing required field 'remote.%s'", serviceLocation, field)
 		}
 	}
+	if err := r.Deploy.ValidateFields(protectionLevel); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("%s/service.yml", serviceLocation))
+	}
+	if err := r.Scaling.ValidateFields(protectionLevel, r.Deploy); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("%s/service.yml", serviceLocation))
+	}
+	if err := validateRoutes(r.Routes, protectionLevel, r.Deploy); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("%s/service.yml", serviceLocation))
+	}
+	for _, permission := range r.Permissions {
+		if err := permission.ValidateFields(); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("%s/service.yml field 'remote.permissions'", serviceLocation))
+		}
+	}
 	return nil
 }
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var routeHostRegex = regexp.MustCompile(`^(\*\.)?[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+// ServiceRoute represents the requests the shared ALB of the application forwards to a public service
+// as provided under remote.routes in service.yml
+type ServiceRoute struct {
+	Host     string `yaml:",omitempty"`
+	Path     string `yaml:",omitempty"`
+	Priority int    `yaml:",omitempty"`
+}
+
+// GetHost returns the host pattern of the route, defaulting to the URL of the application
+func (r ServiceRoute) GetHost(appURL string) string {
+	if r.Host == "" {
+		return appURL
+	}
+	return r.Host
+}
+
+// GetPathPattern returns the pattern of the ALB listener rule matching the paths starting with the path of the route.
+// A path ending with a slash therefore does not match the path without it, /api/ matches /api/users but not /api
+func (r ServiceRoute) GetPathPattern() string {
+	if r.Path == "" {
+		return "/*"
+	}
+	return r.Path + "*"
+}
+
+// GetOrigin returns the origin the route is reachable at, which includes its path
+func (r ServiceRoute) GetOrigin(appURL string) string {
+	return fmt.Sprintf("https://%s%s", r.GetHost(appURL), strings.TrimSuffix(r.Path, "/"))
+}
+
+// ValidateFields validates that the route contains valid fields
+func (r ServiceRoute) ValidateFields() error {
+	if r.Host != "" && !routeHostRegex.MatchString(r.Host) {
+		return fmt.Errorf("invalid host '%s' in a route. Must be a lowercase domain name, optionally starting with '*.'", r.Host)
+	}
+	if r.Path != "" && (!strings.HasPrefix(r.Path, "/") || strings.ContainsAny(r.Path, "*? ")) {
+		return fmt.Errorf("invalid path '%s' in a route. Must be a path prefix starting with '/'", r.Path)
+	}
+	if r.Priority < 1 || r.Priority > 50000 {
+		return fmt.Errorf("invalid priority %d in a route. Must be between 1 and 50000", r.Priority)
+	}
+	return nil
+}
+
+// ValidateServiceRoutes validates that the routes of the given services can share the ALB of the application
+// with the given URL: their priorities must be unique and their hosts must be within the domain of the application
+// without conflicting with the URL of a service that has its own ALB
+func ValidateServiceRoutes(appURL string, serviceRemoteConfigs map[string]ServiceRemoteConfig) error {
+	serviceRoles := []string{}
+	for serviceRole := range serviceRemoteConfigs {
+		serviceRoles = append(serviceRoles, serviceRole)
+	}
+	sort.Strings(serviceRoles)
+	serviceURLs := map[string]string{}
+	for _, serviceRole := range serviceRoles {
+		if remoteConfig := serviceRemoteConfigs[serviceRole]; len(remoteConfig.Routes) == 0 && remoteConfig.URL != "" {
+			serviceURLs[remoteConfig.URL] = serviceRole
+		}
+	}
+	priorities := map[int]string{}
+	for _, serviceRole := range serviceRoles {
+		for _, route := range serviceRemoteConfigs[serviceRole].Routes {
+			if otherRole, ok := priorities[route.Priority]; ok {
+				return fmt.Errorf("the routes of %s and %s have the same priority %d", otherRole, serviceRole, route.Priority)
+			}
+			priorities[route.Priority] = serviceRole
+			host := route.GetHost(appURL)
+			if host != appURL && !strings.HasSuffix(host, "."+appURL) {
+				return fmt.Errorf("the host '%s' of a route of %s is not within the domain of the application '%s'", host, serviceRole, appURL)
+			}
+			if otherRole, ok := serviceURLs[host]; ok {
+				return fmt.Errorf("the host '%s' of a route of %s is the URL of %s, which has its own load balancer", host, serviceRole, otherRole)
+			}
+		}
+	}
+	return nil
+}
+
+// validates the routes of a service of the given type, which replace the URL and the load balancer of the service
+func validateRoutes(routes []ServiceRoute, serviceType string, deployConfig ServiceDeployConfig) error {
+	if len(routes) == 0 {
+		return nil
+	}
+	if serviceType != ServiceTypePublic {
+		return errors.New("'remote.routes' require a public service")
+	}
+	if deployConfig.ShiftsTraffic() {
+		return fmt.Errorf("'remote.routes' are not supported with the %s strategy", deployConfig.Strategy)
+	}
+	for _, route := range routes {
+		if err := route.ValidateFields(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
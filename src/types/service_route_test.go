@@ -0,0 +1,135 @@
+package types_test
+
+import (
+	"github.com/Originate/exosphere/src/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var _ = Describe("ServiceRoute", func() {
+	It("parses routes", func() {
+		var remoteConfig types.ServiceRemoteConfig
+		err := yaml.Unmarshal([]byte(`
+routes:
+  - path: /api/
+    priority: 10
+  - host: admin.example.com
+    priority: 20
+`), &remoteConfig)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remoteConfig.Routes).To(Equal([]types.ServiceRoute{
+			{Path: "/api/", Priority: 10},
+			{Host: "admin.example.com", Priority: 20},
+		}))
+	})
+
+	It("defaults the host to the URL of the application and the path to all paths", func() {
+		route := types.ServiceRoute{Priority: 1}
+		Expect(route.GetHost("example.com")).To(Equal("example.com"))
+		Expect(route.GetPathPattern()).To(Equal("/*"))
+		Expect(route.GetOrigin("example.com")).To(Equal("https://example.com"))
+	})
+
+	It("includes the path in the path pattern and the origin", func() {
+		route := types.ServiceRoute{Host: "api.example.com", Path: "/v1/", Priority: 1}
+		Expect(route.GetPathPattern()).To(Equal("/v1/*"))
+		Expect(types.ServiceRoute{Path: "/v1", Priority: 1}.GetPathPattern()).To(Equal("/v1*"))
+		Expect(route.GetOrigin("example.com")).To(Equal("https://api.example.com/v1"))
+	})
+
+	Describe("validation", func() {
+		It("accepts valid routes", func() {
+			Expect(types.ServiceRoute{Host: "*.example.com", Path: "/api", Priority: 1}.ValidateFields()).To(Succeed())
+		})
+
+		It("throws an error for invalid hosts", func() {
+			err := types.ServiceRoute{Host: "https://example.com", Priority: 1}.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid host 'https://example.com' in a route"))
+		})
+
+		It("throws an error for paths that are not prefixes", func() {
+			err := types.ServiceRoute{Path: "api/*", Priority: 1}.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid path 'api/*' in a route. Must be a path prefix starting with '/'"))
+		})
+
+		It("throws an error for missing priorities", func() {
+			err := types.ServiceRoute{Path: "/api"}.ValidateFields()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid priority 0 in a route. Must be between 1 and 50000"))
+		})
+
+		It("does not require the URL of public services with routes", func() {
+			remoteConfig := types.ServiceRemoteConfig{
+				CPU:         "128",
+				Memory:      "128",
+				HealthCheck: "/health",
+				Routes:      []types.ServiceRoute{{Path: "/api", Priority: 1}},
+			}
+			Expect(remoteConfig.ValidateRemoteFields("api", types.ServiceTypePublic)).To(Succeed())
+		})
+
+		It("still requires the other fields of public services with routes", func() {
+			remoteConfig := types.ServiceRemoteConfig{
+				CPU:    "128",
+				Memory: "128",
+				Routes: []types.ServiceRoute{{Path: "/api", Priority: 1}},
+			}
+			err := remoteConfig.ValidateRemoteFields("api", types.ServiceTypePublic)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("api/service.yml missing required field 'remote.HealthCheck'"))
+		})
+
+		It("throws an error for routes of services that are not public", func() {
+			remoteConfig := types.ServiceRemoteConfig{
+				CPU:    "128",
+				Memory: "128",
+				Routes: []types.ServiceRoute{{Path: "/api", Priority: 1}},
+			}
+			err := remoteConfig.ValidateRemoteFields("reports", types.ServiceTypeWorker)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("reports/service.yml: 'remote.routes' require a public service"))
+		})
+
+		It("throws an error for routes of services that shift traffic", func() {
+			remoteConfig := types.ServiceRemoteConfig{
+				CPU:         "128",
+				Memory:      "128",
+				HealthCheck: "/health",
+				Deploy:      types.ServiceDeployConfig{Strategy: types.DeployStrategyBlueGreen},
+				Routes:      []types.ServiceRoute{{Path: "/api", Priority: 1}},
+			}
+			err := remoteConfig.ValidateRemoteFields("api", types.ServiceTypePublic)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'remote.routes' are not supported with the blue-green strategy"))
+		})
+
+		It("throws an error for routes with the same priority", func() {
+			err := types.ValidateServiceRoutes("example.com", map[string]types.ServiceRemoteConfig{
+				"api": {Routes: []types.ServiceRoute{{Path: "/api", Priority: 5}}},
+				"web": {Routes: []types.ServiceRoute{{Priority: 5}}},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the routes of api and web have the same priority 5"))
+		})
+
+		It("throws an error for hosts outside the domain of the application", func() {
+			err := types.ValidateServiceRoutes("example.com", map[string]types.ServiceRemoteConfig{
+				"api": {Routes: []types.ServiceRoute{{Host: "api.other.com", Priority: 1}}},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the host 'api.other.com' of a route of api is not within the domain of the application 'example.com'"))
+		})
+
+		It("throws an error for hosts that are the URL of a service with its own load balancer", func() {
+			err := types.ValidateServiceRoutes("example.com", map[string]types.ServiceRemoteConfig{
+				"api":   {Routes: []types.ServiceRoute{{Host: "admin.example.com", Priority: 1}}},
+				"admin": {URL: "admin.example.com"},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the host 'admin.example.com' of a route of api is the URL of admin, which has its own load balancer"))
+		})
+	})
+})
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Originate/exosphere/src/util"
+	"github.com/pkg/errors"
+)
+
+// Metrics the scaling policies of a service can track
+const (
+	ScalingMetricCPU        = "cpu"
+	ScalingMetricMemory     = "memory"
+	ScalingMetricRequests   = "requests"
+	ScalingMetricQueueDepth = "queue-depth"
+)
+
+// ServiceScalingConfig represents the number of tasks of a service
+// as provided under remote.scaling in service.yml
+type ServiceScalingConfig struct {
+	Min      int                    `yaml:",omitempty"`
+	Max      int                    `yaml:",omitempty"`
+	Desired  int                    `yaml:",omitempty"`
+	Policies []ServiceScalingPolicy `yaml:",omitempty"`
+}
+
+// ServiceScalingPolicy represents a target tracking policy, which adds or removes tasks
+// so that the given metric stays close to the target value
+type ServiceScalingPolicy struct {
+	Metric string `yaml:",omitempty"`
+	Target int    `yaml:",omitempty"`
+	Queue  string `yaml:",omitempty"`
+}
+
+// GetMin returns the minimum number of tasks, defaulting to 1
+func (s ServiceScalingConfig) GetMin() int {
+	if s.Min == 0 {
+		return 1
+	}
+	return s.Min
+}
+
+// GetMax returns the maximum number of tasks, defaulting to the minimum
+func (s ServiceScalingConfig) GetMax() int {
+	if s.Max == 0 {
+		return s.GetMin()
+	}
+	return s.Max
+}
+
+// GetDesired returns the number of tasks a deploy starts with, defaulting to the minimum
+func (s ServiceScalingConfig) GetDesired() int {
+	if s.Desired == 0 {
+		return s.GetMin()
+	}
+	return s.Desired
+}
+
+// ValidateFields validates that the scaling config of a service of the given type contains valid fields
+// nolint gocyclo
+func (s ServiceScalingConfig) ValidateFields(serviceType string, deployConfig ServiceDeployConfig) error {
+	if s.Min < 0 || s.Max < 0 || s.Desired < 0 {
+		return errors.New("the counts in 'remote.scaling' must be positive")
+	}
+	if serviceType == ServiceTypeScheduled && (s.Min != 0 || s.Max != 0 || s.Desired != 0 || len(s.Policies) > 0) {
+		return errors.New("'remote.scaling' is not supported for scheduled services, which run a single task on each run")
+	}
+	if s.GetMax() < s.GetMin() {
+		return fmt.Errorf("'remote.scaling.max' (%d) must not be less than 'remote.scaling.min' (%d)", s.GetMax(), s.GetMin())
+	}
+	if s.GetDesired() < s.GetMin() || s.GetDesired() > s.GetMax() {
+		return fmt.Errorf("'remote.scaling.desired' (%d) must be between 'remote.scaling.min' (%d) and 'remote.scaling.max' (%d)", s.GetDesired(), s.GetMin(), s.GetMax())
+	}
+	if len(s.Policies) == 0 {
+		return nil
+	}
+	if s.GetMax() == s.GetMin() {
+		return errors.New("'remote.scaling.policies' require 'remote.scaling.max' to be greater than 'remote.scaling.min'")
+	}
+	if deployConfig.ShiftsTraffic() {
+		return fmt.Errorf("'remote.scaling.policies' are not supported with the %s strategy", deployConfig.Strategy)
+	}
+	validMetrics := []string{ScalingMetricCPU, ScalingMetricMemory, ScalingMetricRequests, ScalingMetricQueueDepth}
+	policyNames := []string{}
+	for _, policy := range s.Policies {
+		if !util.DoesStringArrayContain(validMetrics, policy.Metric) {
+			return fmt.Errorf("invalid value '%s' in field 'remote.scaling.policies.metric'. Must be one of: %s", policy.Metric, strings.Join(validMetrics, ", "))
+		}
+		if policy.Target <= 0 {
+			return fmt.Errorf("the %s scaling policy requires a positive 'target'", policy.Metric)
+		}
+		switch policy.Metric {
+		case ScalingMetricCPU, ScalingMetricMemory:
+			if policy.Target > 100 {
+				return fmt.Errorf("the target of the %s scaling policy is a percentage, got %d", policy.Metric, policy.Target)
+			}
+		case ScalingMetricRequests:
+			if serviceType != ServiceTypePublic {
+				return errors.New("the requests scaling policy requires a public service")
+			}
+		case ScalingMetricQueueDepth:
+			if policy.Queue == "" {
+				return errors.New("the queue-depth scaling policy requires a 'queue'")
+			}
+		}
+		if policy.Queue != "" && policy.Metric != ScalingMetricQueueDepth {
+			return fmt.Errorf("the %s scaling policy does not track a queue", policy.Metric)
+		}
+		if util.DoesStringArrayContain(policyNames, policy.GetName()) {
+			return fmt.Errorf("duplicate %s scaling policy", policy.Metric)
+		}
+		policyNames = append(policyNames, policy.GetName())
+	}
+	return nil
+}
+
+// GetName returns a name identifying the policy among the policies of a service
+func (p ServiceScalingPolicy) GetName() string {
+	if p.Queue != "" {
+		return fmt.Sprintf("%s-%s", p.Metric, p.Queue)
+	}
+	return p.Metric
+}
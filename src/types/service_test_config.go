@@ -0,0 +1,35 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// ServiceTestConfig represents the configuration of the tests for a service
+type ServiceTestConfig struct {
+	Timeout string `yaml:",omitempty"`
+	Retries int    `yaml:",omitempty"`
+}
+
+// GetTimeout returns the timeout for the tests, or 0 if no timeout is configured
+func (s ServiceTestConfig) GetTimeout() time.Duration {
+	timeout, err := time.ParseDuration(s.Timeout)
+	if err != nil {
+		return 0
+	}
+	return timeout
+}
+
+// ValidateFields validates that the test section contains valid fields
+func (s ServiceTestConfig) ValidateFields() error {
+	if s.Timeout != "" {
+		timeout, err := time.ParseDuration(s.Timeout)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("Invalid value '%s' in service.yml field 'development.test.timeout'. Must be a positive duration such as '30s' or '5m'", s.Timeout)
+		}
+	}
+	if s.Retries < 0 {
+		return fmt.Errorf("Invalid value '%d' in service.yml field 'development.test.retries'. Must not be negative", s.Retries)
+	}
+	return nil
+}
@@ -2,6 +2,11 @@ package types
 
 // TestResult represents the result of a test
 type TestResult struct {
-	Passed      bool
-	Interrupted bool
+	Passed      bool                    `json:"passed"`
+	Interrupted bool                    `json:"interrupted"`
+	TimedOut    bool                    `json:"timedOut,omitempty"`
+	Failed      []string                `json:"failed"`
+	Flaky       []string                `json:"flaky"`
+	Cached      []string                `json:"cached"`
+	Contracts   []MessageContractReport `json:"contracts,omitempty"`
 }
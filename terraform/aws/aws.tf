@@ -40,12 +40,15 @@ module "alb_security_groups" {
 module "ecs_cluster" {
   source = "./ecs-cluster"
 
-  name          = "${var.env}-${var.name}"
-  env           = "${var.env}"
-  region        = "${data.aws_region.current.name}"
-  instance_type = "${var.ecs_instance_type}"
-  ebs_optimized = "${var.ecs_ebs_optimized}"
-  key_name      = "${var.key_name}"
+  name             = "${var.env}-${var.name}"
+  env              = "${var.env}"
+  region           = "${data.aws_region.current.name}"
+  instance_type    = "${var.ecs_instance_type}"
+  ebs_optimized    = "${var.ecs_ebs_optimized}"
+  key_name         = "${var.key_name}"
+  min_size         = "${var.ecs_min_size}"
+  max_size         = "${var.ecs_max_size}"
+  desired_capacity = "${var.ecs_min_size}"
 
   alb_security_groups = ["${module.alb_security_groups.internal_id}",
     "${module.alb_security_groups.external_id}",
@@ -0,0 +1,59 @@
+resource "aws_elasticache_replication_group" "redis" {
+  replication_group_id          = "${var.replication_group_id}"
+  replication_group_description = "Redis replication group ${var.name} of ${var.app_name}"
+  automatic_failover_enabled    = "${var.num_cache_clusters > 1}"
+  engine                        = "redis"
+  engine_version                = "${var.engine_version}"
+  node_type                     = "${var.node_type}"
+  number_cache_clusters         = "${var.num_cache_clusters}"
+  port                          = 6379
+  security_group_ids            = ["${aws_security_group.redis.id}"]
+  subnet_group_name             = "${aws_elasticache_subnet_group.redis_group.name}"
+
+  tags {
+    Name        = "${var.env}-${var.app_name}-${var.name}"
+    Environment = "${var.env}"
+  }
+}
+
+resource "aws_elasticache_subnet_group" "redis_group" {
+  name       = "${var.env}-${var.app_name}-${var.name}"
+  subnet_ids = ["${var.subnet_ids}"]
+}
+
+resource "aws_route53_record" "redis" {
+  zone_id = "${var.internal_hosted_zone_id}"
+  name    = "${var.name}"
+  type    = "CNAME"
+  ttl     = "300"
+  records = ["${aws_elasticache_replication_group.redis.primary_endpoint_address}"]
+}
+
+resource "aws_security_group" "redis" {
+  name        = "${var.env}-${var.app_name}-${var.name}-elasticache"
+  vpc_id      = "${var.vpc_id}"
+  description = "Allows traffic from ECS cluster"
+
+  ingress {
+    from_port       = 6379
+    to_port         = 6379
+    protocol        = "tcp"
+    security_groups = ["${var.ecs_security_group}"]
+  }
+
+  ingress {
+    from_port       = 6379
+    to_port         = 6379
+    protocol        = "tcp"
+    security_groups = ["${var.bastion_security_group}"]
+  }
+
+  tags {
+    Name        = "${var.env}-${var.app_name}-${var.name}-elasticache"
+    Environment = "${var.env}"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
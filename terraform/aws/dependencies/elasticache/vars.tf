@@ -0,0 +1,58 @@
+/* Variables */
+
+variable "app_name" {
+  description = "Name of the application, used for naming and prefixing"
+}
+
+variable "bastion_security_group" {
+  description = "Security group ID of bastion instances to allow connections from"
+}
+
+variable "ecs_security_group" {
+  description = "Security group ID of ECS cluster from which to allow traffic"
+}
+
+variable "engine_version" {
+  description = "Redis version"
+}
+
+variable "env" {
+  description = "Name of the environment, used for naming and prefixing"
+}
+
+variable "internal_hosted_zone_id" {
+  description = "Route53 Hosted Zone id used for internal routing"
+}
+
+variable "name" {
+  description = "Name of the Redis dependency, used as its internal DNS name and for naming"
+}
+
+variable "node_type" {
+  description = "Instance type of the cache nodes, e.g. cache.t2.micro"
+}
+
+variable "num_cache_clusters" {
+  description = "Number of cache nodes (one primary plus replicas)"
+  default     = 1
+}
+
+variable "replication_group_id" {
+  description = "ID of the replication group, at most 20 characters"
+}
+
+variable "subnet_ids" {
+  description = "List of subnet IDs the cache nodes should live in"
+  type        = "list"
+}
+
+variable "vpc_id" {
+  description = "ID of the main VPC"
+}
+
+/* Output */
+
+output "endpoint" {
+  description = "Connection endpoint of the primary node"
+  value       = "${aws_elasticache_replication_group.redis.primary_endpoint_address}"
+}
@@ -0,0 +1,20 @@
+#cloud-config
+bootcmd:
+  - echo 'SERVER_ENVIRONMENT=${environment}' >> /etc/environment
+  - echo 'SERVER_GROUP=${name}' >> /etc/environment
+  - echo 'SERVER_REGION=${region}' >> /etc/environment
+
+  - mkdir -p /etc/ecs
+  - echo 'ECS_CLUSTER=${name}' >> /etc/ecs/ecs.config
+  - echo 'ECS_ENGINE_AUTH_TYPE=${docker_auth_type}' >> /etc/ecs/ecs.config
+  - >
+    echo 'ECS_ENGINE_AUTH_DATA=${docker_auth_data}' >> /etc/ecs/ecs.config
+
+# the config NATS servers read when clients need to authenticate,
+# the token itself is passed in the environment of the container
+write_files:
+  - path: /etc/nats/auth.conf
+    content: |
+      authorization {
+        token: $NATS_AUTH_TOKEN
+      }
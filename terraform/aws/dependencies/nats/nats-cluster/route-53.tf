@@ -0,0 +1,7 @@
+resource "aws_route53_record" "nats" {
+  zone_id = "${var.internal_hosted_zone_id}"
+  name    = "${var.name}"
+  type    = "A"
+  ttl     = "300"
+  records = ["${aws_instance.nats.*.private_ip}"]
+}
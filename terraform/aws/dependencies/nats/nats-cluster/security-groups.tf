@@ -0,0 +1,42 @@
+resource "aws_security_group" "nats_cluster" {
+  name        = "${var.env}-${var.app_name}-${var.name}-ecs-cluster"
+  vpc_id      = "${var.vpc_id}"
+  description = "Allows traffic from and to the EC2 instances of the NATS ECS cluster"
+
+  ingress {
+    from_port       = 22
+    to_port         = 22
+    protocol        = "tcp"
+    security_groups = ["${var.bastion_security_group}"]
+  }
+
+  ingress {
+    from_port       = 0
+    to_port         = 0
+    protocol        = -1
+    security_groups = ["${var.ecs_cluster_security_groups}"]
+  }
+
+  ingress {
+    from_port = 6222
+    to_port   = 6222
+    protocol  = "tcp"
+    self      = true
+  }
+
+  egress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = -1
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  tags {
+    Name        = "ECS cluster (NATS)"
+    Environment = "${var.env}"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,145 @@
+/* Variables */
+
+variable "app_name" {
+  description = "Name of the application, used for naming and prefixing"
+}
+
+variable "availability_zones" {
+  description = "List of AZs"
+  type        = "list"
+}
+
+variable "bastion_security_group" {
+  description = "ID of the security group of the bastion hosts"
+  type        = "list"
+}
+
+variable "cluster_size" {
+  description = "Number of NATS servers in the cluster"
+  default     = 1
+}
+
+variable "desired_capacity" {
+  description = "Desired instance count"
+  default     = 3
+}
+
+variable "docker_auth_data" {
+  description = "A JSON object providing the docker auth data, see https://godoc.org/github.com/aws/amazon-ecs-agent/agent/engine/dockerauth for the supported formats"
+  default     = ""
+}
+
+variable "docker_auth_type" {
+  description = "The docker auth type, see https://godoc.org/github.com/aws/amazon-ecs-agent/agent/engine/dockerauth for the possible values"
+  default     = ""
+}
+
+variable "docker_volume_size" {
+  description = "Attached EBS volume size in GB"
+  default     = 25
+}
+
+variable "ebs_optimized" {
+  description = "Boolean indicating if cluster instances are ebs optimized"
+  default     = false
+}
+
+variable "env" {
+  description = "Environment tag, e.g prod"
+}
+
+variable "extra_cloud_config_content" {
+  description = "Extra cloud config content"
+  default     = ""
+}
+
+variable "extra_cloud_config_type" {
+  description = "Extra cloud config type"
+  default     = "text/cloud-config"
+}
+
+variable "high_cpu_threshold" {
+  description = "If CPU usage is above this threshold for 5min, scale up"
+  default     = 90
+}
+
+variable "high_memory_threshold" {
+  description = "If CPU usage is above this threshold for 5min, scale up"
+  default     = 90
+}
+
+variable "instance_type" {
+  description = "The instance type to use, e.g t2.small"
+}
+
+variable "internal_hosted_zone_id" {
+  description = "Route53 Hosted Zone id used for internal routing"
+}
+
+variable "key_name" {
+  description = "Name of key pair stored in AWS to authorize for the bastion hosts"
+}
+
+variable "low_cpu_threshold" {
+  description = "If CPU usage is below this threshold for 5min, scale down"
+  default     = 10
+}
+
+variable "low_memory_threshold" {
+  description = "If CPU usage is below this threshold for 5min, scale down"
+  default     = 10
+}
+
+variable "max_size" {
+  description = "Maxmimum instance count"
+  default     = 100
+}
+
+variable "min_size" {
+  description = "Minimum instance count"
+  default     = 3
+}
+
+variable "name" {
+  description = "The cluster name, e.g cdn"
+}
+
+variable "region" {
+  description = "Region of the environment, for example, us-west-2"
+}
+
+variable "root_volume_size" {
+  description = "Root volume size in GB"
+  default     = 25
+}
+
+variable "ecs_cluster_security_groups" {
+  description = "Comma separated list of security groups"
+  type        = "list"
+}
+
+variable "subnet_ids" {
+  description = "List of private subnet IDs"
+  type        = "list"
+}
+
+variable "vpc_id" {
+  description = "VPC ID"
+}
+
+/* Output */
+
+output "cluster_id" {
+  description = "ID of main cluster"
+  value       = "${aws_ecs_cluster.nats.id}"
+}
+
+output "security_groups" {
+  description = "Cluster sg ids"
+  value       = ["${aws_security_group.nats_cluster.id}"]
+}
+
+output "nats_address" {
+  description = "Address resolving to all NATS instances"
+  value       = "${aws_route53_record.nats.fqdn}"
+}
@@ -0,0 +1,32 @@
+// the servers read the auth token from the environment through the config file of the cluster instances,
+// so that it doesn't show up in the command of the task
+locals {
+  auth_args                 = "${var.auth_token == "" ? "" : "--config,/etc/nats/auth.conf"}"
+  auth_environment_variable = "{\"name\": \"NATS_AUTH_TOKEN\", \"value\": ${jsonencode(var.auth_token)}}"
+  environment_variables     = "${var.auth_token == "" ? var.environment_variables : replace(var.environment_variables, "/^\\[/", "[${local.auth_environment_variable}${var.environment_variables == "[]" ? "" : ","}")}"
+}
+
+module "task_definition" {
+  source = "./nats-task-definition"
+
+  command               = ["${compact(concat(list("--cluster", "nats://0.0.0.0:6222", "--routes", "nats://${var.dns_name}:6222"), split(",", local.auth_args)))}"]
+  cpu_units             = "${var.cpu_units}"
+  docker_image          = "${var.docker_image}"
+  env                   = "${var.env}"
+  environment_variables = "${local.environment_variables}"
+  memory_reservation    = "${var.memory_reservation}"
+  name                  = "${var.app_name}-${var.name}"
+  region                = "${var.region}"
+}
+
+resource "aws_ecs_service" "service" {
+  name                               = "${var.name}"
+  cluster                            = "${var.cluster_id}"
+  deployment_minimum_healthy_percent = 50
+  desired_count                      = "${var.cluster_size}"
+  task_definition                    = "${module.task_definition.task_arn}"
+
+  placement_constraints {
+    type = "distinctInstance"
+  }
+}
@@ -0,0 +1,43 @@
+resource "aws_ecs_task_definition" "task" {
+  family = "${var.name}"
+
+  container_definitions = <<EOF
+[{
+  "name": "${var.name}",
+  "image": "${var.docker_image}",
+  "command": ${jsonencode(var.command)},
+  "cpu": ${var.cpu_units},
+  "memoryReservation": ${var.memory_reservation},
+  "portMappings": [{
+    "containerPort": ${var.client_port},
+    "hostPort": ${var.client_port}
+  }, {
+    "containerPort": ${var.cluster_port},
+    "hostPort": ${var.cluster_port}
+  }],
+  "environment": ${var.environment_variables},
+  "mountPoints": [{
+    "sourceVolume": "config",
+    "containerPath": "/etc/nats",
+    "readOnly": true
+  }],
+  "logConfiguration": {
+    "logDriver": "awslogs",
+    "options": {
+      "awslogs-region": "${var.region}",
+      "awslogs-group": "${aws_cloudwatch_log_group.log_group.name}"
+    }
+  },
+  "essential": true
+}]
+EOF
+
+  volume {
+    name      = "config"
+    host_path = "/etc/nats"
+  }
+}
+
+resource "aws_cloudwatch_log_group" "log_group" {
+  name = "services/${var.env}/${var.name}"
+}
@@ -0,0 +1,51 @@
+/* Variables */
+
+variable "client_port" {
+  description = "Port number clients connect to"
+  default     = 4222
+}
+
+variable "cluster_port" {
+  description = "Port number the servers of the cluster connect to each other on"
+  default     = 6222
+}
+
+variable "command" {
+  description = "Starting command to run in container"
+  type        = "list"
+}
+
+variable "cpu_units" {
+  description = "Number of cpu units to reserve for the container"
+}
+
+variable "docker_image" {
+  description = "ECS repository URI of Docker image"
+}
+
+variable "env" {
+  description = "Name of the environment, used for naming and prefixing"
+}
+
+variable "environment_variables" {
+  description = "Environment variables to pass to a container"
+}
+
+variable "memory_reservation" {
+  description = "Soft limit (in MiB) of memory to reserve for the container"
+}
+
+variable "name" {
+  description = "Name of the service"
+}
+
+variable "region" {
+  description = "Region of the environment, for example, us-west-2"
+}
+
+/* Output */
+
+output "task_arn" {
+  value       = "${aws_ecs_task_definition.task.arn}"
+  description = "ARN of task definition to be passed to ECS service"
+}
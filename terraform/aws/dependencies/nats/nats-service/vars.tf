@@ -0,0 +1,52 @@
+/* Variables */
+
+variable "app_name" {
+  description = "Name of the application, used for naming and prefixing"
+}
+
+variable "auth_token" {
+  description = "Token clients need to connect, no authentication if empty"
+  default     = ""
+}
+
+variable "cluster_id" {
+  description = "ID of the ECS cluster"
+}
+
+variable "cluster_size" {
+  description = "Number of NATS servers in the cluster"
+  default     = 1
+}
+
+variable "cpu_units" {
+  description = "Number of cpu units to reserve for the container"
+}
+
+variable "dns_name" {
+  description = "DNS name resolving to all NATS instances, used for the cluster routes"
+}
+
+variable "docker_image" {
+  description = "ECS repository URI of Docker image"
+}
+
+variable "env" {
+  description = "Name of the environment, used for naming and prefixing"
+}
+
+variable "environment_variables" {
+  description = "Environment variables to pass to a container"
+  default     = "[]"
+}
+
+variable "memory_reservation" {
+  description = "Soft limit (in MiB) of memory to reserve for the container"
+}
+
+variable "name" {
+  description = "Name of the service"
+}
+
+variable "region" {
+  description = "Region of the environment, for example, us-west-2"
+}
@@ -115,3 +115,27 @@ resource "aws_iam_role_policy" "ecs_service" {
 }
 EOF
 }
+
+resource "aws_iam_role" "ecs_autoscale" {
+  name = "${var.name}-ecs-autoscale-role"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "application-autoscaling.amazonaws.com"
+      },
+      "Effect": "Allow"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role_policy_attachment" "ecs_autoscale" {
+  role       = "${aws_iam_role.ecs_autoscale.name}"
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AmazonEC2ContainerServiceAutoscaleRole"
+}
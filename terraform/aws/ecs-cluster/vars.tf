@@ -120,6 +120,11 @@ output "ecs_service_iam_role_arn" {
   value       = "${aws_iam_role.ecs_service.arn}"
 }
 
+output "ecs_autoscale_iam_role_arn" {
+  description = "ARN of the IAM role Application Auto Scaling assumes to scale the services"
+  value       = "${aws_iam_role.ecs_autoscale.arn}"
+}
+
 output "security_group" {
   description = "Cluster security group ID"
   value       = "${aws_security_group.cluster.id}"
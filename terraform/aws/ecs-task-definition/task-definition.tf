@@ -1,5 +1,6 @@
 resource "aws_ecs_task_definition" "task" {
-  family = "${var.name}"
+  family        = "${var.name}"
+  task_role_arn = "${aws_iam_role.task.arn}"
 
   lifecycle {
     ignore_changes        = ["image"]
@@ -21,7 +22,8 @@ resource "aws_ecs_task_definition" "task" {
     "logDriver": "awslogs",
     "options": {
       "awslogs-region": "${var.region}",
-      "awslogs-group": "${aws_cloudwatch_log_group.log_group.name}"
+      "awslogs-group": "${aws_cloudwatch_log_group.log_group.name}",
+      "awslogs-stream-prefix": "ecs"
     }
   },
   "essential": true
@@ -1,5 +1,9 @@
 /* Variables */
 
+variable "app_name" {
+  description = "Name of the application, used for naming and prefixing"
+}
+
 variable "command" {
   description = "Starting command to run in container"
   type        = "list"
@@ -45,3 +49,8 @@ output "arn" {
   value       = "${aws_ecs_task_definition.task.arn}"
   description = "ARN of task definition to be passed to ECS service"
 }
+
+output "task_role_name" {
+  value       = "${aws_iam_role.task.name}"
+  description = "Name of the IAM role the containers of the task assume, to attach policies to"
+}
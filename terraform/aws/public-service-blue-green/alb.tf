@@ -0,0 +1,99 @@
+resource "aws_alb" "alb" {
+  name            = "${substr(var.name, 0, length(var.name) <= 32 ? length(var.name) : 31)}"
+  subnets         = ["${var.alb_subnet_ids}"]
+  security_groups = ["${var.alb_security_group}"]
+  internal        = false
+
+  tags {
+    Name        = "${var.name}-lb"
+    Service     = "${var.name}"
+    Environment = "${var.env}"
+  }
+
+  access_logs {
+    bucket = "${var.log_bucket}"
+  }
+}
+
+// One target group per color, the names are shortened so that the suffix fits in 32 characters
+resource "aws_alb_target_group" "blue" {
+  name     = "${substr(var.name, 0, length(var.name) <= 26 ? length(var.name) : 26)}-blue"
+  port     = 80
+  protocol = "HTTP"
+  vpc_id   = "${var.vpc_id}"
+
+  health_check = {
+    path = "${var.health_check_endpoint}"
+
+    healthy_threshold   = 2
+    unhealthy_threshold = 2
+    timeout             = 5
+    interval            = 30
+    matcher             = "200-299" // Allow any 2xx response pass the healthcheck
+  }
+
+  tags {
+    Name        = "${var.name}-blue-target-group"
+    Service     = "${var.name}"
+    Environment = "${var.env}"
+  }
+}
+
+resource "aws_alb_target_group" "green" {
+  name     = "${substr(var.name, 0, length(var.name) <= 26 ? length(var.name) : 26)}-green"
+  port     = 80
+  protocol = "HTTP"
+  vpc_id   = "${var.vpc_id}"
+
+  health_check = {
+    path = "${var.health_check_endpoint}"
+
+    healthy_threshold   = 2
+    unhealthy_threshold = 2
+    timeout             = 5
+    interval            = 30
+    matcher             = "200-299" // Allow any 2xx response pass the healthcheck
+  }
+
+  tags {
+    Name        = "${var.name}-green-target-group"
+    Service     = "${var.name}"
+    Environment = "${var.env}"
+  }
+}
+
+// Forwards the traffic to the live color. Exosphere switches the target groups
+// of both listeners when shifting traffic, so Terraform does not manage them after creation
+resource "aws_alb_listener" "external" {
+  load_balancer_arn = "${aws_alb.alb.arn}"
+  port              = "443"
+  protocol          = "HTTPS"
+  certificate_arn   = "${var.ssl_certificate_arn}"
+
+  default_action {
+    target_group_arn = "${aws_alb_target_group.blue.arn}"
+    type             = "forward"
+  }
+
+  lifecycle {
+    ignore_changes = ["default_action"]
+  }
+}
+
+// Forwards to the idle color, so that the ALB health checks its targets before they receive traffic.
+// The port is not opened by the ALB security group
+resource "aws_alb_listener" "test" {
+  load_balancer_arn = "${aws_alb.alb.arn}"
+  port              = "${var.test_listener_port}"
+  protocol          = "HTTPS"
+  certificate_arn   = "${var.ssl_certificate_arn}"
+
+  default_action {
+    target_group_arn = "${aws_alb_target_group.green.arn}"
+    type             = "forward"
+  }
+
+  lifecycle {
+    ignore_changes = ["default_action"]
+  }
+}
@@ -0,0 +1,129 @@
+resource "aws_iam_role" "task" {
+  name = "${var.app_name}-${var.env}-${var.name}-task-role"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "ecs-tasks.amazonaws.com"
+      },
+      "Effect": "Allow"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_cloudwatch_log_group" "log_group" {
+  name              = "services/${var.env}/${var.env}-${var.name}"
+  retention_in_days = 30
+}
+
+// Both colors share the task role and the log group, so that the policies
+// and the logs of the service do not depend on the live color
+resource "aws_ecs_task_definition" "blue" {
+  family        = "${var.env}-${var.name}-blue"
+  task_role_arn = "${aws_iam_role.task.arn}"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  container_definitions = <<EOF
+[{
+  "name": "${var.env}-${var.name}",
+  "image": "${var.blue_docker_image}",
+  "command": ${jsonencode(var.command)},
+  "cpu": ${var.cpu},
+  "memoryReservation": ${var.memory_reservation},
+  "portMappings": [{"containerPort": ${var.container_port}}],
+  "environment": ${var.environment_variables},
+  "logConfiguration": {
+    "logDriver": "awslogs",
+    "options": {
+      "awslogs-region": "${var.region}",
+      "awslogs-group": "${aws_cloudwatch_log_group.log_group.name}",
+      "awslogs-stream-prefix": "blue"
+    }
+  },
+  "essential": true
+}]
+EOF
+}
+
+resource "aws_ecs_task_definition" "green" {
+  family        = "${var.env}-${var.name}-green"
+  task_role_arn = "${aws_iam_role.task.arn}"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  container_definitions = <<EOF
+[{
+  "name": "${var.env}-${var.name}",
+  "image": "${var.green_docker_image}",
+  "command": ${jsonencode(var.command)},
+  "cpu": ${var.cpu},
+  "memoryReservation": ${var.memory_reservation},
+  "portMappings": [{"containerPort": ${var.container_port}}],
+  "environment": ${var.environment_variables},
+  "logConfiguration": {
+    "logDriver": "awslogs",
+    "options": {
+      "awslogs-region": "${var.region}",
+      "awslogs-group": "${aws_cloudwatch_log_group.log_group.name}",
+      "awslogs-stream-prefix": "green"
+    }
+  },
+  "essential": true
+}]
+EOF
+}
+
+// Exosphere scales the colors when shifting traffic, so Terraform only sets their task count on creation:
+// the blue color serves the traffic of the first deploy
+resource "aws_ecs_service" "blue" {
+  name                               = "${var.name}-blue"
+  cluster                            = "${var.cluster_id}"
+  deployment_minimum_healthy_percent = 100
+  desired_count                      = "${var.desired_count}"
+  task_definition                    = "${aws_ecs_task_definition.blue.arn}"
+  iam_role                           = "${var.ecs_role_arn}"
+
+  load_balancer {
+    container_name   = "${var.env}-${var.name}"
+    container_port   = "${var.container_port}"
+    target_group_arn = "${aws_alb_target_group.blue.id}"
+  }
+
+  lifecycle {
+    ignore_changes = ["desired_count"]
+  }
+
+  depends_on = ["aws_alb_listener.external"]
+}
+
+resource "aws_ecs_service" "green" {
+  name                               = "${var.name}-green"
+  cluster                            = "${var.cluster_id}"
+  deployment_minimum_healthy_percent = 100
+  desired_count                      = 0
+  task_definition                    = "${aws_ecs_task_definition.green.arn}"
+  iam_role                           = "${var.ecs_role_arn}"
+
+  load_balancer {
+    container_name   = "${var.env}-${var.name}"
+    container_port   = "${var.container_port}"
+    target_group_arn = "${aws_alb_target_group.green.id}"
+  }
+
+  lifecycle {
+    ignore_changes = ["desired_count"]
+  }
+
+  depends_on = ["aws_alb_listener.test"]
+}
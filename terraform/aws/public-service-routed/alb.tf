@@ -0,0 +1,45 @@
+resource "aws_alb_target_group" "target_group" {
+  name     = "${substr(var.name, 0, length(var.name) <= 32 ? length(var.name) : 31)}"
+  port     = 80
+  protocol = "HTTP"
+  vpc_id   = "${var.vpc_id}"
+
+  health_check = {
+    path = "${var.health_check_endpoint}"
+
+    healthy_threshold   = 2
+    unhealthy_threshold = 2
+    timeout             = 5
+    interval            = 30
+    matcher             = "200-299" // Allow any 2xx response pass the healthcheck
+  }
+
+  tags {
+    Name        = "${var.name}-target-group"
+    Service     = "${var.name}"
+    Environment = "${var.env}"
+  }
+}
+
+// One rule per route of the service, forwarding the requests matching its host and path
+// from the listener of the shared ALB to the target group of the service
+resource "aws_alb_listener_rule" "route" {
+  count        = "${length(var.route_priorities)}"
+  listener_arn = "${var.listener_arn}"
+  priority     = "${element(var.route_priorities, count.index)}"
+
+  action {
+    type             = "forward"
+    target_group_arn = "${aws_alb_target_group.target_group.arn}"
+  }
+
+  condition {
+    field  = "host-header"
+    values = ["${element(var.route_hosts, count.index)}"]
+  }
+
+  condition {
+    field  = "path-pattern"
+    values = ["${element(var.route_path_patterns, count.index)}"]
+  }
+}
@@ -0,0 +1,104 @@
+/* Variables */
+
+variable "app_name" {
+  description = "Name of the application, used for naming and prefixing"
+}
+
+variable "cluster_id" {
+  description = "ID of the ECS cluster"
+}
+
+variable "command" {
+  description = "Starting command to run in container"
+  type        = "list"
+  default     = []
+}
+
+variable "container_port" {
+  description = "Port number on the container to bind the ALB to"
+}
+
+variable "cpu" {
+  description = "Number of cpu units to reserve for the container"
+}
+
+variable "docker_image" {
+  description = "ECS repository URI of Docker image"
+}
+
+variable "desired_count" {
+  description = "Desired number of tasks to keep running"
+  default = 2
+}
+
+variable "ecs_role_arn" {
+  description = "ARN of the ECS IAM role"
+}
+
+variable "env" {
+  description = "Name of the environment, used for naming and prefixing"
+}
+
+variable "environment_variables" {
+  description = "Environment variables to pass to a container"
+  default     = "[]"
+}
+
+variable "health_check_endpoint" {
+  description = "Endpoint for the alb to hit when performing health checks"
+  default     = "/"
+}
+
+variable "listener_arn" {
+  description = "ARN of the listener of the shared ALB to add the routes to"
+}
+
+variable "alb_arn_suffix" {
+  description = "ARN suffix of the shared ALB, which identifies it in CloudWatch metrics"
+}
+
+variable "memory_reservation" {
+  description = "Soft limit (in MiB) of memory to reserve for the container"
+}
+
+variable "name" {
+  description = "Name of the service"
+}
+
+variable "region" {
+  description = "Region of the environment, for example, us-west-2"
+}
+
+variable "route_hosts" {
+  description = "Host patterns of the routes of the service"
+  type        = "list"
+}
+
+variable "route_path_patterns" {
+  description = "Path patterns of the routes of the service, in the same order as route_hosts"
+  type        = "list"
+}
+
+variable "route_priorities" {
+  description = "Priorities of the listener rules of the routes, in the same order as route_hosts"
+  type        = "list"
+}
+
+variable "vpc_id" {
+  description = "ID of the VPC"
+}
+
+output "task_role_name" {
+  value       = "${module.task_definition.task_role_name}"
+  description = "Name of the IAM role the containers of the service assume, to attach policies to"
+}
+
+output "service_name" {
+  value       = "${aws_ecs_service.service.name}"
+  description = "Name of the ECS service, to scale it"
+}
+
+output "alb_resource_label" {
+  value       = "${var.alb_arn_suffix}/${aws_alb_target_group.target_group.arn_suffix}"
+  description = "Identifies the target group of the shared ALB in the ALBRequestCountPerTarget metric"
+}
@@ -1,6 +1,7 @@
 module "task_definition" {
   source = "../ecs-task-definition"
 
+  app_name              = "${var.app_name}"
   command               = "${var.command}"
   container_port        = "${var.container_port}"
   cpu                   = "${var.cpu}"
@@ -26,5 +27,10 @@ resource "aws_ecs_service" "service" {
     target_group_arn = "${aws_alb_target_group.target_group.id}"
   }
 
+  // the scalable target of the service manages the number of tasks once the service exists
+  lifecycle {
+    ignore_changes = ["desired_count"]
+  }
+
   depends_on = ["aws_alb.alb"]
 }
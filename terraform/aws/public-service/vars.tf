@@ -9,6 +9,10 @@ variable "alb_subnet_ids" {
   type        = "list"
 }
 
+variable "app_name" {
+  description = "Name of the application, used for naming and prefixing"
+}
+
 variable "cluster_id" {
   description = "ID of the ECS cluster"
 }
@@ -93,3 +97,18 @@ variable "ssl_certificate_arn" {
 variable "vpc_id" {
   description = "ID of the VPC"
 }
+
+output "task_role_name" {
+  value       = "${module.task_definition.task_role_name}"
+  description = "Name of the IAM role the containers of the service assume, to attach policies to"
+}
+
+output "service_name" {
+  value       = "${aws_ecs_service.service.name}"
+  description = "Name of the ECS service, to scale it"
+}
+
+output "alb_resource_label" {
+  value       = "${aws_alb.alb.arn_suffix}/${aws_alb_target_group.target_group.arn_suffix}"
+  description = "Identifies the target group of the ALB in the ALBRequestCountPerTarget metric"
+}
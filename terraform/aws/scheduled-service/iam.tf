@@ -0,0 +1,51 @@
+resource "aws_iam_role" "events" {
+  name = "${var.env}-${var.name}-events-role"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "events.amazonaws.com"
+      },
+      "Effect": "Allow"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role_policy" "events_run_task" {
+  name = "${var.env}-${var.name}-run-task"
+  role = "${aws_iam_role.events.id}"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": "ecs:RunTask",
+      "Resource": "${replace(module.task_definition.arn, "/:\\d+$/", ":*")}",
+      "Condition": {
+        "ArnEquals": {
+          "ecs:cluster": "${var.cluster_id}"
+        }
+      }
+    },
+    {
+      "Effect": "Allow",
+      "Action": "iam:PassRole",
+      "Resource": "*",
+      "Condition": {
+        "StringLike": {
+          "iam:PassedToService": "ecs-tasks.amazonaws.com"
+        }
+      }
+    }
+  ]
+}
+EOF
+}
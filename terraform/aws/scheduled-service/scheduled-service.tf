@@ -0,0 +1,30 @@
+module "task_definition" {
+  source = "../ecs-task-definition"
+
+  app_name              = "${var.app_name}"
+  command               = "${var.command}"
+  cpu                   = "${var.cpu}"
+  docker_image          = "${var.docker_image}"
+  env                   = "${var.env}"
+  environment_variables = "${var.environment_variables}"
+  memory_reservation    = "${var.memory_reservation}"
+  name                  = "${var.env}-${var.name}"
+  region                = "${var.region}"
+}
+
+resource "aws_cloudwatch_event_rule" "schedule" {
+  name                = "${var.env}-${var.name}"
+  description         = "Runs the scheduled service ${var.name}"
+  schedule_expression = "${var.schedule_expression}"
+}
+
+resource "aws_cloudwatch_event_target" "task" {
+  rule     = "${aws_cloudwatch_event_rule.schedule.name}"
+  arn      = "${var.cluster_id}"
+  role_arn = "${aws_iam_role.events.arn}"
+
+  ecs_target {
+    task_count          = 1
+    task_definition_arn = "${module.task_definition.arn}"
+  }
+}
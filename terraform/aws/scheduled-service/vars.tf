@@ -0,0 +1,56 @@
+variable "app_name" {
+  description = "Name of the application, used for naming and prefixing"
+}
+
+variable "cluster_id" {
+  description = "ID of the ECS cluster"
+}
+
+variable "command" {
+  description = "Starting command to run in container"
+  type        = "list"
+  default     = []
+}
+
+variable "cpu" {
+  description = "Number of cpu units to reserve for the container"
+}
+
+variable "docker_image" {
+  description = "ECS repository URI of Docker image"
+}
+
+variable "env" {
+  description = "Name of the environment, used for naming and prefixing"
+}
+
+variable "environment_variables" {
+  description = "Environment variables to pass to a container"
+  default     = "[]"
+}
+
+variable "memory_reservation" {
+  description = "Soft limit (in MiB) of memory to reserve for the container"
+}
+
+variable "name" {
+  description = "Name of the service"
+}
+
+variable "region" {
+  description = "Region of the environment, for example, us-west-2"
+}
+
+variable "schedule_expression" {
+  description = "CloudWatch Events schedule expression the task runs on, for example, cron(0 3 * * ? *)"
+}
+
+output "task_role_name" {
+  value       = "${module.task_definition.task_role_name}"
+  description = "Name of the IAM role the containers of the service assume, to attach policies to"
+}
+
+output "rule_name" {
+  value       = "${aws_cloudwatch_event_rule.schedule.name}"
+  description = "Name of the CloudWatch Events rule that runs the task"
+}
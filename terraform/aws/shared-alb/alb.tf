@@ -0,0 +1,41 @@
+resource "aws_alb" "alb" {
+  name            = "${substr(var.name, 0, length(var.name) <= 32 ? length(var.name) : 31)}"
+  subnets         = ["${var.alb_subnet_ids}"]
+  security_groups = ["${var.alb_security_group}"]
+  internal        = false
+
+  tags {
+    Name        = "${var.name}-lb"
+    Environment = "${var.env}"
+  }
+
+  access_logs {
+    bucket = "${var.log_bucket}"
+  }
+}
+
+// Requests no listener rule matches are forwarded to this target group,
+// which has no targets so that they are answered with 503
+resource "aws_alb_target_group" "default" {
+  name     = "${substr(var.name, 0, length(var.name) <= 24 ? length(var.name) : 24)}-default"
+  port     = 80
+  protocol = "HTTP"
+  vpc_id   = "${var.vpc_id}"
+
+  tags {
+    Name        = "${var.name}-default-target-group"
+    Environment = "${var.env}"
+  }
+}
+
+resource "aws_alb_listener" "external" {
+  load_balancer_arn = "${aws_alb.alb.arn}"
+  port              = "443"
+  protocol          = "HTTPS"
+  certificate_arn   = "${var.ssl_certificate_arn}"
+
+  default_action {
+    target_group_arn = "${aws_alb_target_group.default.arn}"
+    type             = "forward"
+  }
+}
@@ -0,0 +1,12 @@
+resource "aws_route53_record" "external" {
+  count   = "${length(var.external_dns_names)}"
+  zone_id = "${var.external_zone_id}"
+  name    = "${element(var.external_dns_names, count.index)}"
+  type    = "A"
+
+  alias {
+    zone_id                = "${aws_alb.alb.zone_id}"
+    name                   = "${aws_alb.alb.dns_name}"
+    evaluate_target_health = false
+  }
+}
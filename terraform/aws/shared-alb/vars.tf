@@ -0,0 +1,51 @@
+/* Variables */
+
+variable "alb_security_group" {
+  description = "ID of external ALB security group"
+}
+
+variable "alb_subnet_ids" {
+  description = "List of public subnet ID's the ALB should live in"
+  type        = "list"
+}
+
+variable "env" {
+  description = "Name of the environment, used for naming and prefixing"
+}
+
+variable "external_dns_names" {
+  description = "External DNS names the routes of the services use, pointed at the ALB"
+  type        = "list"
+}
+
+variable "external_zone_id" {
+  description = "Route53 Hosted Zone id used for external routing"
+}
+
+variable "log_bucket" {
+  description = "S3 bucket id to write ELB logs into"
+}
+
+variable "name" {
+  description = "Name of the ALB"
+}
+
+variable "ssl_certificate_arn" {
+  description = "The ARN of the SSL server certificate, which must cover all external DNS names"
+}
+
+variable "vpc_id" {
+  description = "ID of the VPC"
+}
+
+/* Output */
+
+output "arn_suffix" {
+  value       = "${aws_alb.alb.arn_suffix}"
+  description = "Identifies the ALB in CloudWatch metrics"
+}
+
+output "listener_arn" {
+  value       = "${aws_alb_listener.external.arn}"
+  description = "ARN of the HTTPS listener the routes of the services are added to"
+}
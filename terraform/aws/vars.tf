@@ -10,6 +10,16 @@ variable "ecs_instance_type" {
   default     = "t2.micro"
 }
 
+variable "ecs_max_size" {
+  description = "Maximum number of ECS instances"
+  default     = 100
+}
+
+variable "ecs_min_size" {
+  description = "Minimum number of ECS instances"
+  default     = 3
+}
+
 variable "env" {
   description = "Name of the environment, used for naming and prefixing"
 }
@@ -53,6 +63,11 @@ output "ecs_cluster_security_group" {
   value       = "${module.ecs_cluster.security_group}"
 }
 
+output "ecs_autoscale_iam_role_arn" {
+  description = "ARN of the IAM role Application Auto Scaling assumes to scale the services"
+  value       = "${module.ecs_cluster.ecs_autoscale_iam_role_arn}"
+}
+
 output "ecs_service_iam_role_arn" {
   description = "ARN of ECS service IAM role passed to each service module"
   value       = "${module.ecs_cluster.ecs_service_iam_role_arn}"
@@ -1,3 +1,7 @@
+variable "app_name" {
+  description = "Name of the application, used for naming and prefixing"
+}
+
 variable "cluster_id" {
   description = "ID of the ECS cluster"
 }
@@ -41,3 +45,13 @@ variable "name" {
 variable "region" {
   description = "Region of the environment, for example, us-west-2"
 }
+
+output "task_role_name" {
+  value       = "${module.task_definition.task_role_name}"
+  description = "Name of the IAM role the containers of the service assume, to attach policies to"
+}
+
+output "service_name" {
+  value       = "${aws_ecs_service.service.name}"
+  description = "Name of the ECS service, to scale it"
+}
@@ -1,6 +1,7 @@
 module "task_definition" {
   source = "../ecs-task-definition"
 
+  app_name              = "${var.app_name}"
   command               = "${var.command}"
   cpu                   = "${var.cpu}"
   docker_image          = "${var.docker_image}"
@@ -17,4 +18,9 @@ resource "aws_ecs_service" "service" {
   deployment_minimum_healthy_percent = 100
   desired_count                      = "${var.desired_count}"
   task_definition                    = "${module.task_definition.arn}"
+
+  // the scalable target of the service manages the number of tasks once the service exists
+  lifecycle {
+    ignore_changes = ["desired_count"]
+  }
 }
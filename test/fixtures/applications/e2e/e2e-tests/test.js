@@ -0,0 +1,5 @@
+const http = require('http')
+
+http.get(process.env.WEB_EXTERNAL_ORIGIN, res => {
+  process.exit(res.statusCode === 200 ? 0 : 1)
+}).on('error', () => process.exit(1))
@@ -0,0 +1,12 @@
+http = require('http')
+
+
+requestHandler = function(req, res) {
+  res.writeHead(200, {'Content-Type': 'text/plain'})
+  res.end('e2e example app\n')
+}
+
+
+http.createServer(requestHandler)
+    .listen(8080,
+            function() { console.log('web server running at port 8080') })
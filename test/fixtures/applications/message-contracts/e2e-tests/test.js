@@ -0,0 +1,12 @@
+const http = require('http')
+
+// retries until the web service and the users service it messages are online
+const attempt = function(retries) {
+  const request = http.get(process.env.WEB_EXTERNAL_ORIGIN, res => {
+    process.exit(res.statusCode === 200 ? 0 : 1)
+  })
+  request.on('error', () => retries > 0 ? setTimeout(() => attempt(retries - 1), 1000) : process.exit(1))
+  request.setTimeout(1000, () => request.abort())
+}
+
+attempt(60)
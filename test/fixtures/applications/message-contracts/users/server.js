@@ -0,0 +1,7 @@
+const {bootstrap} = require('exoservice')
+
+bootstrap({
+  'users.list': function(_, {reply}) {
+    reply('users.listed', [{name: 'Jean-Luc Picard'}])
+  }
+})
@@ -0,0 +1,20 @@
+const http = require('http')
+const ExoRelay = require('exorelay')
+
+const exoRelay = new ExoRelay({exocomHost: process.env.EXOCOM_HOST, exocomPort: process.env.EXOCOM_PORT, role: 'web'})
+exoRelay.on('online', () => console.log('web service exorelay online'))
+exoRelay.on('error', err => console.log(`web service exorelay encountered error: ${err}`))
+exoRelay.connect()
+
+
+const requestHandler = function(req, res) {
+  exoRelay.send('users.list', {}, (_, {outcome}) => {
+    res.writeHead(200, {'Content-Type': 'text/plain'})
+    res.end(`web received ${outcome}\n`)
+  })
+}
+
+
+http.createServer(requestHandler)
+    .listen(8080,
+            function() { console.log('web server running at port 8080') })